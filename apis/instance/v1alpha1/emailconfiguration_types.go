@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	xpv2 "github.com/crossplane/crossplane-runtime/v2/apis/common/v2"
+)
+
+// EmailConfigurationParameters represent the desired state of the SonarQube Email/SMTP configuration.
+type EmailConfigurationParameters struct {
+	// Host is the SMTP server host name or IP address.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Host string `json:"host"`
+	// Port is the SMTP server port. SonarQube defaults to 25 when not set.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port *int32 `json:"port,omitempty"`
+	// SecureConnection is the type of secure connection to use with the SMTP server.
+	// Leave it empty to use a plain connection.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=ssl;starttls
+	SecureConnection *string `json:"secureConnection,omitempty"`
+	// Username is the username used to authenticate with the SMTP server.
+	// +kubebuilder:validation:Optional
+	Username *string `json:"username,omitempty"`
+	// PasswordSecretRef references the key of a Secret, in the namespace of the EmailConfiguration, holding the SMTP password.
+	// +kubebuilder:validation:Optional
+	PasswordSecretRef *xpv1.LocalSecretKeySelector `json:"passwordSecretRef,omitempty"`
+	// FromAddress is the email address used as the sender of the emails sent by SonarQube.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	FromAddress *string `json:"fromAddress,omitempty"`
+	// FromName is the display name used as the sender of the emails sent by SonarQube.
+	// +kubebuilder:validation:Optional
+	FromName *string `json:"fromName,omitempty"`
+	// Prefix is prepended to the subject of the emails sent by SonarQube.
+	// +kubebuilder:validation:Optional
+	Prefix *string `json:"prefix,omitempty"`
+	// TestEmail, when set, makes the controller send a test email every time the configuration is applied.
+	// +kubebuilder:validation:Optional
+	TestEmail *EmailConfigurationTestParameters `json:"testEmail,omitempty"`
+}
+
+// EmailConfigurationTestParameters represent the test email sent after the configuration has changed.
+type EmailConfigurationTestParameters struct {
+	// To is the recipient of the test email.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	To string `json:"to"`
+	// Subject of the test email.
+	// +kubebuilder:validation:Optional
+	Subject *string `json:"subject,omitempty"`
+	// Message is the content of the test email.
+	// +kubebuilder:validation:Optional
+	Message *string `json:"message,omitempty"`
+}
+
+// EmailConfigurationObservation are the observable fields of the SonarQube Email/SMTP configuration.
+type EmailConfigurationObservation struct {
+	// FromAddress is the observed sender email address.
+	FromAddress string `json:"fromAddress,omitempty"`
+	// FromName is the observed sender display name.
+	FromName string `json:"fromName,omitempty"`
+	// Prefix is the observed email subject prefix.
+	Prefix string `json:"prefix,omitempty"`
+	// SecuredSettings is the list of secured email settings that currently have a value in SonarQube.
+	// SonarQube never returns the value of secured settings.
+	SecuredSettings []string `json:"securedSettings,omitempty"`
+	// ConfigurationHash is the hash of the last configuration applied by the provider.
+	// It is used to detect changes of the secured settings, including the password, which is identified by the version of its Secret rather than by its value.
+	ConfigurationHash string `json:"configurationHash,omitempty"`
+	// TestEmail is the result of the last test email sent by the provider.
+	TestEmail *EmailConfigurationTestObservation `json:"testEmail,omitempty"`
+}
+
+// EmailConfigurationTestObservation is the result of a test email.
+type EmailConfigurationTestObservation struct {
+	// To is the recipient of the test email.
+	To string `json:"to"`
+	// SentAt is the time at which the test email was sent.
+	SentAt *metav1.Time `json:"sentAt,omitempty"`
+	// Succeeded indicates whether SonarQube managed to send the test email.
+	Succeeded bool `json:"succeeded"`
+	// Message holds the error returned by SonarQube when the test email failed.
+	Message string `json:"message,omitempty"`
+}
+
+// A EmailConfigurationSpec defines the desired state of a EmailConfiguration.
+type EmailConfigurationSpec struct {
+	xpv2.ManagedResourceSpec `json:",inline"`
+
+	ForProvider EmailConfigurationParameters `json:"forProvider"`
+}
+
+// A EmailConfigurationStatus represents the observed state of a EmailConfiguration.
+type EmailConfigurationStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	AtProvider EmailConfigurationObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EmailConfiguration manages the SonarQube Email/SMTP configuration.
+// WARNING: The Email configuration is global to the SonarQube instance, use a single EmailConfiguration resource per instance.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="HOST",type="string",JSONPath=".spec.forProvider.host"
+// +kubebuilder:printcolumn:name="TEST-EMAIL",type="string",JSONPath=".status.atProvider.testEmail.succeeded",priority=1
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced,categories={crossplane,managed,sonarqube}
+type EmailConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EmailConfigurationSpec   `json:"spec"`
+	Status EmailConfigurationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EmailConfigurationList contains a list of EmailConfiguration.
+type EmailConfigurationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []EmailConfiguration `json:"items"`
+}
+
+// EmailConfiguration type metadata.
+var (
+	EmailConfigurationKind             = reflect.TypeFor[EmailConfiguration]().Name()
+	EmailConfigurationGroupKind        = schema.GroupKind{Group: Group, Kind: EmailConfigurationKind}.String()
+	EmailConfigurationKindAPIVersion   = EmailConfigurationKind + "." + SchemeGroupVersion.String()
+	EmailConfigurationGroupVersionKind = SchemeGroupVersion.WithKind(EmailConfigurationKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&EmailConfiguration{}, &EmailConfigurationList{})
+}
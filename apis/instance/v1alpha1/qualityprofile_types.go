@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"reflect"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -27,6 +28,7 @@ import (
 )
 
 // QualityProfileParameters are the configurable fields of a QualityProfile.
+// +kubebuilder:validation:XValidation:rule="!has(self.source) || (has(self.rulesPolicy) && self.rulesPolicy == 'Additive')",message="rulesPolicy must be Additive when source is set, so that the restored rules are kept."
 type QualityProfileParameters struct {
 	// Name is the Display name of the Quality Profile.
 	// +kubebuilder:validation:MaxLength=100
@@ -40,9 +42,152 @@ type QualityProfileParameters struct {
 	// Default indicates whether this Quality Profile is the default one.
 	// +kubebuilder:validation:Optional
 	Default *bool `json:"default,omitempty"`
+	// AdoptExisting allows adopting an existing Quality Profile with the same name and language
+	// when the resource has no external name, instead of creating a new one.
+	// Built-in Quality Profiles are never adopted.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	AdoptExisting *bool `json:"adoptExisting,omitempty"`
 	// Rules is the list of rules to be activated in the Quality Profile.
+	// Rules listed here override the settings of the same rules selected by RuleSelectors.
 	// +kubebuilder:validation:Optional
 	Rules []QualityProfileRuleParameters `json:"rules,omitempty"`
+	// RuleSelectors select rules to be activated in the Quality Profile by querying the rules of its language.
+	// A rule selected by several selectors is activated with the settings of the first one.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	RuleSelectors []QualityProfileRuleSelector `json:"ruleSelectors,omitempty"`
+	// DeactivatedRules is the list of the keys of the rules that must not be active in the Quality Profile,
+	// e.g. rules activated by a parent Quality Profile. They override the rules selected by RuleSelectors and must not be listed in Rules.
+	// A rule inherited from a parent Quality Profile that cannot be deactivated is reset to the settings of the parent
+	// and reported in status.atProvider.ruleConflicts.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	DeactivatedRules []string `json:"deactivatedRules,omitempty"`
+	// RulesPolicy defines how the active rules of the Quality Profile are owned by this resource.
+	// Authoritative (default): the resource owns all the active rules and deactivates the rules that are not desired.
+	// Additive: the resource only owns the rules it has activated itself, tracked in status.atProvider.activatedRules.
+	// Only those are deactivated when they are no longer desired, so the other active rules can be managed elsewhere, e.g. in the UI.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Authoritative;Additive
+	// +kubebuilder:default=Authoritative
+	RulesPolicy *string `json:"rulesPolicy,omitempty"`
+	// DeletionBehavior defines how the projects and child Quality Profiles referencing the Quality Profile are handled when it is deleted.
+	// Reassign (default): the projects using the Quality Profile are reassigned to the default Quality Profile of its language.
+	// Child Quality Profiles block the deletion since SonarQube would delete them along with their parent.
+	// Reparent: the projects are reassigned and the child Quality Profiles are attached to the parent of the Quality Profile.
+	// Block: the deletion is blocked while projects or child Quality Profiles reference the Quality Profile.
+	// The default Quality Profile of a language is never deleted, whatever the behavior.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Reassign;Reparent;Block
+	// +kubebuilder:default=Reassign
+	DeletionBehavior *string `json:"deletionBehavior,omitempty"`
+	// Source restores the Quality Profile from an XML backup, as produced by the SonarQube backup, held in a Secret or a ConfigMap
+	// in the namespace of the QualityProfile. The backup is restored on creation and again whenever its content changes.
+	// The backup must describe a Quality Profile of the same language, named like the Quality Profile once it exists.
+	// The restored rules are kept along the rules of the spec, so rulesPolicy must be Additive.
+	// +kubebuilder:validation:Optional
+	Source *QualityProfileBackupSource `json:"source,omitempty"`
+	// BackupExport exports the XML backup of the Quality Profile to a ConfigMap in the namespace of the QualityProfile
+	// once it is synchronized and whenever its content changes, e.g. to move the Quality Profile to another instance or to keep a snapshot.
+	// The ConfigMap is controlled by the QualityProfile and deleted along with it, an existing ConfigMap not controlled by it is never written.
+	// +kubebuilder:validation:Optional
+	BackupExport *QualityProfileBackupExport `json:"backupExport,omitempty"`
+	// CompareTo compares the Quality Profile with a reference Quality Profile, e.g. the built-in Sonar way,
+	// and reports their differences in status.atProvider.comparison, e.g. the rules added to the reference by a SonarQube upgrade.
+	// +kubebuilder:validation:Optional
+	CompareTo *QualityProfileComparisonTarget `json:"compareTo,omitempty"`
+}
+
+// QualityProfileComparisonTarget identifies the reference Quality Profile a Quality Profile is compared with.
+// +kubebuilder:validation:XValidation:rule="has(self.name) != has(self.key)",message="Exactly one of name or key must be set."
+type QualityProfileComparisonTarget struct {
+	// Name is the name of a Quality Profile of the same language.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	Name *string `json:"name,omitempty"`
+	// Key is the key of a Quality Profile.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	Key *string `json:"key,omitempty"`
+}
+
+// QualityProfileBackupSource references the XML backup a Quality Profile is restored from.
+// +kubebuilder:validation:XValidation:rule="has(self.secretKeyRef) != has(self.configMapKeyRef)",message="Exactly one of secretKeyRef or configMapKeyRef must be set."
+type QualityProfileBackupSource struct {
+	// SecretKeyRef selects a key of a Secret in the namespace of the QualityProfile.
+	// +kubebuilder:validation:Optional
+	SecretKeyRef *xpv1.LocalSecretKeySelector `json:"secretKeyRef,omitempty"`
+	// ConfigMapKeyRef selects a key of a ConfigMap in the namespace of the QualityProfile.
+	// +kubebuilder:validation:Optional
+	ConfigMapKeyRef *LocalConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// QualityProfileBackupExport defines the ConfigMap the XML backup of a Quality Profile is exported to.
+type QualityProfileBackupExport struct {
+	// ConfigMapName is the name of the ConfigMap, it is created if it does not exist.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ConfigMapName string `json:"configMapName"`
+	// Key is the key of the ConfigMap holding the backup.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="backup.xml"
+	Key *string `json:"key,omitempty"`
+}
+
+// QualityProfileRuleSelector selects rules of the language of a Quality Profile.
+// The filters are combined with AND, the values of a filter with OR.
+type QualityProfileRuleSelector struct {
+	// Name identifies the selector in the status of the rules it selects.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// Repositories selects the rules of these repositories, e.g. java or javasecurity.
+	// +kubebuilder:validation:Optional
+	Repositories []string `json:"repositories,omitempty"`
+	// Tags selects the rules having at least one of these tags.
+	// +kubebuilder:validation:Optional
+	Tags []string `json:"tags,omitempty"`
+	// Types selects the rules of these types.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:Enum=CODE_SMELL;BUG;VULNERABILITY;SECURITY_HOTSPOT
+	Types []string `json:"types,omitempty"`
+	// Severities selects the rules with these default severities.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:Enum=INFO;MINOR;MAJOR;CRITICAL;BLOCKER
+	Severities []string `json:"severities,omitempty"`
+	// SoftwareQualities selects the rules impacting these software qualities.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:Enum=MAINTAINABILITY;RELIABILITY;SECURITY
+	SoftwareQualities []string `json:"softwareQualities,omitempty"`
+	// Cwe selects the rules of these CWE identifiers, e.g. 89.
+	// +kubebuilder:validation:Optional
+	Cwe []string `json:"cwe,omitempty"`
+	// OwaspTop10 selects the rules of these OWASP Top 10 2017 categories.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:Enum=a1;a2;a3;a4;a5;a6;a7;a8;a9;a10
+	OwaspTop10 []string `json:"owaspTop10,omitempty"`
+	// OwaspTop102021 selects the rules of these OWASP Top 10 2021 categories.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:Enum=a1;a2;a3;a4;a5;a6;a7;a8;a9;a10
+	OwaspTop102021 []string `json:"owaspTop102021,omitempty"`
+	// Include keeps only the selected rules whose key matches one of these patterns, e.g. java:S1*.
+	// Patterns use the syntax of path.Match.
+	// +kubebuilder:validation:Optional
+	Include []string `json:"include,omitempty"`
+	// Exclude removes the selected rules whose key matches one of these patterns.
+	// Patterns use the syntax of path.Match.
+	// +kubebuilder:validation:Optional
+	Exclude []string `json:"exclude,omitempty"`
+	// Severity is the severity the selected rules are activated with, their default severity if not set.
+	// +kubebuilder:validation:Enum=INFO;MINOR;MAJOR;CRITICAL;BLOCKER
+	// +kubebuilder:validation:Optional
+	Severity *string `json:"severity,omitempty"`
+	// Prioritized marks the selected rules as prioritized.
+	// +kubebuilder:validation:Optional
+	Prioritized *bool `json:"prioritized,omitempty"`
 }
 
 // QualityProfileObservation are the observable fields of a QualityProfile.
@@ -73,6 +218,105 @@ type QualityProfileObservation struct {
 	RulesUpdatedAt *metav1.Time `json:"rulesUpdatedAt,omitempty"`
 	// Rules represents the list of rules activated in the Quality Profile.
 	Rules []QualityProfileRuleObservation `json:"rules,omitempty"`
+	// ActivatedRules is the ledger of the rules activated by this resource when rulesPolicy is Additive.
+	// Only these rules are deactivated when they are no longer desired.
+	ActivatedRules []string `json:"activatedRules,omitempty"`
+	// RuleConflicts are the deactivated rules that stay active because a parent Quality Profile forces their activation.
+	RuleConflicts []QualityProfileRuleConflict `json:"ruleConflicts,omitempty"`
+	// RulesSync reports the changes made by the last synchronization of the rules of the Quality Profile.
+	RulesSync *QualityProfileRulesSyncObservation `json:"rulesSync,omitempty"`
+	// Restore reports the last restoration of the Quality Profile from the backup of its source.
+	Restore *QualityProfileRestoreObservation `json:"restore,omitempty"`
+	// BackupExportTime is the last time the backup of the Quality Profile was exported, the ConfigMap is only written when the backup changes.
+	BackupExportTime *metav1.Time `json:"backupExportTime,omitempty"`
+	// BackupExportRevision identifies the content of the Quality Profile at its last export, i.e. its name and the last update of its rules.
+	// The backup is only fetched again when the Quality Profile changes, the ConfigMap or its key change, or the exported backup is missing.
+	BackupExportRevision string `json:"backupExportRevision,omitempty"`
+	// ChangelogSince is the time from which the changelog of the Quality Profile is looked up for external changes, i.e. the second after its last synchronization.
+	ChangelogSince *metav1.Time `json:"changelogSince,omitempty"`
+	// ExternalChanges are the most recent changes made to the Quality Profile outside of this resource, e.g. in the UI, newest first.
+	// They are looked up in the changelog of the Quality Profile when a drift is detected, and reverted by the synchronization.
+	// +kubebuilder:validation:MaxItems=20
+	ExternalChanges []QualityProfileExternalChange `json:"externalChanges,omitempty"`
+	// Comparison reports the differences between the Quality Profile and the reference Quality Profile of compareTo.
+	Comparison *QualityProfileComparisonObservation `json:"comparison,omitempty"`
+}
+
+// QualityProfileComparisonObservation reports the differences between a Quality Profile and a reference Quality Profile.
+// The key lists are sorted and hold at most 200 keys, the counts are never truncated.
+type QualityProfileComparisonObservation struct {
+	// Key is the key of the reference Quality Profile.
+	Key string `json:"key"`
+	// Name is the name of the reference Quality Profile.
+	Name string `json:"name"`
+	// Time is when the Quality Profiles were compared.
+	Time *metav1.Time `json:"time,omitempty"`
+	// OnlyInProfileCount is the number of rules active only in the Quality Profile.
+	OnlyInProfileCount int64 `json:"onlyInProfileCount"`
+	// OnlyInProfile are the keys of the rules active only in the Quality Profile.
+	OnlyInProfile []string `json:"onlyInProfile,omitempty"`
+	// OnlyInReferenceCount is the number of rules active only in the reference Quality Profile.
+	OnlyInReferenceCount int64 `json:"onlyInReferenceCount"`
+	// OnlyInReference are the keys of the rules active only in the reference Quality Profile.
+	OnlyInReference []string `json:"onlyInReference,omitempty"`
+	// ModifiedCount is the number of rules active in both Quality Profiles with different parameters.
+	ModifiedCount int64 `json:"modifiedCount"`
+	// Modified are the keys of the rules active in both Quality Profiles with different parameters.
+	Modified []string `json:"modified,omitempty"`
+}
+
+// QualityProfileExternalChange is a change of a Quality Profile made outside of its resource, as recorded in its changelog.
+type QualityProfileExternalChange struct {
+	// Date is when the change was made.
+	Date *metav1.Time `json:"date,omitempty"`
+	// Author is the login, or the name, of the user who made the change, empty if SonarQube did not record it.
+	Author string `json:"author,omitempty"`
+	// Action is the change made to the rule: ACTIVATED, DEACTIVATED or UPDATED.
+	Action string `json:"action"`
+	// Rule is the key of the changed rule.
+	Rule string `json:"rule,omitempty"`
+	// Params are the changed parameters of the rule activation, formatted as name=value.
+	Params []string `json:"params,omitempty"`
+}
+
+// QualityProfileRestoreObservation reports the restoration of a Quality Profile from a backup.
+type QualityProfileRestoreObservation struct {
+	// Time is when the backup was restored.
+	Time *metav1.Time `json:"time,omitempty"`
+	// BackupHash is the SHA-256 hash of the restored backup, a backup with another hash is restored again.
+	BackupHash string `json:"backupHash"`
+	// RuleSuccesses is the number of rules of the backup that were activated.
+	RuleSuccesses int64 `json:"ruleSuccesses"`
+	// RuleFailures is the number of rules of the backup that could not be activated, e.g. rules unknown to the instance.
+	RuleFailures int64 `json:"ruleFailures"`
+}
+
+// QualityProfileRuleConflict is a deactivated rule that SonarQube refused to deactivate.
+type QualityProfileRuleConflict struct {
+	// Rule is the key of the rule.
+	Rule string `json:"rule"`
+	// Message is the reason given by SonarQube.
+	Message string `json:"message,omitempty"`
+}
+
+// QualityProfileRulesSyncObservation reports the changes made by a synchronization of the rules of a Quality Profile.
+type QualityProfileRulesSyncObservation struct {
+	// Time is when the synchronization happened.
+	Time *metav1.Time `json:"time,omitempty"`
+	// Activated is the number of rules activated or updated.
+	Activated int64 `json:"activated"`
+	// Deactivated is the number of rules deactivated.
+	Deactivated int64 `json:"deactivated"`
+	// Failed is the number of rules that could not be activated, updated or deactivated.
+	Failed int64 `json:"failed"`
+	// BulkRequests is the number of requests that activated or deactivated rules in bulk.
+	BulkRequests int64 `json:"bulkRequests"`
+	// IndividualRequests is the number of requests that activated, updated or deactivated a single rule.
+	IndividualRequests int64 `json:"individualRequests"`
+	// BulkFallbackRules is the number of rules contributed by a rule selector that were activated individually,
+	// because the query of the selector would not activate exactly its rules, e.g. when it has include or exclude patterns
+	// or when some of the rules it selects are listed in the spec.
+	BulkFallbackRules int64 `json:"bulkFallbackRules"`
 }
 
 // QualityProfileRuleParameters are the configurable fields of a QualityProfile Rule.
@@ -108,6 +352,10 @@ type QualityProfileRuleObservation struct {
 	Impacts     []QualityProfileRuleImpact `json:"impacts,omitempty"`
 	Parameters  map[string]string          `json:"parameters,omitempty"`
 	Prioritized bool                       `json:"prioritized"`
+	// Inheritance tells whether the rule is activated by a parent Quality Profile: NONE, INHERITED or OVERRIDES when its settings are overridden.
+	Inheritance string `json:"inheritance,omitempty"`
+	// Selector is the name of the rule selector that selected the rule, empty if the rule is listed in the rules of the spec or unmanaged.
+	Selector string `json:"selector,omitempty"`
 }
 
 type QualityProfileRuleImpact struct {
@@ -115,6 +363,56 @@ type QualityProfileRuleImpact struct {
 	SoftwareQuality string `json:"softwareQuality,omitempty"`
 }
 
+// TypeRulesConflict indicates whether deactivated rules of a QualityProfile are kept active by a parent Quality Profile.
+const TypeRulesConflict xpv1.ConditionType = "RulesConflict"
+
+// Reasons of the RulesConflict condition of a QualityProfile.
+const (
+	ReasonInheritedRulesForced xpv1.ConditionReason = "InheritedRulesForced"
+	ReasonNoConflict           xpv1.ConditionReason = "NoConflict"
+)
+
+// RulesConflict returns a condition that indicates deactivated rules are kept active by a parent Quality Profile.
+func RulesConflict(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRulesConflict,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInheritedRulesForced,
+		Message:            message,
+	}
+}
+
+// NoRulesConflict returns a condition that indicates all the deactivated rules are inactive.
+func NoRulesConflict() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRulesConflict,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoConflict,
+	}
+}
+
+// TypeDeletionBlocked indicates the deletion of a QualityProfile is blocked.
+const TypeDeletionBlocked xpv1.ConditionType = "DeletionBlocked"
+
+// Reasons of the DeletionBlocked condition of a QualityProfile.
+const (
+	ReasonProfileInUse   xpv1.ConditionReason = "ProfileInUse"
+	ReasonDefaultProfile xpv1.ConditionReason = "DefaultProfile"
+)
+
+// DeletionBlocked returns a condition that indicates the deletion of a QualityProfile is blocked for the given reason.
+func DeletionBlocked(reason xpv1.ConditionReason, message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeletionBlocked,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
 // A QualityProfileSpec defines the desired state of a QualityProfile.
 type QualityProfileSpec struct {
 	xpv2.ManagedResourceSpec `json:",inline"`
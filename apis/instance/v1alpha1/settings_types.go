@@ -36,8 +36,21 @@ type SettingsParameters struct {
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Component is immutable."
 	Component *string `json:"component,omitempty"`
+	// ManagementMode defines how the settings of the component are owned by this resource.
+	// Authoritative (default): the resource owns the settings of the component and resets the observed settings that are no longer desired.
+	// Additive: the resource only owns the settings it has applied itself, tracked in status.atProvider.appliedKeys.
+	// Only those are reset when they are removed from the map or when the resource is deleted, so several Settings resources can share a component.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Authoritative;Additive
+	// +kubebuilder:default=Authoritative
+	ManagementMode *string `json:"managementMode,omitempty"`
+	// ForceExplicitStorage makes the provider explicitly store every setting at the level of the resource.
+	// By default, a setting whose inherited or default value equals the desired value is considered up to date,
+	// in which case it silently follows the parent value, e.g. when the default value changes on upgrade.
+	// +kubebuilder:validation:Optional
+	ForceExplicitStorage *bool `json:"forceExplicitStorage,omitempty"`
 	// Settings is the map of settings to be applied. The key is the unique identifier of the setting and the value is the value of the setting.
-	// WARNING: Removing a setting from this map will NOT reset it to its default value in SonarQube.
+	// WARNING: Removing a setting from this map will NOT reset it to its default value in SonarQube, unless managementMode is Additive.
 	// If you want to make sure a setting is reset to its default value, you need to leave it there and properly delete the Settings resource.
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinProperties=1
@@ -45,37 +58,92 @@ type SettingsParameters struct {
 }
 
 // SettingParameters represent the desired state of a single SonarQube Setting.
+// +kubebuilder:validation:XValidation:rule="!has(self.valueFrom) || !(has(self.value) || has(self.values) || has(self.fieldValues))",message="valueFrom cannot be used together with value, values or fieldValues."
 type SettingParameters struct {
 	// Value is the value of the setting. The format of the value depends on the type of the setting. It can be a string, a number, a boolean or a JSON object.
-	// This field must be set if "Values" and "fieldValues" are not set.
+	// This field must be set if "Values", "fieldValues" and "valueFrom" are not set.
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=4000
 	Value *string `json:"value,omitempty"`
 	// Values is used for multi valued settings.
-	// This field must be set if "Value" and "fieldValues" are not set.
+	// This field must be set if "Value", "fieldValues" and "valueFrom" are not set.
 	// +kubebuilder:validation:Optional
 	Values *[]string `json:"values,omitempty"`
 	// FieldValues is used for multi valued settings with predefined fields.
-	// This field must be set if "Value" and "Values" are not set.
+	// This field must be set if "Value", "Values" and "valueFrom" are not set.
 	// +kubebuilder:validation:Optional
 	FieldValues *map[string]string `json:"fieldValues,omitempty"`
+	// ValueFrom sources the value of the setting from a Secret or a ConfigMap in the namespace of the Settings resource.
+	// It should be used for secured settings (e.g. "*.secured" keys, LDAP bind passwords or OAuth client secrets) so that they are not stored in plain text.
+	// This field must be set if "Value", "Values" and "fieldValues" are not set.
+	// +kubebuilder:validation:Optional
+	ValueFrom *SettingValueSource `json:"valueFrom,omitempty"`
+}
+
+// SettingValueSource represents a source for the value of a SonarQube Setting.
+// +kubebuilder:validation:XValidation:rule="has(self.secretKeyRef) != has(self.configMapKeyRef)",message="Exactly one of secretKeyRef or configMapKeyRef must be set."
+type SettingValueSource struct {
+	// SecretKeyRef selects a key of a Secret in the namespace of the Settings resource.
+	// +kubebuilder:validation:Optional
+	SecretKeyRef *xpv1.LocalSecretKeySelector `json:"secretKeyRef,omitempty"`
+	// ConfigMapKeyRef selects a key of a ConfigMap in the namespace of the Settings resource.
+	// +kubebuilder:validation:Optional
+	ConfigMapKeyRef *LocalConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// LocalConfigMapKeySelector is a reference to a key of a ConfigMap in the namespace of the referencing resource.
+type LocalConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Key within the ConfigMap.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
 }
 
 // SettingsObservation are the observable fields of a Settings.
 type SettingsObservation struct {
 	// Settings is the map of settings that have been applied. The key is the unique identifier of the setting and the value is the value of the setting. The format of the value depends on the type of the setting. It can be a string, a number, a boolean or a JSON object.
 	Settings map[string]SettingObservation `json:"settings,omitempty"`
+	// AppliedKeys is the ledger of the setting keys applied by this resource when managementMode is Additive.
+	// Only these keys are reset when they are removed from the spec or when the resource is deleted.
+	AppliedKeys []string `json:"appliedKeys,omitempty"`
 }
 
 // SettingObservation are the observable fields of a single SonarQube Setting.
 type SettingObservation struct {
 	// Value is the value of the setting. The format of the value depends on the type of the setting. It can be a string, a number, a boolean or a JSON object.
 	Value string `json:"value,omitempty"`
+	// ValueHash is reported instead of the value and the parent value of settings whose value comes from a Secret.
+	// It is the hash of the version of the Secret when the observed value matches it, and empty otherwise.
+	ValueHash string `json:"valueHash,omitempty"`
 	// Values is used for multi valued settings.
 	Values []string `json:"values,omitempty"`
 	// FieldValues is used for multi valued settings with predefined fields.
 	FieldValues map[string]string `json:"fieldValues,omitempty"`
+	// Inherited indicates that the value is not stored at the level of the resource but inherited from a parent level or defaulted.
+	Inherited bool `json:"inherited,omitempty"`
+	// Level is the level the value comes from: Project for the component of the resource, Global for the instance settings,
+	// or Default when the setting is not stored anywhere. It is not reported for secured settings.
+	// Branch level is not supported: the SonarQube settings API neither stores nor reports settings per branch,
+	// so the settings of a branch are those of its project.
+	// +kubebuilder:validation:Enum=Project;Global;Default
+	Level string `json:"level,omitempty"`
+	// ParentValue is the value the setting of a component would have if it was not set on the component.
+	ParentValue string `json:"parentValue,omitempty"`
+	// ParentValues is the parent value of a multi valued setting.
+	ParentValues []string `json:"parentValues,omitempty"`
+	// ParentFieldValues is the parent value of a multi valued setting with predefined fields.
+	ParentFieldValues map[string]string `json:"parentFieldValues,omitempty"`
+	// Secured indicates that the setting is a secured setting: SonarQube only reports that it is set, never its value.
+	Secured bool `json:"secured,omitempty"`
+	// AppliedValueHash is the hash of the value last applied by the provider, or of the version of its Secret
+	// when the value comes from a Secret.
+	// It is used to detect changes of secured settings, whose value is never returned by SonarQube.
+	AppliedValueHash string `json:"appliedValueHash,omitempty"`
 }
 
 // A SettingsSpec defines the desired state of a Settings.
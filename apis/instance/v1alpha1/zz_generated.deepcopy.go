@@ -9,9 +9,242 @@
 package v1alpha1
 
 import (
+	"github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailConfiguration) DeepCopyInto(out *EmailConfiguration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailConfiguration.
+func (in *EmailConfiguration) DeepCopy() *EmailConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EmailConfiguration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailConfigurationList) DeepCopyInto(out *EmailConfigurationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]EmailConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailConfigurationList.
+func (in *EmailConfigurationList) DeepCopy() *EmailConfigurationList {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailConfigurationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EmailConfigurationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailConfigurationObservation) DeepCopyInto(out *EmailConfigurationObservation) {
+	*out = *in
+	if in.SecuredSettings != nil {
+		in, out := &in.SecuredSettings, &out.SecuredSettings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TestEmail != nil {
+		in, out := &in.TestEmail, &out.TestEmail
+		*out = new(EmailConfigurationTestObservation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailConfigurationObservation.
+func (in *EmailConfigurationObservation) DeepCopy() *EmailConfigurationObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailConfigurationObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailConfigurationParameters) DeepCopyInto(out *EmailConfigurationParameters) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SecureConnection != nil {
+		in, out := &in.SecureConnection, &out.SecureConnection
+		*out = new(string)
+		**out = **in
+	}
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(string)
+		**out = **in
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.LocalSecretKeySelector)
+		**out = **in
+	}
+	if in.FromAddress != nil {
+		in, out := &in.FromAddress, &out.FromAddress
+		*out = new(string)
+		**out = **in
+	}
+	if in.FromName != nil {
+		in, out := &in.FromName, &out.FromName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Prefix != nil {
+		in, out := &in.Prefix, &out.Prefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.TestEmail != nil {
+		in, out := &in.TestEmail, &out.TestEmail
+		*out = new(EmailConfigurationTestParameters)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailConfigurationParameters.
+func (in *EmailConfigurationParameters) DeepCopy() *EmailConfigurationParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailConfigurationParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailConfigurationSpec) DeepCopyInto(out *EmailConfigurationSpec) {
+	*out = *in
+	in.ManagedResourceSpec.DeepCopyInto(&out.ManagedResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailConfigurationSpec.
+func (in *EmailConfigurationSpec) DeepCopy() *EmailConfigurationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailConfigurationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailConfigurationStatus) DeepCopyInto(out *EmailConfigurationStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailConfigurationStatus.
+func (in *EmailConfigurationStatus) DeepCopy() *EmailConfigurationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailConfigurationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailConfigurationTestObservation) DeepCopyInto(out *EmailConfigurationTestObservation) {
+	*out = *in
+	if in.SentAt != nil {
+		in, out := &in.SentAt, &out.SentAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailConfigurationTestObservation.
+func (in *EmailConfigurationTestObservation) DeepCopy() *EmailConfigurationTestObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailConfigurationTestObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailConfigurationTestParameters) DeepCopyInto(out *EmailConfigurationTestParameters) {
+	*out = *in
+	if in.Subject != nil {
+		in, out := &in.Subject, &out.Subject
+		*out = new(string)
+		**out = **in
+	}
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailConfigurationTestParameters.
+func (in *EmailConfigurationTestParameters) DeepCopy() *EmailConfigurationTestParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailConfigurationTestParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalConfigMapKeySelector) DeepCopyInto(out *LocalConfigMapKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalConfigMapKeySelector.
+func (in *LocalConfigMapKeySelector) DeepCopy() *LocalConfigMapKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalConfigMapKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QualityGate) DeepCopyInto(out *QualityGate) {
 	*out = *in
@@ -235,6 +468,134 @@ func (in *QualityProfile) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QualityProfileBackupExport) DeepCopyInto(out *QualityProfileBackupExport) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QualityProfileBackupExport.
+func (in *QualityProfileBackupExport) DeepCopy() *QualityProfileBackupExport {
+	if in == nil {
+		return nil
+	}
+	out := new(QualityProfileBackupExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QualityProfileBackupSource) DeepCopyInto(out *QualityProfileBackupSource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.LocalSecretKeySelector)
+		**out = **in
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(LocalConfigMapKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QualityProfileBackupSource.
+func (in *QualityProfileBackupSource) DeepCopy() *QualityProfileBackupSource {
+	if in == nil {
+		return nil
+	}
+	out := new(QualityProfileBackupSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QualityProfileComparisonObservation) DeepCopyInto(out *QualityProfileComparisonObservation) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+	if in.OnlyInProfile != nil {
+		in, out := &in.OnlyInProfile, &out.OnlyInProfile
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OnlyInReference != nil {
+		in, out := &in.OnlyInReference, &out.OnlyInReference
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Modified != nil {
+		in, out := &in.Modified, &out.Modified
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QualityProfileComparisonObservation.
+func (in *QualityProfileComparisonObservation) DeepCopy() *QualityProfileComparisonObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(QualityProfileComparisonObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QualityProfileComparisonTarget) DeepCopyInto(out *QualityProfileComparisonTarget) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QualityProfileComparisonTarget.
+func (in *QualityProfileComparisonTarget) DeepCopy() *QualityProfileComparisonTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(QualityProfileComparisonTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QualityProfileExternalChange) DeepCopyInto(out *QualityProfileExternalChange) {
+	*out = *in
+	if in.Date != nil {
+		in, out := &in.Date, &out.Date
+		*out = (*in).DeepCopy()
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QualityProfileExternalChange.
+func (in *QualityProfileExternalChange) DeepCopy() *QualityProfileExternalChange {
+	if in == nil {
+		return nil
+	}
+	out := new(QualityProfileExternalChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QualityProfileList) DeepCopyInto(out *QualityProfileList) {
 	*out = *in
@@ -285,6 +646,46 @@ func (in *QualityProfileObservation) DeepCopyInto(out *QualityProfileObservation
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ActivatedRules != nil {
+		in, out := &in.ActivatedRules, &out.ActivatedRules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RuleConflicts != nil {
+		in, out := &in.RuleConflicts, &out.RuleConflicts
+		*out = make([]QualityProfileRuleConflict, len(*in))
+		copy(*out, *in)
+	}
+	if in.RulesSync != nil {
+		in, out := &in.RulesSync, &out.RulesSync
+		*out = new(QualityProfileRulesSyncObservation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Restore != nil {
+		in, out := &in.Restore, &out.Restore
+		*out = new(QualityProfileRestoreObservation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BackupExportTime != nil {
+		in, out := &in.BackupExportTime, &out.BackupExportTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ChangelogSince != nil {
+		in, out := &in.ChangelogSince, &out.ChangelogSince
+		*out = (*in).DeepCopy()
+	}
+	if in.ExternalChanges != nil {
+		in, out := &in.ExternalChanges, &out.ExternalChanges
+		*out = make([]QualityProfileExternalChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Comparison != nil {
+		in, out := &in.Comparison, &out.Comparison
+		*out = new(QualityProfileComparisonObservation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QualityProfileObservation.
@@ -305,6 +706,11 @@ func (in *QualityProfileParameters) DeepCopyInto(out *QualityProfileParameters)
 		*out = new(bool)
 		**out = **in
 	}
+	if in.AdoptExisting != nil {
+		in, out := &in.AdoptExisting, &out.AdoptExisting
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Rules != nil {
 		in, out := &in.Rules, &out.Rules
 		*out = make([]QualityProfileRuleParameters, len(*in))
@@ -312,6 +718,43 @@ func (in *QualityProfileParameters) DeepCopyInto(out *QualityProfileParameters)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RuleSelectors != nil {
+		in, out := &in.RuleSelectors, &out.RuleSelectors
+		*out = make([]QualityProfileRuleSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DeactivatedRules != nil {
+		in, out := &in.DeactivatedRules, &out.DeactivatedRules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RulesPolicy != nil {
+		in, out := &in.RulesPolicy, &out.RulesPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.DeletionBehavior != nil {
+		in, out := &in.DeletionBehavior, &out.DeletionBehavior
+		*out = new(string)
+		**out = **in
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(QualityProfileBackupSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BackupExport != nil {
+		in, out := &in.BackupExport, &out.BackupExport
+		*out = new(QualityProfileBackupExport)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CompareTo != nil {
+		in, out := &in.CompareTo, &out.CompareTo
+		*out = new(QualityProfileComparisonTarget)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QualityProfileParameters.
@@ -324,6 +767,40 @@ func (in *QualityProfileParameters) DeepCopy() *QualityProfileParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QualityProfileRestoreObservation) DeepCopyInto(out *QualityProfileRestoreObservation) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QualityProfileRestoreObservation.
+func (in *QualityProfileRestoreObservation) DeepCopy() *QualityProfileRestoreObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(QualityProfileRestoreObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QualityProfileRuleConflict) DeepCopyInto(out *QualityProfileRuleConflict) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QualityProfileRuleConflict.
+func (in *QualityProfileRuleConflict) DeepCopy() *QualityProfileRuleConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(QualityProfileRuleConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QualityProfileRuleImpact) DeepCopyInto(out *QualityProfileRuleImpact) {
 	*out = *in
@@ -421,6 +898,100 @@ func (in *QualityProfileRuleParameters) DeepCopy() *QualityProfileRuleParameters
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QualityProfileRuleSelector) DeepCopyInto(out *QualityProfileRuleSelector) {
+	*out = *in
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Severities != nil {
+		in, out := &in.Severities, &out.Severities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SoftwareQualities != nil {
+		in, out := &in.SoftwareQualities, &out.SoftwareQualities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Cwe != nil {
+		in, out := &in.Cwe, &out.Cwe
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OwaspTop10 != nil {
+		in, out := &in.OwaspTop10, &out.OwaspTop10
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OwaspTop102021 != nil {
+		in, out := &in.OwaspTop102021, &out.OwaspTop102021
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Severity != nil {
+		in, out := &in.Severity, &out.Severity
+		*out = new(string)
+		**out = **in
+	}
+	if in.Prioritized != nil {
+		in, out := &in.Prioritized, &out.Prioritized
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QualityProfileRuleSelector.
+func (in *QualityProfileRuleSelector) DeepCopy() *QualityProfileRuleSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(QualityProfileRuleSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QualityProfileRulesSyncObservation) DeepCopyInto(out *QualityProfileRulesSyncObservation) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QualityProfileRulesSyncObservation.
+func (in *QualityProfileRulesSyncObservation) DeepCopy() *QualityProfileRulesSyncObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(QualityProfileRulesSyncObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QualityProfileSpec) DeepCopyInto(out *QualityProfileSpec) {
 	*out = *in
@@ -470,6 +1041,18 @@ func (in *SettingObservation) DeepCopyInto(out *SettingObservation) {
 			(*out)[key] = val
 		}
 	}
+	if in.ParentValues != nil {
+		in, out := &in.ParentValues, &out.ParentValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ParentFieldValues != nil {
+		in, out := &in.ParentFieldValues, &out.ParentFieldValues
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SettingObservation.
@@ -510,6 +1093,11 @@ func (in *SettingParameters) DeepCopyInto(out *SettingParameters) {
 			}
 		}
 	}
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(SettingValueSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SettingParameters.
@@ -522,6 +1110,31 @@ func (in *SettingParameters) DeepCopy() *SettingParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SettingValueSource) DeepCopyInto(out *SettingValueSource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.LocalSecretKeySelector)
+		**out = **in
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(LocalConfigMapKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SettingValueSource.
+func (in *SettingValueSource) DeepCopy() *SettingValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SettingValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Settings) DeepCopyInto(out *Settings) {
 	*out = *in
@@ -591,6 +1204,11 @@ func (in *SettingsObservation) DeepCopyInto(out *SettingsObservation) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.AppliedKeys != nil {
+		in, out := &in.AppliedKeys, &out.AppliedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SettingsObservation.
@@ -611,6 +1229,16 @@ func (in *SettingsParameters) DeepCopyInto(out *SettingsParameters) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ManagementMode != nil {
+		in, out := &in.ManagementMode, &out.ManagementMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.ForceExplicitStorage != nil {
+		in, out := &in.ForceExplicitStorage, &out.ForceExplicitStorage
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Settings != nil {
 		in, out := &in.Settings, &out.Settings
 		*out = make(map[string]SettingParameters, len(*in))
@@ -8,6 +8,15 @@ package v1alpha1
 
 import resource "github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 
+// GetItems of this EmailConfigurationList.
+func (l *EmailConfigurationList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
 // GetItems of this QualityGateList.
 func (l *QualityGateList) GetItems() []resource.Managed {
 	items := make([]resource.Managed, len(l.Items))
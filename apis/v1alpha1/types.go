@@ -3,12 +3,89 @@ package v1alpha1
 import (
 	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
 	xpv2 "github.com/crossplane/crossplane-runtime/v2/apis/common/v2"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // A ProviderConfigStatus defines the status of a Provider.
 type ProviderConfigStatus struct {
 	xpv1.ProviderConfigStatus `json:",inline"`
+
+	// Server is the state of the SonarQube instance observed by the last health check.
+	// +optional
+	Server *ServerObservation `json:"server,omitempty"`
+}
+
+// ServerObservation is the state of the SonarQube instance observed by a health check of the ProviderConfig.
+type ServerObservation struct {
+	// Version is the version of the SonarQube instance, e.g. 2025.1.0.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Edition is the edition of the SonarQube instance, e.g. community, developer, enterprise or datacenter.
+	// +optional
+	Edition string `json:"edition,omitempty"`
+
+	// Status is the running status of the SonarQube instance, e.g. UP, STARTING or DB_MIGRATION_NEEDED.
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// LastProbeTime is the time of the last health check.
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+}
+
+// TypeAuthenticated indicates whether the credentials of a ProviderConfig are accepted by the SonarQube instance.
+const TypeAuthenticated xpv1.ConditionType = "Authenticated"
+
+// Reasons of the Ready and Authenticated conditions of a ProviderConfig.
+// ReasonUnreachable is also the reason of the Ready condition of a managed resource whose SonarQube request received no response.
+const (
+	ReasonInvalidConfig   xpv1.ConditionReason = "InvalidConfig"
+	ReasonUnreachable     xpv1.ConditionReason = "Unreachable"
+	ReasonServerNotUp     xpv1.ConditionReason = "ServerNotUp"
+	ReasonAuthenticated   xpv1.ConditionReason = "Authenticated"
+	ReasonUnauthenticated xpv1.ConditionReason = "Unauthenticated"
+)
+
+// Authenticated returns a condition that indicates the credentials of the ProviderConfig are accepted by the SonarQube instance.
+func Authenticated() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAuthenticated,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAuthenticated,
+	}
+}
+
+// Unauthenticated returns a condition that indicates the credentials of the ProviderConfig are rejected by the SonarQube instance.
+func Unauthenticated() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAuthenticated,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUnauthenticated,
+	}
+}
+
+// AuthenticationUnknown returns a condition that indicates the credentials of the ProviderConfig could not be checked.
+func AuthenticationUnknown(reason xpv1.ConditionReason) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeAuthenticated,
+		Status:             corev1.ConditionUnknown,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+	}
+}
+
+// NotReady returns a condition that indicates the ProviderConfig cannot be used to manage the SonarQube instance.
+func NotReady(reason xpv1.ConditionReason) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+	}
 }
 
 // ProviderCredentials required to authenticate.
@@ -16,13 +93,20 @@ type ProviderCredentials struct {
 	xpv1.CommonCredentialSelectors `json:",inline"`
 
 	// Source of the provider credentials.
+	// Secret reads the credentials from secretRef, Environment from the env variable of the provider and Filesystem from the fs path,
+	// which is read again on every connection so that rotated credentials are used.
+	// InjectedIdentity reads a token mounted in the provider pod at /var/run/secrets/sonarqube.crossplane.io/token, it is only supported for the token.
 	// +kubebuilder:validation:Enum=None;Secret;InjectedIdentity;Environment;Filesystem
 	Source xpv1.CredentialsSource `json:"source"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!has(self.oauth2) || !(has(self.token) || has(self.username) || has(self.password))",message="oauth2 cannot be used together with token, username or password."
 type ProviderConfigSpec struct {
-	// BaseURL of the SonarQube instance.
+	// BaseURL of the SonarQube instance, an absolute http or https URL.
 	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:XValidation:rule="isURL(self) && url(self).getScheme() in ['http', 'https'] && url(self).getHostname() != ''",message="baseUrl must be an absolute http or https URL."
 	BaseURL string `json:"baseUrl"`
 
 	// InsecureSkipVerify indicates whether to skip TLS certificate verification.
@@ -39,11 +123,166 @@ type ProviderConfigSpec struct {
 	// Password is the password for Basic Authentication to the SonarQube instance.
 	// +kubebuilder:validation:Optional
 	Password *ProviderCredentials `json:"password,omitempty"`
+
+	// OAuth2 authenticates with a bearer token obtained from an OAuth2 client credentials flow,
+	// e.g. for SonarQube instances fronted by an OIDC-aware proxy. It cannot be used together with token, username and password.
+	// +kubebuilder:validation:Optional
+	OAuth2 *OAuth2ClientCredentials `json:"oauth2,omitempty"`
+
+	// TLS configures the TLS connection to the SonarQube instance, e.g. a private CA or a client certificate for mTLS.
+	// +kubebuilder:validation:Optional
+	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// HTTP configures the HTTP client used to connect to the SonarQube instance: proxy, timeouts and extra headers.
+	// +kubebuilder:validation:Optional
+	HTTP *HTTPConfig `json:"http,omitempty"`
+
+	// Retry configures how requests to the SonarQube instance are retried when it is temporarily unavailable
+	// (HTTP 429, 502, 503 and 504). Only idempotent requests are retried.
+	// The HTTP timeout applies to each attempt, so a retried request can take up to maxRetries times the timeout and maxBackoff.
+	// +kubebuilder:validation:Optional
+	Retry *RetryConfig `json:"retry,omitempty"`
+
+	// RateLimit limits the rate of the requests sent to the SonarQube instance by all the resources using this ProviderConfig.
+	// When not set, requests are not rate limited.
+	// +kubebuilder:validation:Optional
+	RateLimit *RateLimitConfig `json:"rateLimit,omitempty"`
+}
+
+// OAuth2ClientCredentials configures the OAuth2 client credentials flow used to get the bearer token sent to the SonarQube instance.
+// The token is cached until it expires and then requested again.
+type OAuth2ClientCredentials struct {
+	// TokenURL is the token endpoint of the OAuth2 authorization server.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:XValidation:rule="isURL(self) && url(self).getScheme() in ['http', 'https']",message="tokenURL must be an absolute http or https URL."
+	TokenURL string `json:"tokenURL"`
+
+	// ClientID is the client ID used to request the token.
+	// +kubebuilder:validation:Required
+	ClientID ProviderCredentials `json:"clientID"`
+
+	// ClientSecret is the client secret used to request the token.
+	// +kubebuilder:validation:Required
+	ClientSecret ProviderCredentials `json:"clientSecret"`
+
+	// Scopes are the scopes requested for the token.
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// RateLimitConfig configures the token bucket limiting the rate of the requests to the SonarQube instance.
+type RateLimitConfig struct {
+	// QPS is the maximum sustained number of requests per second.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	QPS int32 `json:"qps"`
+
+	// Burst is the maximum number of requests sent at once before the QPS limit applies.
+	// Defaults to the QPS.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Burst *int32 `json:"burst,omitempty"`
+}
+
+// RetryConfig configures the retries of the requests to the SonarQube instance.
+type RetryConfig struct {
+	// MaxRetries is the maximum number of retries of a request. Set it to 0 to disable retries.
+	// Defaults to 3.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+
+	// InitialBackoff is the delay before the first retry, doubled on each subsequent retry.
+	// Defaults to 500ms.
+	// +kubebuilder:validation:Optional
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
+
+	// MaxBackoff is the maximum delay between two retries, including the delay requested by a Retry-After header.
+	// Defaults to 10s.
+	// +kubebuilder:validation:Optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+}
+
+// HTTPConfig configures the HTTP client used to connect to the SonarQube instance.
+type HTTPConfig struct {
+	// ProxyURL is the URL of the proxy used to reach the SonarQube instance.
+	// When not set, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables of the provider are used.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="isURL(self)",message="proxyURL must be a valid URL."
+	ProxyURL *string `json:"proxyURL,omitempty"`
+
+	// NoProxy is the list of hosts, domains (e.g. ".example.com"), IP addresses or CIDRs that must not be reached through the proxy.
+	// It is only used together with proxyURL.
+	// +kubebuilder:validation:Optional
+	NoProxy []string `json:"noProxy,omitempty"`
+
+	// Timeout is the maximum duration of a request to the SonarQube instance, including reading the response body.
+	// It applies to each attempt of a request, once the rate limit allows it: waiting for the rate limit or between retries is not counted.
+	// Defaults to 30s so that an unresponsive SonarQube instance cannot block reconciliations indefinitely.
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// IdleConnTimeout is the maximum duration an idle connection to the SonarQube instance is kept open.
+	// Defaults to 90s.
+	// +kubebuilder:validation:Optional
+	IdleConnTimeout *metav1.Duration `json:"idleConnTimeout,omitempty"`
+
+	// Headers are extra headers sent with every request, e.g. API keys required by a gateway in front of the SonarQube instance.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	Headers []HTTPHeader `json:"headers,omitempty"`
+}
+
+// HTTPHeader is an extra header sent with every request to the SonarQube instance.
+// +kubebuilder:validation:XValidation:rule="has(self.value) != has(self.valueSecretRef)",message="Exactly one of value or valueSecretRef must be set."
+type HTTPHeader struct {
+	// Name of the header.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Value of the header.
+	// +kubebuilder:validation:Optional
+	Value *string `json:"value,omitempty"`
+
+	// ValueSecretRef references the key of a Secret holding the value of the header.
+	// +kubebuilder:validation:Optional
+	ValueSecretRef *xpv1.SecretKeySelector `json:"valueSecretRef,omitempty"`
+}
+
+// TLSConfig configures the TLS connection to the SonarQube instance.
+// +kubebuilder:validation:XValidation:rule="has(self.clientCertSecretRef) == has(self.clientKeySecretRef)",message="clientCertSecretRef and clientKeySecretRef must be set together."
+type TLSConfig struct {
+	// CABundleSecretRef references a PEM encoded bundle of CA certificates used to verify the SonarQube server certificate,
+	// in addition to the system trust store.
+	// +kubebuilder:validation:Optional
+	CABundleSecretRef *xpv1.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
+
+	// ClientCertSecretRef references a PEM encoded client certificate presented to the SonarQube instance for mutual TLS.
+	// +kubebuilder:validation:Optional
+	ClientCertSecretRef *xpv1.SecretKeySelector `json:"clientCertSecretRef,omitempty"`
+
+	// ClientKeySecretRef references the PEM encoded private key of the client certificate.
+	// +kubebuilder:validation:Optional
+	ClientKeySecretRef *xpv1.SecretKeySelector `json:"clientKeySecretRef,omitempty"`
+
+	// ServerName overrides the name used to verify the SonarQube server certificate, and sent as SNI.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	ServerName *string `json:"serverName,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:storageversion
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AUTHENTICATED",type="string",JSONPath=".status.conditions[?(@.type=='Authenticated')].status"
+// +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".status.server.version"
+// +kubebuilder:printcolumn:name="EDITION",type="string",JSONPath=".status.server.edition",priority=1
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentials.secretRef.name",priority=1
 // +kubebuilder:resource:scope=Namespaced,categories={crossplane,provider,sonarqube}
@@ -95,6 +334,10 @@ type ProviderConfigUsageList struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AUTHENTICATED",type="string",JSONPath=".status.conditions[?(@.type=='Authenticated')].status"
+// +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".status.server.version"
+// +kubebuilder:printcolumn:name="EDITION",type="string",JSONPath=".status.server.edition",priority=1
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentials.secretRef.name",priority=1
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,provider,sonarqube}
@@ -22,6 +22,9 @@ const (
 
 	// PersonalAccessToken is SonarQube's PersonalAccessToken method of authentification.
 	PersonalAccessToken AuthType = "PersonalAccessToken"
+
+	// OAuth2ClientCredentials authenticates with a bearer token obtained from an OAuth2 client credentials flow.
+	OAuth2ClientCredentials AuthType = "OAuth2ClientCredentials"
 )
 
 // AuthType represents an authentication type within SonarQube.
@@ -0,0 +1,299 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/provider-sonarqube/internal/helpers"
+)
+
+// DefaultCapabilitiesTTL is the duration the capabilities of a SonarQube instance are cached, so that upgrades are eventually detected.
+const DefaultCapabilitiesTTL = 10 * time.Minute
+
+// communityBuildMaxMajor is the highest major version of the year-based Community Builds (e.g. 25.1), which are numbered without the century.
+const communityBuildMaxMajor = 99
+
+const (
+	errParseServerVersion = "cannot parse SonarQube version %q"
+	errGetServerVersion   = "cannot get the version of the SonarQube instance"
+)
+
+// Edition is the edition of a SonarQube instance.
+type Edition string
+
+// SonarQube editions, as returned by api/navigation/global.
+const (
+	EditionCommunity  Edition = "community"
+	EditionDeveloper  Edition = "developer"
+	EditionEnterprise Edition = "enterprise"
+	EditionDataCenter Edition = "datacenter"
+)
+
+// ServerVersion is the version of a SonarQube instance.
+// The version of the year-based Community Builds (e.g. 25.1) is normalized to the year-based versions of the commercial editions (e.g. 2025.1).
+type ServerVersion struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// String returns the version as major.minor.patch.
+func (v ServerVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// AtLeast checks whether the version is the same as or more recent than the other version.
+func (v ServerVersion) AtLeast(other ServerVersion) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
+	}
+
+	return v.Patch >= other.Patch
+}
+
+// ParseServerVersion parses a SonarQube version such as 9.9.0.65466, 10.8.1.101195, 25.1.0.102122 or 2025.1.0.102418.
+// The build number is ignored.
+func ParseServerVersion(version string) (ServerVersion, error) {
+	parts := strings.Split(strings.TrimSpace(version), ".")
+	if len(parts) < 2 {
+		return ServerVersion{}, errors.Errorf(errParseServerVersion, version)
+	}
+
+	numbers := make([]int, 3)
+
+	for i := 0; i < len(parts) && i < len(numbers); i++ {
+		number, err := strconv.Atoi(parts[i])
+		if err != nil || number < 0 {
+			return ServerVersion{}, errors.Errorf(errParseServerVersion, version)
+		}
+
+		numbers[i] = number
+	}
+
+	serverVersion := ServerVersion{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}
+
+	// Community Builds are numbered 24.12, 25.1, ... and released alongside the 2024.x, 2025.x, ... commercial editions.
+	if serverVersion.Major > 10 && serverVersion.Major <= communityBuildMaxMajor {
+		serverVersion.Major += 2000
+	}
+
+	return serverVersion, nil
+}
+
+// Feature is a feature of SonarQube that is only available from a given version or in given editions.
+type Feature struct {
+	// Name describes the feature in error messages
+	Name string
+	// MinVersion is the first version supporting the feature
+	MinVersion ServerVersion
+	// Editions are the editions supporting the feature, all editions if empty
+	Editions []Edition
+}
+
+// Features managed by the provider that are not supported by all SonarQube instances.
+var (
+	// FeaturePrioritizedRules is the activation of prioritized rules in quality profiles.
+	FeaturePrioritizedRules = Feature{Name: "prioritized rules", MinVersion: ServerVersion{Major: 10, Minor: 6}}
+	// FeatureRuleImpacts is the override of the impacts of rules activated in quality profiles.
+	FeatureRuleImpacts = Feature{Name: "rule impacts", MinVersion: ServerVersion{Major: 10, Minor: 8}}
+)
+
+// Capabilities are the version and edition of a SonarQube instance, which determine the features it supports.
+type Capabilities struct {
+	// Version is the version of the instance, nil if it could not be detected
+	Version *ServerVersion
+	// Edition is the edition of the instance, empty if it could not be detected
+	Edition Edition
+}
+
+// Supports checks whether the SonarQube instance supports the feature.
+// A feature is assumed to be supported when the version or the edition of the instance is unknown,
+// so that SonarQube remains the judge of what it supports.
+func (c Capabilities) Supports(feature Feature) bool {
+	if c.Version != nil && !c.Version.AtLeast(feature.MinVersion) {
+		return false
+	}
+
+	if c.Edition != "" && len(feature.Editions) > 0 && !slices.Contains(feature.Editions, c.Edition) {
+		return false
+	}
+
+	return true
+}
+
+// CheckSupported returns an UnsupportedFeatureError if the SonarQube instance does not support the feature.
+func (c Capabilities) CheckSupported(feature Feature) error {
+	if c.Supports(feature) {
+		return nil
+	}
+
+	return &UnsupportedFeatureError{Feature: feature, Capabilities: c}
+}
+
+// UnsupportedFeatureError is returned when a resource uses a feature the SonarQube instance does not support.
+type UnsupportedFeatureError struct {
+	Feature      Feature
+	Capabilities Capabilities
+}
+
+// Error describes the unsupported feature and what the SonarQube instance would need to support it.
+func (e *UnsupportedFeatureError) Error() string {
+	var requirements []string
+
+	if e.Feature.MinVersion != (ServerVersion{}) {
+		requirements = append(requirements, "SonarQube "+e.Feature.MinVersion.String()+" or later")
+	}
+
+	if len(e.Feature.Editions) > 0 {
+		editions := make([]string, 0, len(e.Feature.Editions))
+		for _, edition := range e.Feature.Editions {
+			editions = append(editions, string(edition))
+		}
+
+		requirements = append(requirements, "the "+strings.Join(editions, ", ")+" edition")
+	}
+
+	server := "the SonarQube instance runs version "
+	if e.Capabilities.Version != nil {
+		server += e.Capabilities.Version.String()
+	} else {
+		server += "unknown"
+	}
+
+	if e.Capabilities.Edition != "" {
+		server += " " + string(e.Capabilities.Edition) + " edition"
+	}
+
+	return fmt.Sprintf("using %s requires %s, %s", e.Feature.Name, strings.Join(requirements, " and "), server)
+}
+
+// capabilitiesCacheEntry is the capabilities of a SonarQube instance cached for a ProviderConfig.
+type capabilitiesCacheEntry struct {
+	key          ClientCacheKey
+	capabilities Capabilities
+	expires      time.Time
+}
+
+// CapabilitiesCache caches the capabilities of the SonarQube instance of each ProviderConfig.
+type CapabilitiesCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[types.UID]capabilitiesCacheEntry
+	now     func() time.Time
+}
+
+// NewCapabilitiesCache creates a capabilities cache keeping the capabilities for the given duration.
+func NewCapabilitiesCache(ttl time.Duration) *CapabilitiesCache {
+	return &CapabilitiesCache{ttl: ttl, entries: make(map[types.UID]capabilitiesCacheEntry), now: time.Now}
+}
+
+// capabilities is the capabilities cache shared by all the controllers.
+var capabilities = NewCapabilitiesCache(DefaultCapabilitiesTTL)
+
+// GetCapabilities returns the capabilities of the SonarQube instance of the given configuration,
+// probing the instance if they are not cached for its ProviderConfig.
+func GetCapabilities(clientConfig Config) (Capabilities, error) {
+	return capabilities.Get(clientConfig.CacheKey, func() (Capabilities, error) {
+		return probeCapabilities(clientConfig)
+	})
+}
+
+// Get returns the cached capabilities of the ProviderConfig identified by the key, or probes them if they are missing,
+// expired or if the ProviderConfig changed. Capabilities are not cached if the key is nil or if the probe fails.
+func (c *CapabilitiesCache) Get(key *ClientCacheKey, probe func() (Capabilities, error)) (Capabilities, error) {
+	if key == nil {
+		return probe()
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key.ProviderConfigUID]
+	c.mu.Unlock()
+
+	if ok && entry.key == *key && c.now().Before(entry.expires) {
+		return entry.capabilities, nil
+	}
+
+	probed, err := probe()
+	if err != nil {
+		return Capabilities{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for uid, cached := range c.entries {
+		if !now.Before(cached.expires) {
+			delete(c.entries, uid)
+		}
+	}
+
+	c.entries[key.ProviderConfigUID] = capabilitiesCacheEntry{key: *key, capabilities: probed, expires: now.Add(c.ttl)}
+
+	return probed, nil
+}
+
+// probeCapabilities detects the version and edition of the SonarQube instance, using api/server/version and api/navigation/global.
+// The edition is left empty if it cannot be detected.
+//
+//nolint:bodyclose // closed via helpers.CloseBody
+func probeCapabilities(clientConfig Config) (Capabilities, error) {
+	client, err := GetClient(clientConfig)
+	if err != nil {
+		return Capabilities{}, err
+	}
+
+	version, resp, err := client.Server.Version()
+	helpers.CloseBody(resp)
+
+	if err != nil {
+		return Capabilities{}, errors.Wrap(err, errGetServerVersion)
+	}
+
+	if version == nil {
+		return Capabilities{}, errors.New(errGetServerVersion)
+	}
+
+	serverVersion, err := ParseServerVersion(*version)
+	if err != nil {
+		return Capabilities{}, err
+	}
+
+	probed := Capabilities{Version: &serverVersion}
+
+	global, resp, err := client.Navigation.Global()
+	helpers.CloseBody(resp)
+
+	if err == nil && global != nil {
+		probed.Edition = Edition(global.Edition)
+	}
+
+	return probed, nil
+}
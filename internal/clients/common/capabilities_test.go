@@ -0,0 +1,223 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Features gated by edition, the features managed by the provider are only gated by version.
+var (
+	testEditionFeature = Feature{
+		Name:       "edition feature",
+		MinVersion: ServerVersion{Major: 10, Minor: 7},
+		Editions:   []Edition{EditionDeveloper, EditionEnterprise, EditionDataCenter},
+	}
+	testEnterpriseFeature = Feature{Name: "enterprise feature", Editions: []Edition{EditionEnterprise, EditionDataCenter}}
+)
+
+func TestParseServerVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		version string
+		want    ServerVersion
+		wantErr bool
+	}{
+		"LTS99":                 {version: "9.9.0.65466", want: ServerVersion{Major: 9, Minor: 9}},
+		"LTS99Patch":            {version: "9.9.8.100196", want: ServerVersion{Major: 9, Minor: 9, Patch: 8}},
+		"WithoutBuild":          {version: "9.9", want: ServerVersion{Major: 9, Minor: 9}},
+		"Version10":             {version: "10.0.0.68432", want: ServerVersion{Major: 10}},
+		"Version106":            {version: "10.6.0.92116", want: ServerVersion{Major: 10, Minor: 6}},
+		"LTA108":                {version: "10.8.1.101195", want: ServerVersion{Major: 10, Minor: 8, Patch: 1}},
+		"CommunityBuild2412":    {version: "24.12.0.100206", want: ServerVersion{Major: 2024, Minor: 12}},
+		"CommunityBuild251":     {version: "25.1.0.102122", want: ServerVersion{Major: 2025, Minor: 1}},
+		"Release20251":          {version: "2025.1.0.102418", want: ServerVersion{Major: 2025, Minor: 1}},
+		"Release20254Patch":     {version: "2025.4.2.112048", want: ServerVersion{Major: 2025, Minor: 4, Patch: 2}},
+		"SurroundingWhitespace": {version: " 2025.3.0.108892\n", want: ServerVersion{Major: 2025, Minor: 3}},
+		"Empty":                 {version: "", wantErr: true},
+		"MajorOnly":             {version: "10", wantErr: true},
+		"NotANumber":            {version: "10.x.0", wantErr: true},
+		"Garbage":               {version: "sonarqube", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseServerVersion(tc.version)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseServerVersion(%q) error = %v, wantErr %v", tc.version, err, tc.wantErr)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseServerVersion(%q) mismatch (-want +got):\n%s", tc.version, diff)
+			}
+		})
+	}
+}
+
+func TestCapabilitiesSupports(t *testing.T) {
+	t.Parallel()
+
+	version := func(value string) *ServerVersion {
+		v, err := ParseServerVersion(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &v
+	}
+
+	tests := map[string]struct {
+		capabilities Capabilities
+		feature      Feature
+		want         bool
+	}{
+		"UnknownVersion": {
+			capabilities: Capabilities{},
+			feature:      FeatureRuleImpacts,
+			want:         true,
+		},
+		"ImpactsOnLTS99": {
+			capabilities: Capabilities{Version: version("9.9.0.65466")},
+			feature:      FeatureRuleImpacts,
+			want:         false,
+		},
+		"ImpactsOn107": {
+			capabilities: Capabilities{Version: version("10.7.0.96327")},
+			feature:      FeatureRuleImpacts,
+			want:         false,
+		},
+		"ImpactsOnLTA108": {
+			capabilities: Capabilities{Version: version("10.8.1.101195")},
+			feature:      FeatureRuleImpacts,
+			want:         true,
+		},
+		"PrioritizedOn105": {
+			capabilities: Capabilities{Version: version("10.5.1.90531")},
+			feature:      FeaturePrioritizedRules,
+			want:         false,
+		},
+		"PrioritizedOnCommunityBuild": {
+			capabilities: Capabilities{Version: version("25.1.0.102122"), Edition: EditionCommunity},
+			feature:      FeaturePrioritizedRules,
+			want:         true,
+		},
+		"EditionFeatureOnCommunity": {
+			capabilities: Capabilities{Version: version("2025.1.0.102418"), Edition: EditionCommunity},
+			feature:      testEditionFeature,
+			want:         false,
+		},
+		"EditionFeatureOnDeveloper": {
+			capabilities: Capabilities{Version: version("2025.1.0.102418"), Edition: EditionDeveloper},
+			feature:      testEditionFeature,
+			want:         true,
+		},
+		"EnterpriseFeatureOnDeveloper": {
+			capabilities: Capabilities{Version: version("2025.4.2.112048"), Edition: EditionDeveloper},
+			feature:      testEnterpriseFeature,
+			want:         false,
+		},
+		"EnterpriseFeatureOnEnterprise": {
+			capabilities: Capabilities{Version: version("9.9.0.65466"), Edition: EditionEnterprise},
+			feature:      testEnterpriseFeature,
+			want:         true,
+		},
+		"EnterpriseFeatureUnknownEdition": {
+			capabilities: Capabilities{Version: version("2025.4.2.112048")},
+			feature:      testEnterpriseFeature,
+			want:         true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.capabilities.Supports(tc.feature); got != tc.want {
+				t.Errorf("Supports(%s) = %v, want %v", tc.feature.Name, got, tc.want)
+			}
+
+			err := tc.capabilities.CheckSupported(tc.feature)
+			if (err == nil) != tc.want {
+				t.Errorf("CheckSupported(%s) error = %v, want supported %v", tc.feature.Name, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestUnsupportedFeatureError(t *testing.T) {
+	t.Parallel()
+
+	err := Capabilities{Version: &ServerVersion{Major: 9, Minor: 9}, Edition: EditionDeveloper}.CheckSupported(testEditionFeature)
+
+	want := "using edition feature requires SonarQube 10.7.0 or later and the developer, enterprise, datacenter edition, " +
+		"the SonarQube instance runs version 9.9.0 developer edition"
+	if err == nil || err.Error() != want {
+		t.Errorf("CheckSupported() error = %v, want %q", err, want)
+	}
+}
+
+func TestCapabilitiesCache(t *testing.T) {
+	t.Parallel()
+
+	cache := NewCapabilitiesCache(time.Minute)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	probes := 0
+	probe := func() (Capabilities, error) {
+		probes++
+
+		return Capabilities{Version: &ServerVersion{Major: 2025, Minor: probes}}, nil
+	}
+
+	key := &ClientCacheKey{ProviderConfigUID: "uid", Version: "1"}
+
+	first, err := cache.Get(key, probe)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, _ := cache.Get(key, probe); got.Version != first.Version || probes != 1 {
+		t.Errorf("Get() probed again within the TTL, %d probes", probes)
+	}
+
+	if _, _ = cache.Get(&ClientCacheKey{ProviderConfigUID: "uid", Version: "2"}, probe); probes != 2 {
+		t.Errorf("Get() did not probe again after the ProviderConfig changed, %d probes", probes)
+	}
+
+	now = now.Add(2 * time.Minute)
+
+	if _, _ = cache.Get(&ClientCacheKey{ProviderConfigUID: "uid", Version: "2"}, probe); probes != 3 {
+		t.Errorf("Get() did not probe again after the TTL, %d probes", probes)
+	}
+
+	if _, _ = cache.Get(nil, probe); probes != 4 {
+		t.Errorf("Get() cached capabilities without a key, %d probes", probes)
+	}
+
+	errBoom := errors.New("boom")
+	if _, err := cache.Get(&ClientCacheKey{ProviderConfigUID: "other"}, func() (Capabilities, error) { return Capabilities{}, errBoom }); !errors.Is(err, errBoom) {
+		t.Errorf("Get() error = %v, want %v", err, errBoom)
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/boxboxjason/sonarqube-client-go/sonar"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultClientCacheSize is the maximum number of SonarQube clients kept by the shared client cache.
+const DefaultClientCacheSize = 64
+
+// clients is the client cache shared by all the controllers.
+var clients = NewClientCache(DefaultClientCacheSize)
+
+// GetClient returns the SonarQube client of the given configuration from the client cache shared by all the controllers.
+// Configurations without a cache key, i.e. not built from a ProviderConfig, always get a new client.
+func GetClient(clientConfig Config) (*sonar.Client, error) {
+	if clientConfig.CacheKey == nil {
+		return NewClient(clientConfig)
+	}
+
+	return clients.Get(clientConfig)
+}
+
+// InvalidateClient removes the client of a ProviderConfig from the client cache shared by all the controllers,
+// so that the client and the credentials it holds are released as soon as the ProviderConfig is deleted.
+func InvalidateClient(providerConfigUID types.UID) {
+	clients.Invalidate(providerConfigUID)
+}
+
+// ClientCacheKey identifies the version of a ProviderConfig and of the credentials a SonarQube client was built from.
+type ClientCacheKey struct {
+	// ProviderConfigUID is the UID of the ProviderConfig
+	ProviderConfigUID types.UID
+	// Version identifies the generation of the ProviderConfig, the resource versions of its Secrets and its resolved credentials
+	Version string
+}
+
+// clientCacheEntry is a SonarQube client kept in the client cache.
+type clientCacheEntry struct {
+	key        ClientCacheKey
+	client     *sonar.Client
+	httpClient *http.Client
+}
+
+// ClientCache keeps the SonarQube clients of the most recently used ProviderConfigs, so that their connections are reused across reconciles.
+// A client is replaced as soon as its ProviderConfig or one of the Secrets it references changes.
+type ClientCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[types.UID]*list.Element
+	// lru holds the entries, the most recently used first
+	lru *list.List
+	// newHTTPClient creates the HTTP client of a new SonarQube client
+	newHTTPClient func(clientConfig Config) *http.Client
+}
+
+// NewClientCache creates a client cache holding at most size clients.
+func NewClientCache(size int) *ClientCache {
+	return &ClientCache{
+		size:          max(size, 1),
+		entries:       make(map[types.UID]*list.Element),
+		lru:           list.New(),
+		newHTTPClient: newHTTPClient,
+	}
+}
+
+// Get returns the cached client of the configuration, or creates it if the ProviderConfig has no cached client or if it changed.
+// The configuration must have a cache key. Clients that cannot be created are not cached.
+func (c *ClientCache) Get(clientConfig Config) (*sonar.Client, error) {
+	key := *clientConfig.CacheKey
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key.ProviderConfigUID]; ok {
+		entry, _ := element.Value.(*clientCacheEntry)
+		if entry.key == key {
+			c.lru.MoveToFront(element)
+
+			return entry.client, nil
+		}
+
+		c.remove(element)
+	}
+
+	httpClient := c.newHTTPClient(clientConfig)
+
+	sonarClient, err := newClientWithHTTPClient(clientConfig, httpClient)
+	if err != nil {
+		httpClient.CloseIdleConnections()
+
+		return nil, err
+	}
+
+	entry := &clientCacheEntry{
+		key:        key,
+		client:     sonarClient,
+		httpClient: httpClient,
+	}
+
+	c.entries[key.ProviderConfigUID] = c.lru.PushFront(entry)
+
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+	}
+
+	return entry.client, nil
+}
+
+// Invalidate removes the cached client of a ProviderConfig, it is called when the ProviderConfig is deleted.
+func (c *ClientCache) Invalidate(providerConfigUID types.UID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[providerConfigUID]; ok {
+		c.remove(element)
+	}
+}
+
+// Len returns the number of cached clients.
+func (c *ClientCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// remove removes an entry from the cache and closes the idle connections of its client.
+// In-flight requests of the client are not interrupted.
+func (c *ClientCache) remove(element *list.Element) {
+	entry, _ := c.lru.Remove(element).(*clientCacheEntry)
+	delete(c.entries, entry.key.ProviderConfigUID)
+
+	entry.httpClient.CloseIdleConnections()
+}
+
+// secretVersionRecorder is a Kubernetes client recording the resource version of the Secrets it reads,
+// so that a client built from them can be invalidated when they change.
+type secretVersionRecorder struct {
+	client.Client
+
+	mu       sync.Mutex
+	versions map[string]string
+}
+
+// newSecretVersionRecorder wraps the given Kubernetes client to record the resource version of the Secrets it reads.
+func newSecretVersionRecorder(kubeClient client.Client) *secretVersionRecorder {
+	return &secretVersionRecorder{Client: kubeClient, versions: make(map[string]string)}
+}
+
+// Get gets the object and records its resource version if it is a Secret.
+func (r *secretVersionRecorder) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := r.Client.Get(ctx, key, obj, opts...)
+	if err != nil {
+		return err
+	}
+
+	if secret, ok := obj.(*corev1.Secret); ok {
+		r.mu.Lock()
+		r.versions[key.String()] = secret.ResourceVersion
+		r.mu.Unlock()
+	}
+
+	return nil
+}
+
+// newClientCacheKey builds the cache key of a client built from the given ProviderConfig, the Secrets it read and the resolved credentials.
+// The credentials are part of the key as they can also come from files, which have no resource version.
+func newClientCacheKey(uid types.UID, generation int64, secrets *secretVersionRecorder, clientConfig *Config) *ClientCacheKey {
+	secrets.mu.Lock()
+	versions := make([]string, 0, len(secrets.versions))
+
+	for name, version := range secrets.versions {
+		versions = append(versions, name+"@"+version)
+	}
+	secrets.mu.Unlock()
+
+	slices.Sort(versions)
+
+	hash := sha256.New()
+	_, _ = fmt.Fprintf(hash, "%s\x00%s", clientConfig.AuthType, clientConfig.Token)
+
+	if clientConfig.BasicAuth != nil {
+		_, _ = fmt.Fprintf(hash, "\x00%s\x00%s", clientConfig.BasicAuth.Username, clientConfig.BasicAuth.Password)
+	}
+
+	if clientConfig.OAuth2 != nil {
+		_, _ = fmt.Fprintf(hash, "\x00%s\x00%s\x00%s\x00%s", clientConfig.OAuth2.TokenURL, clientConfig.OAuth2.ClientID,
+			clientConfig.OAuth2.ClientSecret, strings.Join(clientConfig.OAuth2.Scopes, " "))
+	}
+
+	return &ClientCacheKey{
+		ProviderConfigUID: uid,
+		Version:           fmt.Sprintf("%d;%s;%s", generation, strings.Join(versions, ","), hex.EncodeToString(hash.Sum(nil))),
+	}
+}
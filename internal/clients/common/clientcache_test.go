@@ -0,0 +1,170 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/boxboxjason/sonarqube-client-go/sonar"
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// newCacheableConfig creates a configuration with the given cache key.
+func newCacheableConfig(uid types.UID, version string) Config {
+	return Config{
+		AuthType: PersonalAccessToken,
+		Token:    "token",
+		BaseURL:  "https://sonarqube.example.com/api/",
+		CacheKey: &ClientCacheKey{ProviderConfigUID: uid, Version: version},
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	t.Parallel()
+
+	cache := NewClientCache(2)
+
+	get := func(config Config) *sonar.Client {
+		t.Helper()
+
+		got, err := cache.Get(config)
+		if err != nil {
+			t.Fatalf("Get() unexpected error = %v", err)
+		}
+
+		return got
+	}
+
+	first := get(newCacheableConfig("team-a", "1"))
+
+	if got := get(newCacheableConfig("team-a", "1")); got != first {
+		t.Error("Get() created a new client for an unchanged ProviderConfig")
+	}
+
+	updated := get(newCacheableConfig("team-a", "2"))
+	if updated == first {
+		t.Error("Get() reused the client of a changed ProviderConfig")
+	}
+
+	if got := cache.Len(); got != 1 {
+		t.Errorf("Len() = %d, want 1 after the ProviderConfig changed", got)
+	}
+
+	teamB := get(newCacheableConfig("team-b", "1"))
+	// team-a is now the most recently used, so team-b is evicted when team-c is added.
+	get(newCacheableConfig("team-a", "2"))
+	get(newCacheableConfig("team-c", "1"))
+
+	if got := cache.Len(); got != 2 {
+		t.Errorf("Len() = %d, want the size of the cache 2", got)
+	}
+
+	if got := get(newCacheableConfig("team-a", "2")); got != updated {
+		t.Error("Get() evicted the most recently used client")
+	}
+
+	if got := get(newCacheableConfig("team-b", "1")); got == teamB {
+		t.Error("Get() did not evict the least recently used client")
+	}
+
+	cache.Invalidate("team-b")
+
+	if got := cache.Len(); got != 1 {
+		t.Errorf("Len() = %d, want 1 after Invalidate()", got)
+	}
+
+	invalid := newCacheableConfig("team-d", "1")
+	invalid.BaseURL = "sonarqube.example.com"
+
+	if _, err := cache.Get(invalid); err == nil {
+		t.Error("Get() expected an error for an invalid configuration")
+	}
+
+	if got := cache.Len(); got != 1 {
+		t.Errorf("Len() = %d, want 1 after a client could not be created", got)
+	}
+}
+
+func TestGetClientWithoutCacheKey(t *testing.T) {
+	t.Parallel()
+
+	config := Config{AuthType: PersonalAccessToken, Token: "token", BaseURL: "https://sonarqube.example.com/api/"}
+
+	first, err := GetClient(config)
+	if err != nil {
+		t.Fatalf("GetClient() unexpected error = %v", err)
+	}
+
+	second, err := GetClient(config)
+	if err != nil {
+		t.Fatalf("GetClient() unexpected error = %v", err)
+	}
+
+	if first == second {
+		t.Error("GetClient() reused a client for a configuration without cache key")
+	}
+}
+
+func TestNewClientCacheKey(t *testing.T) {
+	t.Parallel()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "sonarqube", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("s3cr3t")},
+	}
+	kube := newFakeClient(secret)
+	selector := &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "sonarqube", Namespace: "default"}, Key: "token"}
+
+	keyFor := func(generation int64, token string) *ClientCacheKey {
+		recorder := newSecretVersionRecorder(kube)
+
+		_, err := GetTokenValueFromSecret(context.Background(), recorder, &fake.Managed{}, selector)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return newClientCacheKey("uid", generation, recorder, &Config{AuthType: PersonalAccessToken, Token: token})
+	}
+
+	initial := keyFor(1, "s3cr3t")
+
+	if got := keyFor(1, "s3cr3t"); *got != *initial {
+		t.Errorf("newClientCacheKey() = %v, want the same key %v for unchanged inputs", got, initial)
+	}
+
+	if got := keyFor(2, "s3cr3t"); *got == *initial {
+		t.Error("newClientCacheKey() did not change with the generation of the ProviderConfig")
+	}
+
+	if got := keyFor(1, "rotated"); *got == *initial {
+		t.Error("newClientCacheKey() did not change with the credentials")
+	}
+
+	secret.Data["token"] = []byte("rotated")
+	if err := kube.Update(context.Background(), secret); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := keyFor(1, "s3cr3t"); *got == *initial {
+		t.Error("newClientCacheKey() did not change with the resource version of the Secret")
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+)
+
+// InjectedIdentityTokenPath is the well-known path of the token mounted in the provider pod
+// when the InjectedIdentity credentials source is used.
+const InjectedIdentityTokenPath = "/var/run/secrets/sonarqube.crossplane.io/token" //nolint:gosec // This is a path, not a credential
+
+const (
+	errCredentialsEnvEmpty    = "environment variable %s is empty"
+	errCredentialsFileRead    = "cannot read credentials file %s"
+	errCredentialsFileEmpty   = "credentials file %s is empty"
+	errCredentialsUnsupported = "credentials source %s is not supported"
+)
+
+// validateCredentialsSource validates that the selector required by the credentials source is set.
+// InjectedIdentity is only allowed when allowInjectedIdentity is true, as it provides a single token.
+func validateCredentialsSource(name string, credentials *v1alpha1.ProviderCredentials, allowInjectedIdentity bool) error {
+	switch credentials.Source {
+	case xpv1.CredentialsSourceSecret:
+		if credentials.SecretRef == nil {
+			return errors.Errorf("secretRef must be provided for %s", name)
+		}
+	case xpv1.CredentialsSourceEnvironment:
+		if credentials.Env == nil {
+			return errors.Errorf("env must be provided for %s", name)
+		}
+	case xpv1.CredentialsSourceFilesystem:
+		if credentials.Fs == nil {
+			return errors.Errorf("fs must be provided for %s", name)
+		}
+	case xpv1.CredentialsSourceInjectedIdentity:
+		if !allowInjectedIdentity {
+			return errors.Errorf("credentials source %s for %s is not currently supported", credentials.Source, name)
+		}
+	default:
+		return errors.Errorf("credentials source %s for %s is not currently supported", credentials.Source, name)
+	}
+
+	return nil
+}
+
+// ExtractCredentials extracts the value of the provider credentials from their source.
+// Files are read on every call so that rotated credentials (projected volumes, Vault agent...) are picked up on the next connection.
+func ExtractCredentials(ctx context.Context, kubeClient client.Client, managedResource resource.Managed, credentials *v1alpha1.ProviderCredentials) (string, error) {
+	switch credentials.Source {
+	case xpv1.CredentialsSourceSecret:
+		value, err := GetTokenValueFromSecret(ctx, kubeClient, managedResource, credentials.SecretRef)
+		if err != nil {
+			return "", err
+		}
+
+		return *value, nil
+	case xpv1.CredentialsSourceEnvironment:
+		if credentials.Env == nil {
+			return "", errors.New("env must be provided")
+		}
+
+		value := os.Getenv(credentials.Env.Name)
+		if value == "" {
+			return "", errors.Errorf(errCredentialsEnvEmpty, credentials.Env.Name)
+		}
+
+		return value, nil
+	case xpv1.CredentialsSourceFilesystem:
+		if credentials.Fs == nil {
+			return "", errors.New("fs must be provided")
+		}
+
+		return readCredentialsFile(credentials.Fs.Path)
+	case xpv1.CredentialsSourceInjectedIdentity:
+		return readCredentialsFile(InjectedIdentityTokenPath)
+	default:
+		return "", errors.Errorf(errCredentialsUnsupported, credentials.Source)
+	}
+}
+
+// readCredentialsFile reads credentials from a file, ignoring the surrounding whitespace.
+func readCredentialsFile(path string) (string, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // The path is configured by the cluster administrator
+	if err != nil {
+		return "", errors.Wrapf(err, errCredentialsFileRead, path)
+	}
+
+	value := strings.TrimSpace(string(data))
+	if value == "" {
+		return "", errors.Errorf(errCredentialsFileEmpty, path)
+	}
+
+	return value, nil
+}
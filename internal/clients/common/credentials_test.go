@@ -0,0 +1,280 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+)
+
+func TestDetermineAuthType(t *testing.T) {
+	t.Parallel()
+
+	secretCredentials := &v1alpha1.ProviderCredentials{
+		Source: xpv1.CredentialsSourceSecret,
+		CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+			SecretRef: &xpv1.SecretKeySelector{Key: "token"},
+		},
+	}
+
+	tests := map[string]struct {
+		spec        v1alpha1.ProviderConfigSpec
+		want        AuthType
+		errContains string
+	}{
+		"TokenFromSecret": {
+			spec: v1alpha1.ProviderConfigSpec{Token: secretCredentials},
+			want: PersonalAccessToken,
+		},
+		"TokenFromEnvironment": {
+			spec: v1alpha1.ProviderConfigSpec{Token: &v1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceEnvironment,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					Env: &xpv1.EnvSelector{Name: "SONARQUBE_TOKEN"},
+				},
+			}},
+			want: PersonalAccessToken,
+		},
+		"TokenFromFilesystemWithoutPath": {
+			spec:        v1alpha1.ProviderConfigSpec{Token: &v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceFilesystem}},
+			errContains: "fs must be provided for token",
+		},
+		"TokenFromInjectedIdentity": {
+			spec: v1alpha1.ProviderConfigSpec{Token: &v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceInjectedIdentity}},
+			want: PersonalAccessToken,
+		},
+		"TokenFromNone": {
+			spec:        v1alpha1.ProviderConfigSpec{Token: &v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceNone}},
+			errContains: "credentials source None for token is not currently supported",
+		},
+		"BasicAuthFromFilesystem": {
+			spec: v1alpha1.ProviderConfigSpec{
+				Username: &v1alpha1.ProviderCredentials{
+					Source:                    xpv1.CredentialsSourceFilesystem,
+					CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Fs: &xpv1.FsSelector{Path: "/creds/username"}},
+				},
+				Password: &v1alpha1.ProviderCredentials{
+					Source:                    xpv1.CredentialsSourceFilesystem,
+					CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Fs: &xpv1.FsSelector{Path: "/creds/password"}},
+				},
+			},
+			want: BasicAuth,
+		},
+		"BasicAuthFromInjectedIdentity": {
+			spec: v1alpha1.ProviderConfigSpec{
+				Username: secretCredentials,
+				Password: &v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceInjectedIdentity},
+			},
+			errContains: "credentials source InjectedIdentity for password is not currently supported",
+		},
+		"OAuth2FromSecret": {
+			spec: v1alpha1.ProviderConfigSpec{OAuth2: &v1alpha1.OAuth2ClientCredentials{
+				TokenURL:     "https://idp.example.com/oauth2/token",
+				ClientID:     *secretCredentials,
+				ClientSecret: *secretCredentials,
+			}},
+			want: OAuth2ClientCredentials,
+		},
+		"OAuth2FromInjectedIdentity": {
+			spec: v1alpha1.ProviderConfigSpec{OAuth2: &v1alpha1.OAuth2ClientCredentials{
+				TokenURL:     "https://idp.example.com/oauth2/token",
+				ClientID:     *secretCredentials,
+				ClientSecret: v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceInjectedIdentity},
+			}},
+			errContains: "credentials source InjectedIdentity for clientSecret is not currently supported",
+		},
+		"NoCredentials": {
+			spec:        v1alpha1.ProviderConfigSpec{},
+			errContains: "no valid authentication method found",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := determineAuthType(tc.spec)
+			if tc.errContains != "" {
+				if err == nil || !containsString(err.Error(), tc.errContains) {
+					t.Errorf("determineAuthType() error = %v, should contain %v", err, tc.errContains)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("determineAuthType() unexpected error = %v", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("determineAuthType() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExtractCredentials(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	kube := newFakeClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "sonarqube", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("secret-token")},
+	})
+
+	tests := map[string]struct {
+		credentials *v1alpha1.ProviderCredentials
+		want        string
+		errContains string
+	}{
+		"Secret": {
+			credentials: &v1alpha1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					SecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Name: "sonarqube", Namespace: "default"},
+						Key:             "token",
+					},
+				},
+			},
+			want: "secret-token",
+		},
+		"Filesystem": {
+			credentials: &v1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceFilesystem,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Fs: &xpv1.FsSelector{Path: tokenFile}},
+			},
+			want: "file-token",
+		},
+		"FilesystemEmptyFile": {
+			credentials: &v1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceFilesystem,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Fs: &xpv1.FsSelector{Path: emptyFile}},
+			},
+			errContains: "is empty",
+		},
+		"FilesystemMissingFile": {
+			credentials: &v1alpha1.ProviderCredentials{
+				Source:                    xpv1.CredentialsSourceFilesystem,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Fs: &xpv1.FsSelector{Path: filepath.Join(dir, "missing")}},
+			},
+			errContains: "cannot read credentials file",
+		},
+		"InjectedIdentityNotMounted": {
+			credentials: &v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceInjectedIdentity},
+			errContains: InjectedIdentityTokenPath,
+		},
+		"None": {
+			credentials: &v1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceNone},
+			errContains: "credentials source None is not supported",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ExtractCredentials(context.Background(), kube, &fake.Managed{}, tc.credentials)
+			if tc.errContains != "" {
+				if err == nil || !containsString(err.Error(), tc.errContains) {
+					t.Errorf("ExtractCredentials() error = %v, should contain %v", err, tc.errContains)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ExtractCredentials() unexpected error = %v", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("ExtractCredentials() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExtractCredentialsFilesystemRotation(t *testing.T) {
+	t.Parallel()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	credentials := &v1alpha1.ProviderCredentials{
+		Source:                    xpv1.CredentialsSourceFilesystem,
+		CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Fs: &xpv1.FsSelector{Path: tokenFile}},
+	}
+
+	for _, token := range []string{"first", "rotated"} {
+		if err := os.WriteFile(tokenFile, []byte(token), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := ExtractCredentials(context.Background(), newFakeClient(), &fake.Managed{}, credentials)
+		if err != nil {
+			t.Fatalf("ExtractCredentials() unexpected error = %v", err)
+		}
+
+		if got != token {
+			t.Errorf("ExtractCredentials() = %v, want %v", got, token)
+		}
+	}
+}
+
+//nolint:paralleltest // t.Setenv cannot be used in parallel tests
+func TestExtractCredentialsEnvironment(t *testing.T) {
+	credentials := &v1alpha1.ProviderCredentials{
+		Source:                    xpv1.CredentialsSourceEnvironment,
+		CommonCredentialSelectors: xpv1.CommonCredentialSelectors{Env: &xpv1.EnvSelector{Name: "PROVIDER_SONARQUBE_TEST_TOKEN"}},
+	}
+
+	t.Setenv("PROVIDER_SONARQUBE_TEST_TOKEN", "env-token")
+
+	got, err := ExtractCredentials(context.Background(), newFakeClient(), &fake.Managed{}, credentials)
+	if err != nil {
+		t.Fatalf("ExtractCredentials() unexpected error = %v", err)
+	}
+
+	if got != "env-token" {
+		t.Errorf("ExtractCredentials() = %v, want env-token", got)
+	}
+
+	t.Setenv("PROVIDER_SONARQUBE_TEST_TOKEN", "")
+
+	_, err = ExtractCredentials(context.Background(), newFakeClient(), &fake.Managed{}, credentials)
+	if err == nil || !containsString(err.Error(), "is empty") {
+		t.Errorf("ExtractCredentials() error = %v, should contain is empty", err)
+	}
+}
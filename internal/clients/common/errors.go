@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"net/http"
+
+	"github.com/boxboxjason/sonarqube-client-go/sonar"
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+)
+
+// Reasons of the Ready condition of a managed resource whose SonarQube request failed.
+// v1alpha1.ReasonUnreachable is used when no response is received from SonarQube, e.g. on a timeout.
+const (
+	// ReasonNotFound is used when the SonarQube object does not exist (HTTP 404).
+	ReasonNotFound xpv1.ConditionReason = "NotFound"
+	// ReasonUnauthorized is used when SonarQube rejects the credentials of the ProviderConfig (HTTP 401).
+	ReasonUnauthorized xpv1.ConditionReason = "Unauthorized"
+	// ReasonForbidden is used when the credentials of the ProviderConfig lack a permission (HTTP 403).
+	ReasonForbidden xpv1.ConditionReason = "Forbidden"
+	// ReasonRequestRejected is used when SonarQube rejects the request for another reason (other HTTP 4xx).
+	ReasonRequestRejected xpv1.ConditionReason = "RequestRejected"
+	// ReasonServerError is used when SonarQube fails to handle the request (HTTP 5xx).
+	ReasonServerError xpv1.ConditionReason = "ServerError"
+)
+
+// APIError is an error of a request to the SonarQube API, classified from the status of its response.
+type APIError struct {
+	// Reason classifies the error
+	Reason xpv1.ConditionReason
+	// StatusCode is the HTTP status of the response, 0 if no response was received
+	StatusCode int
+
+	err error
+}
+
+// Error returns the message of the underlying error.
+func (e *APIError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *APIError) Unwrap() error {
+	return e.err
+}
+
+// ClassifyError classifies the error of a request to the SonarQube API from the status of its response.
+// The response may be nil, in which case the response of a sonar.ResponseError is used. It returns nil if err is nil.
+func ClassifyError(resp *http.Response, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if resp == nil {
+		var responseError *sonar.ResponseError
+		if errors.As(err, &responseError) {
+			resp = responseError.Response
+		}
+	}
+
+	apiError := &APIError{Reason: v1alpha1.ReasonUnreachable, err: err}
+
+	if resp == nil {
+		return apiError
+	}
+
+	apiError.StatusCode = resp.StatusCode
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		apiError.Reason = ReasonNotFound
+	case resp.StatusCode == http.StatusUnauthorized:
+		apiError.Reason = ReasonUnauthorized
+	case resp.StatusCode == http.StatusForbidden:
+		apiError.Reason = ReasonForbidden
+	case resp.StatusCode >= http.StatusInternalServerError:
+		apiError.Reason = ReasonServerError
+	case resp.StatusCode >= http.StatusBadRequest:
+		apiError.Reason = ReasonRequestRejected
+	}
+
+	return apiError
+}
+
+// IsNotFound checks whether the error is a classified error of a request for a SonarQube object that does not exist.
+func IsNotFound(err error) bool {
+	var apiError *APIError
+
+	return errors.As(err, &apiError) && apiError.Reason == ReasonNotFound
+}
+
+// IsRequestRejected checks whether the error is a classified error of a request SonarQube rejected as invalid (HTTP 400 or other 4xx).
+func IsRequestRejected(err error) bool {
+	var apiError *APIError
+
+	return errors.As(err, &apiError) && apiError.Reason == ReasonRequestRejected
+}
+
+// RequestFailed returns a Ready condition that indicates the state of the SonarQube object is unknown because a request failed.
+// Its reason is the classification of the error, or v1alpha1.ReasonUnreachable if the error is not classified.
+func RequestFailed(err error) xpv1.Condition {
+	reason := v1alpha1.ReasonUnreachable
+
+	var apiError *APIError
+	if errors.As(err, &apiError) {
+		reason = apiError.Reason
+	}
+
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionUnknown,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            err.Error(),
+	}
+}
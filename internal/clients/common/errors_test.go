@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/boxboxjason/sonarqube-client-go/sonar"
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+)
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+
+	errBoom := errors.New("boom")
+
+	// responseError is the error returned by the SonarQube client for an unsuccessful response.
+	responseError := &sonar.ResponseError{
+		Response: &http.Response{
+			StatusCode: http.StatusNotFound,
+			Request:    &http.Request{Method: http.MethodGet, URL: &url.URL{Scheme: "https", Host: "sonarqube.example.com", Path: "/api/qualitygates/show"}},
+		},
+	}
+
+	tests := map[string]struct {
+		resp           *http.Response
+		err            error
+		wantReason     xpv1.ConditionReason
+		wantStatusCode int
+		wantNotFound   bool
+	}{
+		"NotFound": {
+			resp:           &http.Response{StatusCode: http.StatusNotFound},
+			err:            errBoom,
+			wantReason:     ReasonNotFound,
+			wantStatusCode: http.StatusNotFound,
+			wantNotFound:   true,
+		},
+		"NotFoundFromResponseError": {
+			err:            errors.Wrap(responseError, "cannot get quality gate"),
+			wantReason:     ReasonNotFound,
+			wantStatusCode: http.StatusNotFound,
+			wantNotFound:   true,
+		},
+		"Unauthorized": {
+			resp:           &http.Response{StatusCode: http.StatusUnauthorized},
+			err:            errBoom,
+			wantReason:     ReasonUnauthorized,
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		"Forbidden": {
+			resp:           &http.Response{StatusCode: http.StatusForbidden},
+			err:            errBoom,
+			wantReason:     ReasonForbidden,
+			wantStatusCode: http.StatusForbidden,
+		},
+		"BadRequest": {
+			resp:           &http.Response{StatusCode: http.StatusBadRequest},
+			err:            errBoom,
+			wantReason:     ReasonRequestRejected,
+			wantStatusCode: http.StatusBadRequest,
+		},
+		"ServerError": {
+			resp:           &http.Response{StatusCode: http.StatusBadGateway},
+			err:            errBoom,
+			wantReason:     ReasonServerError,
+			wantStatusCode: http.StatusBadGateway,
+		},
+		"NoResponse": {
+			err:        errBoom,
+			wantReason: v1alpha1.ReasonUnreachable,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ClassifyError(tc.resp, tc.err)
+
+			var apiError *APIError
+			if !errors.As(err, &apiError) {
+				t.Fatalf("ClassifyError() = %v, want an *APIError", err)
+			}
+
+			if apiError.Reason != tc.wantReason || apiError.StatusCode != tc.wantStatusCode {
+				t.Errorf("ClassifyError() = %s (%d), want %s (%d)", apiError.Reason, apiError.StatusCode, tc.wantReason, tc.wantStatusCode)
+			}
+
+			if got := IsNotFound(errors.Wrap(err, "cannot observe")); got != tc.wantNotFound {
+				t.Errorf("IsNotFound() = %v, want %v", got, tc.wantNotFound)
+			}
+
+			if got, want := IsRequestRejected(errors.Wrap(err, "cannot deactivate")), tc.wantReason == ReasonRequestRejected; got != want {
+				t.Errorf("IsRequestRejected() = %v, want %v", got, want)
+			}
+
+			if !errors.Is(err, tc.err) {
+				t.Errorf("ClassifyError() = %v, want it to wrap %v", err, tc.err)
+			}
+
+			condition := RequestFailed(errors.Wrap(err, "cannot observe"))
+			if condition.Type != xpv1.TypeReady || condition.Status != corev1.ConditionUnknown || condition.Reason != tc.wantReason {
+				t.Errorf("RequestFailed() = %s=%s (%s), want Ready=Unknown (%s)", condition.Type, condition.Status, condition.Reason, tc.wantReason)
+			}
+		})
+	}
+}
+
+func TestClassifyErrorNil(t *testing.T) {
+	t.Parallel()
+
+	if err := ClassifyError(&http.Response{StatusCode: http.StatusOK}, nil); err != nil {
+		t.Errorf("ClassifyError() = %v, want nil", err)
+	}
+
+	if IsNotFound(errors.New("boom")) {
+		t.Error("IsNotFound() = true for an unclassified error")
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/pkg/errors"
+	"golang.org/x/net/http/httpproxy"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+)
+
+// DefaultHTTPTimeout is the maximum duration of an attempt of a request to SonarQube when no timeout is configured.
+const DefaultHTTPTimeout = 30 * time.Second
+
+const (
+	errParseProxyURL  = "cannot parse proxyURL"
+	errGetHeaderValue = "cannot get value of header %s from secret"
+)
+
+// HTTPConfig is the HTTP configuration used to connect to the SonarQube instance.
+type HTTPConfig struct {
+	// ProxyURL is the URL of the proxy, nil to use the proxy environment variables
+	ProxyURL *url.URL
+	// NoProxy is the comma separated list of hosts that must not be reached through ProxyURL
+	NoProxy string
+	// Timeout is the maximum duration of an attempt of a request, 0 to use DefaultHTTPTimeout
+	Timeout time.Duration
+	// IdleConnTimeout is the maximum duration an idle connection is kept open, 0 to use the default
+	IdleConnTimeout time.Duration
+	// Headers are extra headers sent with every request
+	Headers http.Header
+}
+
+// buildHTTPConfig builds the HTTP configuration from the HTTP configuration of a ProviderConfig, resolving secret-backed headers.
+// It returns nil if no HTTP configuration is given.
+func buildHTTPConfig(ctx context.Context, kubeClient client.Client, managedResource resource.Managed, spec *v1alpha1.HTTPConfig) (*HTTPConfig, error) {
+	if spec == nil {
+		return nil, nil //nolint:nilnil // No HTTP configuration is not an error
+	}
+
+	httpConfig := &HTTPConfig{
+		NoProxy: strings.Join(spec.NoProxy, ","),
+	}
+
+	if spec.ProxyURL != nil {
+		proxyURL, err := url.Parse(*spec.ProxyURL)
+		if err != nil {
+			return nil, errors.Wrap(err, errParseProxyURL)
+		}
+
+		httpConfig.ProxyURL = proxyURL
+	}
+
+	if spec.Timeout != nil {
+		httpConfig.Timeout = spec.Timeout.Duration
+	}
+
+	if spec.IdleConnTimeout != nil {
+		httpConfig.IdleConnTimeout = spec.IdleConnTimeout.Duration
+	}
+
+	if len(spec.Headers) > 0 {
+		httpConfig.Headers = make(http.Header, len(spec.Headers))
+	}
+
+	for _, header := range spec.Headers {
+		value := header.Value
+
+		if header.ValueSecretRef != nil {
+			secretValue, err := GetTokenValueFromSecret(ctx, kubeClient, managedResource, header.ValueSecretRef)
+			if err != nil {
+				return nil, errors.Wrapf(err, errGetHeaderValue, header.Name)
+			}
+
+			value = secretValue
+		}
+
+		if value != nil {
+			httpConfig.Headers.Set(header.Name, *value)
+		}
+	}
+
+	return httpConfig, nil
+}
+
+// newHTTPClient creates the HTTP client used by the SonarQube client from the TLS, HTTP, OAuth2, rate limit and retry configurations.
+// Retries go through the rate limiter, so that they also count toward the rate limit.
+// The timeout bounds each attempt once the rate limiter allows it, so that waiting for the rate limiter or between retries never times out a request.
+func newHTTPClient(clientConfig Config) *http.Client {
+	transport := cleanhttp.DefaultPooledTransport()
+	transport.TLSClientConfig = newTLSClientConfig(clientConfig)
+
+	httpClient := &http.Client{}
+	timeout := DefaultHTTPTimeout
+
+	var roundTripper http.RoundTripper = transport
+
+	if httpConfig := clientConfig.HTTP; httpConfig != nil {
+		if httpConfig.ProxyURL != nil {
+			proxyFunc := (&httpproxy.Config{
+				HTTPProxy:  httpConfig.ProxyURL.String(),
+				HTTPSProxy: httpConfig.ProxyURL.String(),
+				NoProxy:    httpConfig.NoProxy,
+			}).ProxyFunc()
+
+			transport.Proxy = func(req *http.Request) (*url.URL, error) {
+				return proxyFunc(req.URL)
+			}
+		}
+
+		if httpConfig.Timeout > 0 {
+			timeout = httpConfig.Timeout
+		}
+
+		if httpConfig.IdleConnTimeout > 0 {
+			transport.IdleConnTimeout = httpConfig.IdleConnTimeout
+		}
+
+		if len(httpConfig.Headers) > 0 {
+			roundTripper = &headerRoundTripper{headers: httpConfig.Headers, next: transport}
+		}
+	}
+
+	if clientConfig.AuthType == OAuth2ClientCredentials {
+		// The token endpoint is reached with the TLS and proxy settings, but without the extra headers meant for SonarQube.
+		tokenClient := &http.Client{Timeout: timeout, Transport: transport}
+		roundTripper = newOAuth2RoundTripper(clientConfig.OAuth2, tokenClient, roundTripper)
+	}
+
+	roundTripper = &timeoutRoundTripper{timeout: timeout, next: roundTripper}
+	roundTripper = newRateLimitRoundTripper(clientConfig.RateLimit, roundTripper)
+	httpClient.Transport = newRetryRoundTripper(clientConfig.Retry, roundTripper)
+
+	return httpClient
+}
+
+// headerRoundTripper adds extra headers to every request.
+type headerRoundTripper struct {
+	headers http.Header
+	next    http.RoundTripper
+}
+
+// RoundTrip adds the extra headers to a copy of the request before sending it.
+func (h *headerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+
+	for name, values := range h.headers {
+		req.Header[name] = values
+	}
+
+	return h.next.RoundTrip(req)
+}
+
+// timeoutRoundTripper bounds each request it sends, including reading the response body, to the timeout.
+type timeoutRoundTripper struct {
+	timeout time.Duration
+	next    http.RoundTripper
+}
+
+// RoundTrip sends the request with a deadline, which is released once the response body is closed.
+func (t *timeoutRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// cancelOnCloseBody is a response body that cancels the context of its request when it is closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and cancels the context of its request.
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+
+	return err
+}
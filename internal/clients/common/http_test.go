@@ -0,0 +1,301 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+)
+
+func TestBuildHTTPConfig(t *testing.T) {
+	t.Parallel()
+
+	kube := newFakeClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: "default"},
+		Data:       map[string][]byte{"apiKey": []byte("s3cr3t")},
+	})
+
+	proxyURL, _ := url.Parse("http://proxy.example.com:3128")
+
+	tests := map[string]struct {
+		spec    *v1alpha1.HTTPConfig
+		want    *HTTPConfig
+		wantErr bool
+	}{
+		"Nil": {
+			spec: nil,
+			want: nil,
+		},
+		"AllFields": {
+			spec: &v1alpha1.HTTPConfig{
+				ProxyURL:        ptr.To("http://proxy.example.com:3128"),
+				NoProxy:         []string{"sonarqube.internal", ".example.com"},
+				Timeout:         &metav1.Duration{Duration: 10 * time.Second},
+				IdleConnTimeout: &metav1.Duration{Duration: time.Minute},
+				Headers: []v1alpha1.HTTPHeader{
+					{Name: "X-Team", Value: ptr.To("platform")},
+					{Name: "x-api-key", ValueSecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Name: "gateway", Namespace: "default"},
+						Key:             "apiKey",
+					}},
+				},
+			},
+			want: &HTTPConfig{
+				ProxyURL:        proxyURL,
+				NoProxy:         "sonarqube.internal,.example.com",
+				Timeout:         10 * time.Second,
+				IdleConnTimeout: time.Minute,
+				Headers: http.Header{
+					"X-Team":    []string{"platform"},
+					"X-Api-Key": []string{"s3cr3t"},
+				},
+			},
+		},
+		"MissingHeaderSecret": {
+			spec: &v1alpha1.HTTPConfig{
+				Headers: []v1alpha1.HTTPHeader{
+					{Name: "X-Api-Key", ValueSecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Name: "missing", Namespace: "default"},
+						Key:             "apiKey",
+					}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := buildHTTPConfig(context.Background(), kube, &fake.Managed{}, tc.spec)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("buildHTTPConfig() error = %v, wantErr %v", err, tc.wantErr)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("buildHTTPConfig() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewHTTPClientHeaders(t *testing.T) {
+	t.Parallel()
+
+	headers := make(chan http.Header, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Clone()
+
+		_, _ = w.Write([]byte("2025.1.0"))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(Config{
+		AuthType: PersonalAccessToken,
+		Token:    "token",
+		BaseURL:  server.URL + "/api/",
+		HTTP: &HTTPConfig{
+			Headers: http.Header{"X-Api-Key": []string{"s3cr3t"}},
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error = %v", err)
+	}
+
+	_, resp, err := client.Server.Version()
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+
+	if err != nil {
+		t.Fatalf("Server.Version() unexpected error = %v", err)
+	}
+
+	received := <-headers
+	if got := received.Get("X-Api-Key"); got != "s3cr3t" {
+		t.Errorf("X-Api-Key header = %q, want s3cr3t", got)
+	}
+
+	if received.Get("Authorization") == "" {
+		t.Error("Authorization header was not sent along the extra headers")
+	}
+}
+
+func TestNewHTTPClientTimeout(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	httpClient := newHTTPClient(Config{HTTP: &HTTPConfig{Timeout: 50 * time.Millisecond}})
+
+	start := time.Now()
+
+	resp, err := httpClient.Get(server.URL) //nolint:noctx // The client timeout is under test
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+
+	if err == nil {
+		t.Fatal("Get() expected a timeout error")
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Get() returned after %v, the timeout was not applied", elapsed)
+	}
+}
+
+func TestNewHTTPClientRateLimitWaitNotTimedOut(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("2025.1.0"))
+	}))
+	t.Cleanup(server.Close)
+
+	// Each request after the first one waits for the rate limiter about twice as long as the timeout.
+	httpClient := newHTTPClient(Config{
+		HTTP:      &HTTPConfig{Timeout: 50 * time.Millisecond},
+		RateLimit: &RateLimitConfig{Key: "ProviderConfig/default/rate-limit-timeout-test", QPS: 10, Burst: 1},
+	})
+
+	start := time.Now()
+
+	for i := range 3 {
+		resp, err := httpClient.Get(server.URL) //nolint:noctx // The client timeout is under test
+		if err != nil {
+			t.Fatalf("Get() #%d unexpected error = %v", i, err)
+		}
+
+		_ = resp.Body.Close()
+	}
+
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Errorf("Get() sent 3 requests in %v, the rate limit was not applied", elapsed)
+	}
+}
+
+func TestNewHTTPClientDefaults(t *testing.T) {
+	t.Parallel()
+
+	httpClient := newHTTPClient(Config{})
+
+	if httpClient.Timeout != 0 {
+		t.Errorf("Timeout = %v, want the timeout to be applied to each attempt instead", httpClient.Timeout)
+	}
+
+	timeout, ok := httpClient.Transport.(*timeoutRoundTripper)
+	if !ok {
+		t.Fatalf("Transport = %T, want *timeoutRoundTripper", httpClient.Transport)
+	}
+
+	if timeout.timeout != DefaultHTTPTimeout {
+		t.Errorf("timeout = %v, want %v", timeout.timeout, DefaultHTTPTimeout)
+	}
+
+	timeout, ok = newHTTPClient(Config{HTTP: &HTTPConfig{IdleConnTimeout: time.Minute}}).Transport.(*timeoutRoundTripper)
+	if !ok {
+		t.Fatal("Transport is not a *timeoutRoundTripper")
+	}
+
+	transport, ok := timeout.next.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *http.Transport", timeout.next)
+	}
+
+	if transport.IdleConnTimeout != time.Minute {
+		t.Errorf("IdleConnTimeout = %v, want %v", transport.IdleConnTimeout, time.Minute)
+	}
+}
+
+func TestNewHTTPClientProxy(t *testing.T) {
+	t.Parallel()
+
+	requests := make(chan string, 1)
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- r.URL.String()
+
+		_, _ = w.Write([]byte("2025.1.0"))
+	}))
+	t.Cleanup(proxy.Close)
+
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	client, err := NewClient(Config{
+		AuthType: PersonalAccessToken,
+		Token:    "token",
+		BaseURL:  "http://sonarqube.example.com/api/",
+		HTTP: &HTTPConfig{
+			ProxyURL: proxyURL,
+			NoProxy:  "sonarqube.internal",
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error = %v", err)
+	}
+
+	_, resp, err := client.Server.Version()
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+
+	if err != nil {
+		t.Fatalf("Server.Version() unexpected error = %v", err)
+	}
+
+	if proxied := <-requests; proxied != "http://sonarqube.example.com/api/server/version" {
+		t.Errorf("proxy received %q, want the SonarQube request", proxied)
+	}
+
+	timeout, ok := newHTTPClient(Config{HTTP: &HTTPConfig{ProxyURL: proxyURL, NoProxy: "sonarqube.internal"}}).Transport.(*timeoutRoundTripper)
+	if !ok {
+		t.Fatal("Transport is not a *timeoutRoundTripper")
+	}
+
+	transport, ok := timeout.next.(*http.Transport)
+	if !ok {
+		t.Fatal("Transport is not a *http.Transport")
+	}
+
+	direct, err := transport.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "sonarqube.internal"}})
+	if err != nil || direct != nil {
+		t.Errorf("Proxy() = %v, %v, want no proxy for hosts in noProxy", direct, err)
+	}
+}
@@ -18,6 +18,7 @@ package common
 
 import (
 	"context"
+	"fmt"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/errors"
@@ -34,6 +35,10 @@ const (
 	ErrSecretKeyNotFound = "Cannot find key in referenced secret"
 	// ErrSecretSelectorNil is the error string used when a secret selector is nil.
 	ErrSecretSelectorNil = "Secret selector is nil"
+	// ErrConfigMapNotFound is the error string used when a configmap cannot be found.
+	ErrConfigMapNotFound = "Cannot find referenced configmap"
+	// ErrConfigMapKeyNotFound is the error string used when a key in a configmap cannot be found.
+	ErrConfigMapKeyNotFound = "Cannot find key in referenced configmap"
 )
 
 // GetTokenValueFromSecret retrieves the token value from the referenced secret.
@@ -73,3 +78,43 @@ func GetTokenValueFromLocalSecret(ctx context.Context, client client.Client, man
 		},
 	})
 }
+
+// GetValueFromLocalConfigMap retrieves the value of a key of a ConfigMap in the same namespace as the managed resource.
+func GetValueFromLocalConfigMap(ctx context.Context, client client.Client, managedResource resource.Managed, name, key string) (*string, error) {
+	configMap := &corev1.ConfigMap{}
+
+	err := client.Get(ctx, types.NamespacedName{Name: name, Namespace: managedResource.GetNamespace()}, configMap)
+	if err != nil {
+		return nil, errors.Wrap(err, ErrConfigMapNotFound)
+	}
+
+	if value, exists := configMap.Data[key]; exists {
+		return &value, nil
+	}
+
+	if value, exists := configMap.BinaryData[key]; exists {
+		data := string(value)
+
+		return &data, nil
+	}
+
+	return nil, errors.Errorf(ErrConfigMapKeyNotFound)
+}
+
+// GetLocalSecretKeyVersion returns an identifier of the version of a key of a Secret in the same namespace as the managed resource,
+// made of the UID and the resource version of the Secret. It changes whenever the value may have changed, without revealing anything
+// about the value, unlike a digest of the value that could be brute-forced offline.
+func GetLocalSecretKeyVersion(ctx context.Context, client client.Client, managedResource resource.Managed, localSelector *xpv1.LocalSecretKeySelector) (string, error) {
+	if localSelector == nil {
+		return "", errors.Errorf(ErrSecretSelectorNil)
+	}
+
+	secret := &corev1.Secret{}
+
+	err := client.Get(ctx, types.NamespacedName{Name: localSelector.Name, Namespace: managedResource.GetNamespace()}, secret)
+	if err != nil {
+		return "", errors.Wrap(err, ErrSecretNotFound)
+	}
+
+	return fmt.Sprintf("%s/%s/%s", secret.UID, secret.ResourceVersion, localSelector.Key), nil
+}
@@ -294,6 +294,121 @@ func TestGetTokenValueFromLocalSecret(t *testing.T) {
 	}
 }
 
+func TestGetValueFromLocalConfigMap(t *testing.T) {
+	t.Parallel()
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "settings",
+			Namespace: "test-ns",
+		},
+		Data: map[string]string{
+			"url": "https://sonarqube.example.com",
+		},
+		BinaryData: map[string][]byte{
+			"binary": []byte("binary-value"),
+		},
+	}
+
+	tests := map[string]struct {
+		name        string
+		key         string
+		want        *string
+		wantErr     bool
+		errContains string
+	}{
+		"SuccessfulDataRetrieval": {
+			name: "settings",
+			key:  "url",
+			want: strPtr("https://sonarqube.example.com"),
+		},
+		"SuccessfulBinaryDataRetrieval": {
+			name: "settings",
+			key:  "binary",
+			want: strPtr("binary-value"),
+		},
+		"ConfigMapNotFoundReturnsError": {
+			name:        "nonexistent",
+			key:         "url",
+			wantErr:     true,
+			errContains: ErrConfigMapNotFound,
+		},
+		"KeyNotFoundReturnsError": {
+			name:        "settings",
+			key:         "missing",
+			wantErr:     true,
+			errContains: ErrConfigMapKeyNotFound,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			managedResource := &fake.Managed{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns"}}
+
+			got, err := GetValueFromLocalConfigMap(context.Background(), newFakeClient(configMap), managedResource, tc.name, tc.key)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("GetValueFromLocalConfigMap() error = %v, wantErr %v", err, tc.wantErr)
+
+				return
+			}
+
+			if tc.wantErr {
+				if !containsString(err.Error(), tc.errContains) {
+					t.Errorf("GetValueFromLocalConfigMap() error = %v, should contain %v", err, tc.errContains)
+				}
+
+				return
+			}
+
+			if got == nil || *got != *tc.want {
+				t.Errorf("GetValueFromLocalConfigMap() = %v, want %v", got, *tc.want)
+			}
+		})
+	}
+}
+
+func TestGetLocalSecretKeyVersion(t *testing.T) {
+	t.Parallel()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "smtp", Namespace: "test-ns", UID: "1234"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	kube := newFakeClient(secret)
+	managedResource := &fake.Managed{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns"}}
+	selector := &xpv1.LocalSecretKeySelector{LocalSecretReference: xpv1.LocalSecretReference{Name: "smtp"}, Key: "password"}
+
+	got, err := GetLocalSecretKeyVersion(context.Background(), kube, managedResource, selector)
+	if err != nil {
+		t.Fatalf("GetLocalSecretKeyVersion() returned unexpected error: %v", err)
+	}
+
+	if containsString(got, "secret") {
+		t.Errorf("GetLocalSecretKeyVersion() = %q reveals the value of the Secret", got)
+	}
+
+	secret.Data["password"] = []byte("rotated")
+	if err := kube.Update(context.Background(), secret); err != nil {
+		t.Fatalf("Update() returned unexpected error: %v", err)
+	}
+
+	rotated, err := GetLocalSecretKeyVersion(context.Background(), kube, managedResource, selector)
+	if err != nil {
+		t.Fatalf("GetLocalSecretKeyVersion() returned unexpected error: %v", err)
+	}
+
+	if rotated == got {
+		t.Errorf("GetLocalSecretKeyVersion() = %q did not change with the Secret", rotated)
+	}
+
+	_, err = GetLocalSecretKeyVersion(context.Background(), kube, managedResource, nil)
+	if err == nil || !containsString(err.Error(), ErrSecretSelectorNil) {
+		t.Errorf("GetLocalSecretKeyVersion() error = %v, want %v", err, ErrSecretSelectorNil)
+	}
+}
+
 // strPtr returns a pointer to the given string.
 func strPtr(s string) *string {
 	return &s
@@ -0,0 +1,44 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricsSubsystem = "sonarqube_client"
+
+var (
+	// retries counts the requests to SonarQube that have been retried.
+	retries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "request_retries_total",
+		Help:      "Number of retries of requests to SonarQube, by HTTP method and status code of the failed attempt.",
+	}, []string{"method", "code"})
+
+	// retriesExhausted counts the requests to SonarQube that still failed after all their retries.
+	retriesExhausted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Subsystem: metricsSubsystem,
+		Name:      "request_retries_exhausted_total",
+		Help:      "Number of requests to SonarQube that still failed after all their retries, by HTTP method and status code.",
+	}, []string{"method", "code"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(retries, retriesExhausted)
+}
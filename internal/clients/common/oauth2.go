@@ -0,0 +1,82 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+)
+
+// OAuth2Config contains the OAuth2 client credentials used to get the bearer token sent to the SonarQube instance.
+type OAuth2Config struct {
+	// TokenURL is the token endpoint of the OAuth2 authorization server
+	TokenURL string
+	// ClientID is the client ID used to request the token
+	ClientID string
+	// ClientSecret is the client secret used to request the token
+	ClientSecret string
+	// Scopes are the scopes requested for the token
+	Scopes []string
+}
+
+// buildOAuth2Config builds the OAuth2 configuration from the ProviderConfig, reading the client ID and secret from their source.
+func buildOAuth2Config(ctx context.Context, kubeClient client.Client, managedResource resource.Managed, spec *v1alpha1.OAuth2ClientCredentials) (*OAuth2Config, error) {
+	clientID, err := ExtractCredentials(ctx, kubeClient, managedResource, &spec.ClientID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get clientID from %s", spec.ClientID.Source)
+	}
+
+	clientSecret, err := ExtractCredentials(ctx, kubeClient, managedResource, &spec.ClientSecret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get clientSecret from %s", spec.ClientSecret.Source)
+	}
+
+	return &OAuth2Config{
+		TokenURL:     spec.TokenURL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       spec.Scopes,
+	}, nil
+}
+
+// newOAuth2RoundTripper adds the bearer token of the OAuth2 client credentials flow to every request.
+// The token is requested with the tokenClient, cached until it expires and then requested again.
+func newOAuth2RoundTripper(config *OAuth2Config, tokenClient *http.Client, next http.RoundTripper) http.RoundTripper {
+	if config == nil {
+		return next
+	}
+
+	credentials := &clientcredentials.Config{
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		TokenURL:     config.TokenURL,
+		Scopes:       config.Scopes,
+	}
+
+	// The context is kept by the token source to refresh the token, so it must outlive the request that first needs a token.
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, tokenClient)
+
+	return &oauth2.Transport{Source: credentials.TokenSource(ctx), Base: next}
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+)
+
+// newFakeTokenEndpoint starts an OAuth2 token endpoint issuing tokens valid for expiresIn seconds.
+// It counts the issued tokens, which are named token-1, token-2, ...
+func newFakeTokenEndpoint(t *testing.T, expiresIn int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	issued := &atomic.Int32{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok {
+			clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+		}
+
+		if r.PostForm.Get("grant_type") != "client_credentials" || clientID != "provider-sonarqube" || clientSecret != "s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		if r.PostForm.Get("scope") != "sonarqube openid" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "token-" + strconv.Itoa(int(issued.Add(1))),
+			"token_type":   "Bearer",
+			"expires_in":   expiresIn,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, issued
+}
+
+func TestOAuth2ClientCredentials(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		// expiresIn is the lifetime of the tokens, tokens expiring within 10 seconds are considered expired
+		expiresIn  int
+		wantTokens []string
+		wantIssued int32
+	}{
+		"TokenIsCachedUntilExpiry": {
+			expiresIn:  3600,
+			wantTokens: []string{"Bearer token-1", "Bearer token-1", "Bearer token-1"},
+			wantIssued: 1,
+		},
+		"ExpiredTokenIsRefreshed": {
+			expiresIn:  1,
+			wantTokens: []string{"Bearer token-1", "Bearer token-2", "Bearer token-3"},
+			wantIssued: 3,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tokenEndpoint, issued := newFakeTokenEndpoint(t, tc.expiresIn)
+
+			var tokens []string
+
+			sonarqube := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				tokens = append(tokens, r.Header.Get("Authorization"))
+
+				_, _ = w.Write([]byte("2025.1.0"))
+			}))
+			t.Cleanup(sonarqube.Close)
+
+			client, err := NewClient(Config{
+				AuthType: OAuth2ClientCredentials,
+				BaseURL:  sonarqube.URL + "/api/",
+				OAuth2: &OAuth2Config{
+					TokenURL:     tokenEndpoint.URL,
+					ClientID:     "provider-sonarqube",
+					ClientSecret: "s3cr3t",
+					Scopes:       []string{"sonarqube", "openid"},
+				},
+			})
+			if err != nil {
+				t.Fatalf("NewClient() unexpected error = %v", err)
+			}
+
+			for range tc.wantTokens {
+				_, resp, err := client.Server.Version()
+				if resp != nil && resp.Body != nil {
+					_ = resp.Body.Close()
+				}
+
+				if err != nil {
+					t.Fatalf("Server.Version() unexpected error = %v", err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.wantTokens, tokens); diff != "" {
+				t.Errorf("Authorization headers mismatch (-want +got):\n%s", diff)
+			}
+
+			if got := issued.Load(); got != tc.wantIssued {
+				t.Errorf("issued tokens = %d, want %d", got, tc.wantIssued)
+			}
+		})
+	}
+}
+
+func TestOAuth2ClientCredentialsRejected(t *testing.T) {
+	t.Parallel()
+
+	tokenEndpoint, _ := newFakeTokenEndpoint(t, 3600)
+
+	sonarqube := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the request was sent to SonarQube without a token")
+	}))
+	t.Cleanup(sonarqube.Close)
+
+	client, err := NewClient(Config{
+		AuthType: OAuth2ClientCredentials,
+		BaseURL:  sonarqube.URL + "/api/",
+		OAuth2: &OAuth2Config{
+			TokenURL:     tokenEndpoint.URL,
+			ClientID:     "provider-sonarqube",
+			ClientSecret: "wrong",
+			Scopes:       []string{"sonarqube", "openid"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error = %v", err)
+	}
+
+	_, resp, err := client.Server.Version()
+	if resp != nil && resp.Body != nil {
+		_ = resp.Body.Close()
+	}
+
+	if err == nil {
+		t.Error("Server.Version() expected an error when the token endpoint rejects the client credentials")
+	}
+}
+
+func TestBuildOAuth2Config(t *testing.T) {
+	t.Parallel()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "oauth2", Namespace: "default"},
+		Data: map[string][]byte{
+			"client-id":     []byte("provider-sonarqube"),
+			"client-secret": []byte("s3cr3t"),
+		},
+	}
+
+	secretCredentials := func(key string) v1alpha1.ProviderCredentials {
+		return v1alpha1.ProviderCredentials{
+			Source: xpv1.CredentialsSourceSecret,
+			CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+				SecretRef: &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "oauth2", Namespace: "default"}, Key: key},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		spec    *v1alpha1.OAuth2ClientCredentials
+		want    *OAuth2Config
+		wantErr bool
+	}{
+		"FromSecret": {
+			spec: &v1alpha1.OAuth2ClientCredentials{
+				TokenURL:     "https://idp.example.com/oauth2/token",
+				ClientID:     secretCredentials("client-id"),
+				ClientSecret: secretCredentials("client-secret"),
+				Scopes:       []string{"sonarqube"},
+			},
+			want: &OAuth2Config{
+				TokenURL:     "https://idp.example.com/oauth2/token",
+				ClientID:     "provider-sonarqube",
+				ClientSecret: "s3cr3t",
+				Scopes:       []string{"sonarqube"},
+			},
+		},
+		"MissingSecretKey": {
+			spec: &v1alpha1.OAuth2ClientCredentials{
+				TokenURL:     "https://idp.example.com/oauth2/token",
+				ClientID:     secretCredentials("client-id"),
+				ClientSecret: secretCredentials("missing"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := buildOAuth2Config(context.Background(), newFakeClient(secret), &fake.Managed{}, tc.spec)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("buildOAuth2Config() error = %v, wantErr %v", err, tc.wantErr)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("buildOAuth2Config() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+)
+
+// RateLimitConfig is the rate limit of the requests to the SonarQube instance.
+type RateLimitConfig struct {
+	// Key identifies the ProviderConfig, all the clients with the same key share the same rate limit
+	Key string
+	// QPS is the maximum sustained number of requests per second
+	QPS float64
+	// Burst is the maximum number of requests sent at once
+	Burst int
+}
+
+// rateLimiters are the rate limiters shared by all the clients created for the same ProviderConfig.
+var rateLimiters = &rateLimiterRegistry{limiters: make(map[string]*rate.Limiter)}
+
+// rateLimiterRegistry holds one rate limiter per ProviderConfig.
+type rateLimiterRegistry struct {
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// get returns the rate limiter of the given configuration, creating it or updating its limits if needed.
+func (r *rateLimiterRegistry) get(config RateLimitConfig) *rate.Limiter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	limit := rate.Limit(config.QPS)
+
+	limiter, ok := r.limiters[config.Key]
+	if !ok {
+		limiter = rate.NewLimiter(limit, config.Burst)
+		r.limiters[config.Key] = limiter
+
+		return limiter
+	}
+
+	if limiter.Limit() != limit {
+		limiter.SetLimit(limit)
+	}
+
+	if limiter.Burst() != config.Burst {
+		limiter.SetBurst(config.Burst)
+	}
+
+	return limiter
+}
+
+// remove removes the rate limiter of the given key.
+func (r *rateLimiterRegistry) remove(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.limiters, key)
+}
+
+// InvalidateRateLimiter removes the rate limiter shared by the clients of a ProviderConfig, it is called when the ProviderConfig is deleted.
+// The key is the one returned by ProviderConfigKey.
+func InvalidateRateLimiter(providerConfigKey string) {
+	rateLimiters.remove(providerConfigKey)
+}
+
+// buildRateLimitConfig builds the rate limit configuration from the rate limit configuration of a ProviderConfig.
+// It returns nil if no rate limit is configured.
+func buildRateLimitConfig(key string, spec *v1alpha1.RateLimitConfig) *RateLimitConfig {
+	if spec == nil {
+		return nil
+	}
+
+	return &RateLimitConfig{
+		Key:   key,
+		QPS:   float64(spec.QPS),
+		Burst: int(ptr.Deref(spec.Burst, spec.QPS)),
+	}
+}
+
+// rateLimitRoundTripper waits for the rate limiter before sending each request.
+type rateLimitRoundTripper struct {
+	limiter *rate.Limiter
+	next    http.RoundTripper
+}
+
+// newRateLimitRoundTripper wraps the given RoundTripper with the shared rate limiter of the configuration,
+// or returns it unchanged if no rate limit is configured.
+func newRateLimitRoundTripper(config *RateLimitConfig, next http.RoundTripper) http.RoundTripper {
+	if config == nil || config.QPS <= 0 {
+		return next
+	}
+
+	return &rateLimitRoundTripper{limiter: rateLimiters.get(*config), next: next}
+}
+
+// RoundTrip waits until the rate limit allows the request, or the request is cancelled, before sending it.
+func (r *rateLimitRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	err := r.limiter.Wait(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	return r.next.RoundTrip(req)
+}
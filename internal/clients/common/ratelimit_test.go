@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/time/rate"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+)
+
+func TestBuildRateLimitConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		spec *v1alpha1.RateLimitConfig
+		want *RateLimitConfig
+	}{
+		"Nil": {
+			spec: nil,
+			want: nil,
+		},
+		"BurstDefaultsToQPS": {
+			spec: &v1alpha1.RateLimitConfig{QPS: 5},
+			want: &RateLimitConfig{Key: "ProviderConfig/default/example", QPS: 5, Burst: 5},
+		},
+		"Burst": {
+			spec: &v1alpha1.RateLimitConfig{QPS: 5, Burst: ptr.To[int32](20)},
+			want: &RateLimitConfig{Key: "ProviderConfig/default/example", QPS: 5, Burst: 20},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tc.want, buildRateLimitConfig("ProviderConfig/default/example", tc.spec)); diff != "" {
+				t.Errorf("buildRateLimitConfig() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRateLimiterRegistry(t *testing.T) {
+	t.Parallel()
+
+	registry := &rateLimiterRegistry{limiters: make(map[string]*rate.Limiter)}
+
+	first := registry.get(RateLimitConfig{Key: "ProviderConfig/team-a/sonarqube", QPS: 5, Burst: 10})
+	second := registry.get(RateLimitConfig{Key: "ProviderConfig/team-a/sonarqube", QPS: 2, Burst: 4})
+	other := registry.get(RateLimitConfig{Key: "ProviderConfig/team-b/sonarqube", QPS: 5, Burst: 10})
+
+	if first != second {
+		t.Error("get() returned different limiters for the same ProviderConfig")
+	}
+
+	if first == other {
+		t.Error("get() returned the same limiter for different ProviderConfigs")
+	}
+
+	if first.Limit() != 2 || first.Burst() != 4 {
+		t.Errorf("get() limit = %v, burst = %d, want the updated limit 2 and burst 4", first.Limit(), first.Burst())
+	}
+}
+
+func TestRateLimiterRegistryRemove(t *testing.T) {
+	t.Parallel()
+
+	registry := &rateLimiterRegistry{limiters: make(map[string]*rate.Limiter)}
+
+	first := registry.get(RateLimitConfig{Key: "ProviderConfig/team-a/sonarqube", QPS: 5, Burst: 10})
+	registry.get(RateLimitConfig{Key: "ProviderConfig/team-b/sonarqube", QPS: 5, Burst: 10})
+
+	registry.remove("ProviderConfig/team-a/sonarqube")
+
+	if _, ok := registry.limiters["ProviderConfig/team-a/sonarqube"]; ok {
+		t.Error("remove() kept the limiter of the removed ProviderConfig")
+	}
+
+	if _, ok := registry.limiters["ProviderConfig/team-b/sonarqube"]; !ok {
+		t.Error("remove() removed the limiter of another ProviderConfig")
+	}
+
+	if registry.get(RateLimitConfig{Key: "ProviderConfig/team-a/sonarqube", QPS: 5, Burst: 10}) == first {
+		t.Error("get() returned the removed limiter")
+	}
+}
+
+func TestRateLimitRoundTripper(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+
+	next := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+
+		return newTestResponse(http.StatusOK, nil), nil
+	})
+
+	if _, ok := newRateLimitRoundTripper(nil, next).(roundTripperFunc); !ok {
+		t.Error("newRateLimitRoundTripper() wrapped the transport without rate limit")
+	}
+
+	roundTripper := newRateLimitRoundTripper(&RateLimitConfig{Key: "ProviderConfig/default/rate-limit-test", QPS: 1, Burst: 1}, next)
+
+	send := func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://sonarqube.example.com/api/server/version", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := roundTripper.RoundTrip(req)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+
+		return err
+	}
+
+	if err := send(context.Background()); err != nil {
+		t.Fatalf("RoundTrip() unexpected error = %v", err)
+	}
+
+	// The burst is spent, the next request would have to wait about a second, longer than its deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := send(ctx); err == nil {
+		t.Error("RoundTrip() expected an error when the rate limit does not allow the request before its deadline")
+	}
+
+	if calls != 1 {
+		t.Errorf("RoundTrip() sent %d requests, want 1", calls)
+	}
+}
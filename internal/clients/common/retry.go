@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+)
+
+// Default retry configuration, used when a ProviderConfig does not configure retries.
+const (
+	DefaultMaxRetries     = 3
+	DefaultInitialBackoff = 500 * time.Millisecond
+	DefaultMaxBackoff     = 10 * time.Second
+)
+
+// retryableStatusCodes are the HTTP status codes returned by SonarQube, or a proxy in front of it, while it is temporarily unavailable.
+var retryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// idempotentPOSTEndpoints are the SonarQube API endpoints that use POST but can safely be sent more than once:
+// sending one again after a first attempt succeeded behind a failing proxy leaves the same state and does not fail.
+// Endpoints addressing their target by a value they change, e.g. qualitygates/rename by the current name, are not safe.
+var idempotentPOSTEndpoints = []string{
+	// Set or reset the value of settings to the given value
+	"settings/set",
+	"settings/reset",
+	// Activate rules with the given parameters, activating an active rule again updates it with the same ones
+	"qualityprofiles/activate_rule",
+	"qualityprofiles/activate_rules",
+	// Deactivate rules, deactivating an inactive rule does nothing
+	"qualityprofiles/deactivate_rule",
+	"qualityprofiles/deactivate_rules",
+	// Set the parent or the default Quality Profile to the given one
+	"qualityprofiles/change_parent",
+	"qualityprofiles/set_default",
+	// Associate or dissociate a project, which does nothing when it already is
+	"qualityprofiles/add_project",
+	"qualityprofiles/remove_project",
+	// Set the Quality Gate of a project, or the default one, to the given one
+	"qualitygates/select",
+	"qualitygates/deselect",
+	"qualitygates/set_as_default",
+	// Set the condition with the given ID to the given metric, operator and threshold
+	"qualitygates/update_condition",
+}
+
+// RetryConfig is the retry configuration of the requests to the SonarQube instance.
+type RetryConfig struct {
+	// MaxRetries is the maximum number of retries of a request, 0 disables retries
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry, doubled on each retry
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between two retries
+	MaxBackoff time.Duration
+}
+
+// buildRetryConfig builds the retry configuration from the retry configuration of a ProviderConfig, applying the defaults.
+func buildRetryConfig(spec *v1alpha1.RetryConfig) RetryConfig {
+	retryConfig := RetryConfig{
+		MaxRetries:     DefaultMaxRetries,
+		InitialBackoff: DefaultInitialBackoff,
+		MaxBackoff:     DefaultMaxBackoff,
+	}
+
+	if spec == nil {
+		return retryConfig
+	}
+
+	retryConfig.MaxRetries = int(ptr.Deref(spec.MaxRetries, DefaultMaxRetries))
+
+	if spec.InitialBackoff != nil {
+		retryConfig.InitialBackoff = spec.InitialBackoff.Duration
+	}
+
+	if spec.MaxBackoff != nil {
+		retryConfig.MaxBackoff = spec.MaxBackoff.Duration
+	}
+
+	return retryConfig
+}
+
+// retryRoundTripper retries idempotent requests when SonarQube is temporarily unavailable.
+type retryRoundTripper struct {
+	config RetryConfig
+	next   http.RoundTripper
+	// sleep waits for the given duration or until the request is cancelled, it returns false if the request was cancelled
+	sleep func(req *http.Request, delay time.Duration) bool
+}
+
+// newRetryRoundTripper wraps the given RoundTripper with retries, or returns it unchanged if retries are disabled.
+func newRetryRoundTripper(config RetryConfig, next http.RoundTripper) http.RoundTripper {
+	if config.MaxRetries <= 0 {
+		return next
+	}
+
+	return &retryRoundTripper{config: config, next: next, sleep: sleepContext}
+}
+
+// RoundTrip sends the request, retrying it with exponential backoff while SonarQube answers with a retryable status code.
+// The HTTP timeout bounds each attempt, so the retries of a request can last up to MaxRetries times the timeout and the maximum backoff:
+// the request is only bounded as a whole by the deadline of its context.
+func (r *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isRetryableRequest(req) {
+		return r.next.RoundTrip(req)
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+
+		// A request without body, e.g. with http.NoBody and no GetBody, is sent again as is
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		resp, err := r.next.RoundTrip(attemptReq)
+		if err != nil || !slices.Contains(retryableStatusCodes, resp.StatusCode) {
+			return resp, err
+		}
+
+		code := strconv.Itoa(resp.StatusCode)
+
+		if attempt >= r.config.MaxRetries {
+			retriesExhausted.WithLabelValues(req.Method, code).Inc()
+
+			return resp, nil
+		}
+
+		delay := r.backoff(attempt, resp)
+
+		// Drain the body so that the connection can be reused by the next attempt.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		retries.WithLabelValues(req.Method, code).Inc()
+
+		if !r.sleep(req, delay) {
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// backoff returns the delay before the next retry: the Retry-After delay if the response has one, the exponential backoff otherwise.
+// The delay never exceeds the configured maximum backoff.
+func (r *retryRoundTripper) backoff(attempt int, resp *http.Response) time.Duration {
+	delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	if !ok {
+		delay = r.config.InitialBackoff << attempt
+	}
+
+	if delay > r.config.MaxBackoff || delay < 0 {
+		delay = r.config.MaxBackoff
+	}
+
+	return delay
+}
+
+// isRetryableRequest checks whether the request can safely be sent more than once.
+func isRetryableRequest(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		return true
+	case http.MethodPost:
+		return slices.ContainsFunc(idempotentPOSTEndpoints, func(endpoint string) bool {
+			return strings.HasSuffix(req.URL.Path, "/"+endpoint)
+		})
+	default:
+		return false
+	}
+}
+
+// parseRetryAfter parses the value of a Retry-After header, either a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err == nil {
+		return time.Duration(seconds) * time.Second, seconds >= 0
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(date.Sub(now), 0), true
+}
+
+// sleepContext waits for the given duration, or until the request is cancelled.
+func sleepContext(req *http.Request, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-req.Context().Done():
+		return false
+	}
+}
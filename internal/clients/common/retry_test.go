@@ -0,0 +1,354 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+)
+
+// roundTripperFunc is a http.RoundTripper implemented by a function.
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls the function.
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newTestResponse creates a response with the given status code and headers.
+func newTestResponse(statusCode int, headers map[string]string) *http.Response {
+	resp := &http.Response{
+		StatusCode: statusCode,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader("body")),
+	}
+
+	for name, value := range headers {
+		resp.Header.Set(name, value)
+	}
+
+	return resp
+}
+
+func TestRetryRoundTripper(t *testing.T) {
+	t.Parallel()
+
+	type response struct {
+		status  int
+		headers map[string]string
+	}
+
+	config := RetryConfig{
+		MaxRetries:     3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     300 * time.Millisecond,
+	}
+
+	tests := map[string]struct {
+		method     string
+		path       string
+		responses  []response
+		wantStatus int
+		wantDelays []time.Duration
+	}{
+		"GETSucceedsAfterRetry": {
+			method:     http.MethodGet,
+			path:       "/api/qualityprofiles/show",
+			responses:  []response{{status: http.StatusServiceUnavailable}, {status: http.StatusOK}},
+			wantStatus: http.StatusOK,
+			wantDelays: []time.Duration{100 * time.Millisecond},
+		},
+		"GETExhaustsRetriesWithExponentialBackoff": {
+			method: http.MethodGet,
+			path:   "/api/settings/values",
+			responses: []response{
+				{status: http.StatusBadGateway},
+				{status: http.StatusGatewayTimeout},
+				{status: http.StatusServiceUnavailable},
+				{status: http.StatusTooManyRequests},
+			},
+			wantStatus: http.StatusTooManyRequests,
+			wantDelays: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond},
+		},
+		"RetryAfterSeconds": {
+			method:     http.MethodGet,
+			path:       "/api/rules/search",
+			responses:  []response{{status: http.StatusTooManyRequests, headers: map[string]string{"Retry-After": "0"}}, {status: http.StatusOK}},
+			wantStatus: http.StatusOK,
+			wantDelays: []time.Duration{0},
+		},
+		"RetryAfterCappedByMaxBackoff": {
+			method:     http.MethodGet,
+			path:       "/api/rules/search",
+			responses:  []response{{status: http.StatusServiceUnavailable, headers: map[string]string{"Retry-After": "120"}}, {status: http.StatusOK}},
+			wantStatus: http.StatusOK,
+			wantDelays: []time.Duration{300 * time.Millisecond},
+		},
+		"IdempotentPOSTIsRetried": {
+			method:     http.MethodPost,
+			path:       "/api/qualityprofiles/activate_rule",
+			responses:  []response{{status: http.StatusServiceUnavailable}, {status: http.StatusNoContent}},
+			wantStatus: http.StatusNoContent,
+			wantDelays: []time.Duration{100 * time.Millisecond},
+		},
+		"NonIdempotentPOSTIsNotRetried": {
+			method:     http.MethodPost,
+			path:       "/api/qualityprofiles/create",
+			responses:  []response{{status: http.StatusServiceUnavailable}, {status: http.StatusOK}},
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		"RenameIsNotRetried": {
+			method:     http.MethodPost,
+			path:       "/api/qualitygates/rename",
+			responses:  []response{{status: http.StatusBadGateway}, {status: http.StatusNoContent}},
+			wantStatus: http.StatusBadGateway,
+		},
+		"ClientErrorIsNotRetried": {
+			method:     http.MethodGet,
+			path:       "/api/qualitygates/show",
+			responses:  []response{{status: http.StatusNotFound}, {status: http.StatusOK}},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+
+			var delays []time.Duration
+
+			roundTripper := &retryRoundTripper{
+				config: config,
+				next: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					resp := tc.responses[calls]
+					calls++
+
+					return newTestResponse(resp.status, resp.headers), nil
+				}),
+				sleep: func(req *http.Request, delay time.Duration) bool {
+					delays = append(delays, delay)
+
+					return true
+				},
+			}
+
+			req, err := http.NewRequestWithContext(context.Background(), tc.method, "https://sonarqube.example.com"+tc.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := roundTripper.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip() unexpected error = %v", err)
+			}
+
+			_ = resp.Body.Close()
+
+			if resp.StatusCode != tc.wantStatus {
+				t.Errorf("RoundTrip() status = %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+
+			if diff := cmp.Diff(tc.wantDelays, delays); diff != "" {
+				t.Errorf("RoundTrip() delays mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRetryRoundTripperCancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	roundTripper := newRetryRoundTripper(RetryConfig{MaxRetries: 3, InitialBackoff: time.Hour, MaxBackoff: time.Hour},
+		roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			cancel()
+
+			return newTestResponse(http.StatusServiceUnavailable, nil), nil
+		}))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://sonarqube.example.com/api/server/version", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := roundTripper.RoundTrip(req)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+
+	if err == nil {
+		t.Fatal("RoundTrip() expected an error when the request is cancelled while waiting")
+	}
+}
+
+func TestRetryRoundTripperNoBody(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+
+	roundTripper := &retryRoundTripper{
+		config: RetryConfig{MaxRetries: 1},
+		next: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+
+			if req.Body != http.NoBody {
+				t.Errorf("RoundTrip() attempt %d body = %v, want http.NoBody", calls, req.Body)
+			}
+
+			if calls == 1 {
+				return newTestResponse(http.StatusServiceUnavailable, nil), nil
+			}
+
+			return newTestResponse(http.StatusOK, nil), nil
+		}),
+		sleep: func(req *http.Request, delay time.Duration) bool { return true },
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://sonarqube.example.com/api/qualitygates/select", http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.GetBody = nil
+
+	resp, err := roundTripper.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() unexpected error = %v", err)
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || calls != 2 {
+		t.Errorf("RoundTrip() status = %d after %d attempts, want %d after 2 attempts", resp.StatusCode, calls, http.StatusOK)
+	}
+}
+
+func TestRetryMetrics(t *testing.T) {
+	t.Parallel()
+
+	// HEAD requests are never sent by the SonarQube client, so the metrics are not affected by the other tests.
+	before := testutil.ToFloat64(retries.WithLabelValues(http.MethodHead, "502"))
+	beforeExhausted := testutil.ToFloat64(retriesExhausted.WithLabelValues(http.MethodHead, "502"))
+
+	roundTripper := &retryRoundTripper{
+		config: RetryConfig{MaxRetries: 2},
+		next: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return newTestResponse(http.StatusBadGateway, nil), nil
+		}),
+		sleep: func(req *http.Request, delay time.Duration) bool { return true },
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodHead, "https://sonarqube.example.com/api/system/status", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := roundTripper.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() unexpected error = %v", err)
+	}
+
+	_ = resp.Body.Close()
+
+	if got := testutil.ToFloat64(retries.WithLabelValues(http.MethodHead, "502")) - before; got != 2 {
+		t.Errorf("retries = %v, want 2", got)
+	}
+
+	if got := testutil.ToFloat64(retriesExhausted.WithLabelValues(http.MethodHead, "502")) - beforeExhausted; got != 1 {
+		t.Errorf("retries exhausted = %v, want 1", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		"Empty":        {value: "", wantOK: false},
+		"Seconds":      {value: "5", want: 5 * time.Second, wantOK: true},
+		"Negative":     {value: "-1", want: -time.Second, wantOK: false},
+		"HTTPDate":     {value: now.Add(time.Minute).Format(http.TimeFormat), want: time.Minute, wantOK: true},
+		"PastHTTPDate": {value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0, wantOK: true},
+		"Invalid":      {value: "soon", wantOK: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := parseRetryAfter(tc.value, now)
+			if ok != tc.wantOK || (ok && got != tc.want) {
+				t.Errorf("parseRetryAfter(%q) = %v, %v, want %v, %v", tc.value, got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestBuildRetryConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		spec *v1alpha1.RetryConfig
+		want RetryConfig
+	}{
+		"Defaults": {
+			spec: nil,
+			want: RetryConfig{MaxRetries: DefaultMaxRetries, InitialBackoff: DefaultInitialBackoff, MaxBackoff: DefaultMaxBackoff},
+		},
+		"Disabled": {
+			spec: &v1alpha1.RetryConfig{MaxRetries: ptr.To[int32](0)},
+			want: RetryConfig{MaxRetries: 0, InitialBackoff: DefaultInitialBackoff, MaxBackoff: DefaultMaxBackoff},
+		},
+		"Custom": {
+			spec: &v1alpha1.RetryConfig{
+				MaxRetries:     ptr.To[int32](5),
+				InitialBackoff: &metav1.Duration{Duration: time.Second},
+				MaxBackoff:     &metav1.Duration{Duration: time.Minute},
+			},
+			want: RetryConfig{MaxRetries: 5, InitialBackoff: time.Second, MaxBackoff: time.Minute},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tc.want, buildRetryConfig(tc.spec)); diff != "" {
+				t.Errorf("buildRetryConfig() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,19 +18,26 @@ package common
 
 import (
 	"context"
-	"crypto/tls"
+	"net/http"
+	"net/url"
 
 	"github.com/boxboxjason/sonarqube-client-go/sonar"
-	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
-	"github.com/hashicorp/go-cleanhttp"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	errMissingBasicAuth    = "BasicAuth configuration is required for BasicAuth"
+	errMissingOAuth2       = "OAuth2 configuration is required for OAuth2ClientCredentials"
+	errUnsupportedAuthType = "unsupported authentication type %q"
+	errInvalidBaseURL      = "baseUrl %q must be an absolute http or https URL"
+	errNewSonarClient      = "cannot create SonarQube client"
+)
+
 // BasicAuthArgs is the expected struct that can be passed in the Config.Token field to add support for BasicAuth AuthMethod.
 type BasicAuthArgs struct {
 	Username string `json:"username"`
@@ -45,67 +52,86 @@ type Config struct {
 	BasicAuth *BasicAuthArgs
 	// Token is the Personal access token for the SonarQube instance
 	Token string
+	// OAuth2 contains the OAuth2 client credentials used to get the bearer token for OAuth2ClientCredentials
+	OAuth2 *OAuth2Config
 	// BaseURL is the URL of the SonarQube instance (trailing slash is optional)
 	BaseURL string
 	// InsecureSkipVerify indicates whether to skip TLS certificate verification (for self-signed certificates)
 	InsecureSkipVerify bool
+	// TLS contains the CA certificates, client certificates and server name used for the TLS connection
+	TLS *TLSConfig
+	// HTTP contains the proxy, timeouts and extra headers used for the HTTP requests
+	HTTP *HTTPConfig
+	// Retry configures the retries of the requests while SonarQube is temporarily unavailable
+	Retry RetryConfig
+	// RateLimit limits the rate of the requests, shared by all the clients of the same ProviderConfig, nil for no limit
+	RateLimit *RateLimitConfig
+	// CacheKey identifies the ProviderConfig and credentials version the configuration was built from, nil if it is not cacheable
+	CacheKey *ClientCacheKey
 }
 
 // NewClient creates new SonarQube Client with provided SonarQube Configurations/Credentials.
-func NewClient(clientConfig Config) *sonar.Client {
-	var client *sonar.Client
+// Controllers should use GetClient, which reuses the clients of unchanged ProviderConfigs.
+func NewClient(clientConfig Config) (*sonar.Client, error) {
+	return newClientWithHTTPClient(clientConfig, newHTTPClient(clientConfig))
+}
+
+// newClientWithHTTPClient creates new SonarQube Client with provided SonarQube Configurations/Credentials and HTTP client.
+func newClientWithHTTPClient(clientConfig Config, httpClient *http.Client) (*sonar.Client, error) {
+	err := validateBaseURL(clientConfig.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var createOption *sonar.ClientCreateOption
 
 	switch clientConfig.AuthType {
 	case BasicAuth:
 		if clientConfig.BasicAuth == nil {
-			panic(errors.New("BasicAuth configuration is required for BasicAuth"))
+			return nil, errors.New(errMissingBasicAuth)
 		}
 		// Create SonarQube client with Basic Auth
-		sonarClient, err := sonar.NewClient(
-			&sonar.ClientCreateOption{
-				URL:      &clientConfig.BaseURL,
-				Username: &clientConfig.BasicAuth.Username,
-				Password: &clientConfig.BasicAuth.Password,
-			})
-		if err != nil {
-			panic(err)
+		createOption = &sonar.ClientCreateOption{
+			URL:      &clientConfig.BaseURL,
+			Username: &clientConfig.BasicAuth.Username,
+			Password: &clientConfig.BasicAuth.Password,
 		}
-
-		client = sonarClient
 	case PersonalAccessToken:
 		// Create SonarQube client with Personal Access Token
-		sonarClient, err := sonar.NewClient(
-			&sonar.ClientCreateOption{
-				URL:   &clientConfig.BaseURL,
-				Token: &clientConfig.Token,
-			})
-		if err != nil {
-			panic(err)
+		createOption = &sonar.ClientCreateOption{
+			URL:   &clientConfig.BaseURL,
+			Token: &clientConfig.Token,
+		}
+	case OAuth2ClientCredentials:
+		if clientConfig.OAuth2 == nil {
+			return nil, errors.New(errMissingOAuth2)
+		}
+		// The bearer token is added to the requests by the HTTP client
+		createOption = &sonar.ClientCreateOption{
+			URL: &clientConfig.BaseURL,
 		}
-
-		client = sonarClient
 	default:
-		panic(errors.New("unsupported authentication type"))
+		return nil, errors.Errorf(errUnsupportedAuthType, clientConfig.AuthType)
 	}
 
-	httpClient := cleanhttp.DefaultClient()
-
-	// Configure TLS settings if InsecureSkipVerify is set to true
-	if clientConfig.InsecureSkipVerify {
-		transport := cleanhttp.DefaultPooledTransport()
-		if transport.TLSClientConfig == nil {
-			transport.TLSClientConfig = &tls.Config{
-				MinVersion: tls.VersionTLS12,
-			}
-		}
-
-		transport.TLSClientConfig.InsecureSkipVerify = true
-		httpClient.Transport = transport
+	client, err := sonar.NewClient(createOption)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewSonarClient)
 	}
 
 	client.SetHTTPClient(httpClient)
 
-	return client
+	return client, nil
+}
+
+// validateBaseURL checks that the base URL of the SonarQube instance is an absolute http or https URL.
+func validateBaseURL(baseURL string) error {
+	parsed, err := url.Parse(baseURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.Errorf(errInvalidBaseURL, baseURL)
+	}
+
+	return nil
 }
 
 // GetConfig constructs a Config that can be used to authenticate to SonarQube's
@@ -138,7 +164,12 @@ func UseProviderConfig(ctx context.Context, kubeClient client.Client, managedRes
 			return nil, errors.Wrap(err, "cannot get referenced ClusterProviderConfig")
 		}
 
-		return buildConfigFromSpec(ctx, kubeClient, managedResource, cpc.Spec)
+		err = trackProviderConfigUsage(ctx, kubeClient, managedResource)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildCacheableConfigFromSpec(ctx, kubeClient, managedResource, cpc, ProviderConfigKey(cpc), cpc.Spec)
 	default: // "ProviderConfig" or empty (default)
 		providerConfig := &v1alpha1.ProviderConfig{}
 
@@ -147,22 +178,86 @@ func UseProviderConfig(ctx context.Context, kubeClient client.Client, managedRes
 			return nil, errors.Wrap(err, "cannot get referenced ProviderConfig")
 		}
 
-		return buildConfigFromSpec(ctx, kubeClient, managedResource, providerConfig.Spec)
+		err = trackProviderConfigUsage(ctx, kubeClient, managedResource)
+		if err != nil {
+			return nil, err
+		}
+
+		return buildCacheableConfigFromSpec(ctx, kubeClient, managedResource, providerConfig, ProviderConfigKey(providerConfig), providerConfig.Spec)
 	}
 }
 
-// buildConfigFromSpec builds a Config from the given ProviderConfigSpec.
-func buildConfigFromSpec(ctx context.Context, kubeClient client.Client, managedResource resource.ModernManaged, spec v1alpha1.ProviderConfigSpec) (*Config, error) {
+// ConfigFromProviderConfig constructs a Config from the given ProviderConfig or ClusterProviderConfig, without tracking
+// its usage by a managed resource, e.g. to check that the SonarQube instance can be reached with it.
+func ConfigFromProviderConfig(ctx context.Context, kubeClient client.Client, providerConfig client.Object) (*Config, error) {
+	switch pc := providerConfig.(type) {
+	case *v1alpha1.ClusterProviderConfig:
+		return buildCacheableConfigFromSpec(ctx, kubeClient, nil, pc, ProviderConfigKey(pc), pc.Spec)
+	case *v1alpha1.ProviderConfig:
+		return buildCacheableConfigFromSpec(ctx, kubeClient, nil, pc, ProviderConfigKey(pc), pc.Spec)
+	default:
+		return nil, errors.New("unknown provider config type")
+	}
+}
+
+// ProviderConfigKey identifies a ProviderConfig or ClusterProviderConfig by its kind, namespace and name,
+// all the clients created for the same ProviderConfig share the rate limiter of this key.
+func ProviderConfigKey(providerConfig client.Object) string {
+	if _, ok := providerConfig.(*v1alpha1.ClusterProviderConfig); ok {
+		return "ClusterProviderConfig/" + providerConfig.GetName()
+	}
+
+	return "ProviderConfig/" + providerConfig.GetNamespace() + "/" + providerConfig.GetName()
+}
+
+// trackProviderConfigUsage records that the managed resource uses its ProviderConfig.
+func trackProviderConfigUsage(ctx context.Context, kubeClient client.Client, managedResource resource.ModernManaged) error {
 	t := resource.NewProviderConfigUsageTracker(kubeClient, &v1alpha1.ProviderConfigUsage{})
 
 	err := t.Track(ctx, managedResource)
 	if err != nil {
-		return nil, errors.Wrap(err, "cannot track ProviderConfig usage")
+		return errors.Wrap(err, "cannot track ProviderConfig usage")
 	}
 
+	return nil
+}
+
+// buildCacheableConfigFromSpec builds a Config from the given ProviderConfigSpec, with the cache key identifying the
+// version of the ProviderConfig and of the Secrets it references.
+func buildCacheableConfigFromSpec(ctx context.Context, kubeClient client.Client, managedResource resource.Managed, providerConfig client.Object, providerConfigKey string, spec v1alpha1.ProviderConfigSpec) (*Config, error) {
+	secrets := newSecretVersionRecorder(kubeClient)
+
+	config, err := buildConfigFromSpec(ctx, secrets, managedResource, providerConfigKey, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	config.CacheKey = newClientCacheKey(providerConfig.GetUID(), providerConfig.GetGeneration(), secrets, config)
+
+	return config, nil
+}
+
+// buildConfigFromSpec builds a Config from the given ProviderConfigSpec.
+// The managedResource is nil when the Config is not built for a managed resource.
+// The providerConfigKey identifies the ProviderConfig, so that all its clients share the same rate limit.
+func buildConfigFromSpec(ctx context.Context, kubeClient client.Client, managedResource resource.Managed, providerConfigKey string, spec v1alpha1.ProviderConfigSpec) (*Config, error) {
+	var err error
+
 	config := &Config{
 		BaseURL:            spec.BaseURL,
 		InsecureSkipVerify: ptr.Deref(spec.InsecureSkipVerify, false),
+		Retry:              buildRetryConfig(spec.Retry),
+		RateLimit:          buildRateLimitConfig(providerConfigKey, spec.RateLimit),
+	}
+
+	config.TLS, err = buildTLSConfig(ctx, kubeClient, managedResource, spec.TLS)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot build TLS configuration from ProviderConfigSpec")
+	}
+
+	config.HTTP, err = buildHTTPConfig(ctx, kubeClient, managedResource, spec.HTTP)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot build HTTP configuration from ProviderConfigSpec")
 	}
 
 	authType, err := determineAuthType(spec)
@@ -174,26 +269,31 @@ func buildConfigFromSpec(ctx context.Context, kubeClient client.Client, managedR
 
 	switch authType {
 	case PersonalAccessToken:
-		token, err := GetTokenValueFromSecret(ctx, kubeClient, managedResource, spec.Token.SecretRef)
+		token, err := ExtractCredentials(ctx, kubeClient, managedResource, spec.Token)
 		if err != nil {
-			return nil, errors.Wrap(err, "cannot get token from secret")
+			return nil, errors.Wrapf(err, "cannot get token from %s", spec.Token.Source)
 		}
 
-		config.Token = *token
+		config.Token = token
 	case BasicAuth:
-		username, err := GetTokenValueFromSecret(ctx, kubeClient, managedResource, spec.Username.SecretRef)
+		username, err := ExtractCredentials(ctx, kubeClient, managedResource, spec.Username)
 		if err != nil {
-			return nil, errors.Wrap(err, "cannot get username from secret")
+			return nil, errors.Wrapf(err, "cannot get username from %s", spec.Username.Source)
 		}
 
-		password, err := GetTokenValueFromSecret(ctx, kubeClient, managedResource, spec.Password.SecretRef)
+		password, err := ExtractCredentials(ctx, kubeClient, managedResource, spec.Password)
 		if err != nil {
-			return nil, errors.Wrap(err, "cannot get password from secret")
+			return nil, errors.Wrapf(err, "cannot get password from %s", spec.Password.Source)
 		}
 
 		config.BasicAuth = &BasicAuthArgs{
-			Username: *username,
-			Password: *password,
+			Username: username,
+			Password: password,
+		}
+	case OAuth2ClientCredentials:
+		config.OAuth2, err = buildOAuth2Config(ctx, kubeClient, managedResource, spec.OAuth2)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot build OAuth2 configuration from ProviderConfigSpec")
 		}
 	}
 
@@ -214,38 +314,49 @@ func determineAuthType(spec v1alpha1.ProviderConfigSpec) (AuthType, error) {
 		return validateBasicAuth(spec.Username, spec.Password)
 	}
 
+	// Check if OAuth2 client credentials are provided for bearer token authentication
+	if spec.OAuth2 != nil {
+		return validateOAuth2Auth(spec.OAuth2)
+	}
+
 	return "", errors.New("no valid authentication method found in ProviderConfigSpec")
 }
 
 // validateTokenAuth validates token-based authentication configuration.
 func validateTokenAuth(token *v1alpha1.ProviderCredentials) (AuthType, error) {
-	if token.Source != xpv1.CredentialsSourceSecret {
-		return "", errors.Errorf("credentials source %s for token is not currently supported", token.Source)
-	}
-
-	if token.SecretRef == nil {
-		return "", errors.New("secretRef must be provided for token")
+	err := validateCredentialsSource("token", token, true)
+	if err != nil {
+		return "", err
 	}
 
 	return PersonalAccessToken, nil
 }
 
-// validateBasicAuth validates basic authentication configuration.
-func validateBasicAuth(username, password *v1alpha1.ProviderCredentials) (AuthType, error) {
-	if username.Source != xpv1.CredentialsSourceSecret {
-		return "", errors.Errorf("credentials source %s for username is not currently supported", username.Source)
+// validateOAuth2Auth validates OAuth2 client credentials authentication configuration.
+func validateOAuth2Auth(oauth2 *v1alpha1.OAuth2ClientCredentials) (AuthType, error) {
+	err := validateCredentialsSource("clientID", &oauth2.ClientID, false)
+	if err != nil {
+		return "", err
 	}
 
-	if username.SecretRef == nil {
-		return "", errors.New("secretRef must be provided for username")
+	err = validateCredentialsSource("clientSecret", &oauth2.ClientSecret, false)
+	if err != nil {
+		return "", err
 	}
 
-	if password.Source != xpv1.CredentialsSourceSecret {
-		return "", errors.Errorf("credentials source %s for password is not currently supported", password.Source)
+	return OAuth2ClientCredentials, nil
+}
+
+// validateBasicAuth validates basic authentication configuration.
+func validateBasicAuth(username, password *v1alpha1.ProviderCredentials) (AuthType, error) {
+	err := validateCredentialsSource("username", username, false)
+	if err != nil {
+		return "", err
 	}
 
-	if password.SecretRef == nil {
-		return "", errors.New("secretRef must be provided for password")
+	err = validateCredentialsSource("password", password, false)
+	if err != nil {
+		return "", err
 	}
 
 	return BasicAuth, nil
@@ -0,0 +1,124 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+)
+
+func TestNewClient(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		config  Config
+		wantErr bool
+	}{
+		"PersonalAccessToken": {
+			config: Config{AuthType: PersonalAccessToken, Token: "token", BaseURL: "https://sonarqube.example.com/api/"},
+		},
+		"BasicAuth": {
+			config: Config{
+				AuthType:  BasicAuth,
+				BasicAuth: &BasicAuthArgs{Username: "admin", Password: "admin"},
+				BaseURL:   "http://sonarqube.example.com:9000/api",
+			},
+		},
+		"MissingBasicAuth": {
+			config:  Config{AuthType: BasicAuth, BaseURL: "https://sonarqube.example.com/api/"},
+			wantErr: true,
+		},
+		"OAuth2ClientCredentials": {
+			config: Config{
+				AuthType: OAuth2ClientCredentials,
+				OAuth2:   &OAuth2Config{TokenURL: "https://idp.example.com/oauth2/token", ClientID: "id", ClientSecret: "secret"},
+				BaseURL:  "https://sonarqube.example.com/api/",
+			},
+		},
+		"MissingOAuth2": {
+			config:  Config{AuthType: OAuth2ClientCredentials, BaseURL: "https://sonarqube.example.com/api/"},
+			wantErr: true,
+		},
+		"UnsupportedAuthType": {
+			config:  Config{AuthType: "Kerberos", BaseURL: "https://sonarqube.example.com/api/"},
+			wantErr: true,
+		},
+		"EmptyBaseURL": {
+			config:  Config{AuthType: PersonalAccessToken, Token: "token"},
+			wantErr: true,
+		},
+		"BaseURLWithoutScheme": {
+			config:  Config{AuthType: PersonalAccessToken, Token: "token", BaseURL: "sonarqube.example.com/api/"},
+			wantErr: true,
+		},
+		"BaseURLWithUnsupportedScheme": {
+			config:  Config{AuthType: PersonalAccessToken, Token: "token", BaseURL: "ftp://sonarqube.example.com/api/"},
+			wantErr: true,
+		},
+		"MalformedBaseURL": {
+			config:  Config{AuthType: PersonalAccessToken, Token: "token", BaseURL: "https://sonarqube.example.com:port/api/"},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := NewClient(tc.config)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewClient() error = %v, wantErr %v", err, tc.wantErr)
+			}
+
+			if !tc.wantErr && client == nil {
+				t.Error("NewClient() returned a nil client without error")
+			}
+		})
+	}
+}
+
+func TestProviderConfigKey(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		providerConfig client.Object
+		want           string
+	}{
+		"ProviderConfig": {
+			providerConfig: &v1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "sonarqube", Namespace: "team-a"}},
+			want:           "ProviderConfig/team-a/sonarqube",
+		},
+		"ClusterProviderConfig": {
+			providerConfig: &v1alpha1.ClusterProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "sonarqube"}},
+			want:           "ClusterProviderConfig/sonarqube",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := ProviderConfigKey(tc.providerConfig); got != tc.want {
+				t.Errorf("ProviderConfigKey() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
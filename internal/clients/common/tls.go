@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+)
+
+const (
+	errGetCABundle         = "cannot get CA bundle from secret"
+	errParseCABundle       = "CA bundle does not contain any valid PEM encoded certificate"
+	errGetClientCert       = "cannot get client certificate from secret"
+	errGetClientKey        = "cannot get client key from secret"
+	errLoadClientKeyPair   = "cannot load client certificate and key"
+	errClientKeyPairNotSet = "clientCertSecretRef and clientKeySecretRef must be set together"
+)
+
+// TLSConfig is the TLS configuration used to connect to the SonarQube instance.
+type TLSConfig struct {
+	// RootCAs are the CA certificates used to verify the server certificate, nil to only use the system trust store
+	RootCAs *x509.CertPool
+	// Certificates are the client certificates presented for mutual TLS
+	Certificates []tls.Certificate
+	// ServerName overrides the name used to verify the server certificate
+	ServerName string
+}
+
+// buildTLSConfig loads the CA bundle and client certificate referenced by the TLS configuration of a ProviderConfig.
+// It returns nil if no TLS configuration is given.
+func buildTLSConfig(ctx context.Context, kubeClient client.Client, managedResource resource.Managed, spec *v1alpha1.TLSConfig) (*TLSConfig, error) {
+	if spec == nil {
+		return nil, nil //nolint:nilnil // No TLS configuration is not an error
+	}
+
+	tlsConfig := &TLSConfig{
+		ServerName: ptr.Deref(spec.ServerName, ""),
+	}
+
+	if spec.CABundleSecretRef != nil {
+		caBundle, err := GetTokenValueFromSecret(ctx, kubeClient, managedResource, spec.CABundleSecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errGetCABundle)
+		}
+
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+
+		if !rootCAs.AppendCertsFromPEM([]byte(*caBundle)) {
+			return nil, errors.New(errParseCABundle)
+		}
+
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if (spec.ClientCertSecretRef == nil) != (spec.ClientKeySecretRef == nil) {
+		return nil, errors.New(errClientKeyPairNotSet)
+	}
+
+	if spec.ClientCertSecretRef != nil {
+		clientCert, err := GetTokenValueFromSecret(ctx, kubeClient, managedResource, spec.ClientCertSecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errGetClientCert)
+		}
+
+		clientKey, err := GetTokenValueFromSecret(ctx, kubeClient, managedResource, spec.ClientKeySecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errGetClientKey)
+		}
+
+		certificate, err := tls.X509KeyPair([]byte(*clientCert), []byte(*clientKey))
+		if err != nil {
+			return nil, errors.Wrap(err, errLoadClientKeyPair)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+// newTLSClientConfig builds the tls.Config of the HTTP transport from the client configuration.
+// It returns nil if the default TLS configuration can be used.
+func newTLSClientConfig(clientConfig Config) *tls.Config {
+	if !clientConfig.InsecureSkipVerify && clientConfig.TLS == nil {
+		return nil
+	}
+
+	tlsClientConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: clientConfig.InsecureSkipVerify, //nolint:gosec // Explicitly requested in the ProviderConfig
+	}
+
+	if clientConfig.TLS != nil {
+		tlsClientConfig.RootCAs = clientConfig.TLS.RootCAs
+		tlsClientConfig.Certificates = clientConfig.TLS.Certificates
+		tlsClientConfig.ServerName = clientConfig.TLS.ServerName
+	}
+
+	return tlsClientConfig
+}
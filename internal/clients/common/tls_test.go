@@ -0,0 +1,302 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+)
+
+// testCertificate is a PEM encoded certificate and private key generated for the tests.
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	certPEM     []byte
+	keyPEM      []byte
+}
+
+// newTestCertificate generates a certificate signed by the given parent, or a self-signed CA if parent is nil.
+func newTestCertificate(t *testing.T, commonName string, parent *testCertificate) *testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.certificate, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testCertificate{
+		certificate: certificate,
+		key:         key,
+		certPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:      pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+// newMutualTLSServer starts a TLS server answering the SonarQube version endpoint, requiring a client certificate signed by clientCA.
+func newMutualTLSServer(t *testing.T, clientCA *testCertificate) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("2025.1.0"))
+	}))
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCA.certificate)
+
+	server.TLS = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestMutualTLS(t *testing.T) {
+	t.Parallel()
+
+	clientCA := newTestCertificate(t, "client-ca", nil)
+	clientCert := newTestCertificate(t, "crossplane", clientCA)
+	untrustedCA := newTestCertificate(t, "untrusted-ca", nil)
+	untrustedCert := newTestCertificate(t, "crossplane", untrustedCA)
+
+	server := newMutualTLSServer(t, clientCA)
+	serverCAPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	kube := newFakeClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "sonarqube-tls", Namespace: "default"},
+		Data: map[string][]byte{
+			"ca.crt":         serverCAPEM,
+			"tls.crt":        clientCert.certPEM,
+			"tls.key":        clientCert.keyPEM,
+			"untrusted.crt":  untrustedCert.certPEM,
+			"untrusted.key":  untrustedCert.keyPEM,
+			"invalid-ca.crt": []byte("not a certificate"),
+		},
+	})
+
+	selector := func(key string) *xpv1.SecretKeySelector {
+		return &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "sonarqube-tls", Namespace: "default"},
+			Key:             key,
+		}
+	}
+
+	tests := map[string]struct {
+		spec        *v1alpha1.TLSConfig
+		buildErr    bool
+		connectFail bool
+	}{
+		"MutualTLS": {
+			spec: &v1alpha1.TLSConfig{
+				CABundleSecretRef:   selector("ca.crt"),
+				ClientCertSecretRef: selector("tls.crt"),
+				ClientKeySecretRef:  selector("tls.key"),
+			},
+		},
+		"MutualTLSWithServerName": {
+			spec: &v1alpha1.TLSConfig{
+				CABundleSecretRef:   selector("ca.crt"),
+				ClientCertSecretRef: selector("tls.crt"),
+				ClientKeySecretRef:  selector("tls.key"),
+				ServerName:          ptr.To("example.com"),
+			},
+		},
+		"ServerNameMismatch": {
+			spec: &v1alpha1.TLSConfig{
+				CABundleSecretRef:   selector("ca.crt"),
+				ClientCertSecretRef: selector("tls.crt"),
+				ClientKeySecretRef:  selector("tls.key"),
+				ServerName:          ptr.To("sonarqube.internal"),
+			},
+			connectFail: true,
+		},
+		"MissingClientCertificate": {
+			spec: &v1alpha1.TLSConfig{
+				CABundleSecretRef: selector("ca.crt"),
+			},
+			connectFail: true,
+		},
+		"UntrustedClientCertificate": {
+			spec: &v1alpha1.TLSConfig{
+				CABundleSecretRef:   selector("ca.crt"),
+				ClientCertSecretRef: selector("untrusted.crt"),
+				ClientKeySecretRef:  selector("untrusted.key"),
+			},
+			connectFail: true,
+		},
+		"UnknownServerCA": {
+			spec: &v1alpha1.TLSConfig{
+				ClientCertSecretRef: selector("tls.crt"),
+				ClientKeySecretRef:  selector("tls.key"),
+			},
+			connectFail: true,
+		},
+		"InvalidCABundle": {
+			spec: &v1alpha1.TLSConfig{
+				CABundleSecretRef: selector("invalid-ca.crt"),
+			},
+			buildErr: true,
+		},
+		"ClientCertificateWithoutKey": {
+			spec: &v1alpha1.TLSConfig{
+				ClientCertSecretRef: selector("tls.crt"),
+			},
+			buildErr: true,
+		},
+		"MismatchedClientKey": {
+			spec: &v1alpha1.TLSConfig{
+				ClientCertSecretRef: selector("untrusted.crt"),
+				ClientKeySecretRef:  selector("tls.key"),
+			},
+			buildErr: true,
+		},
+		"MissingSecretKey": {
+			spec: &v1alpha1.TLSConfig{
+				CABundleSecretRef: selector("missing"),
+			},
+			buildErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			tlsConfig, err := buildTLSConfig(context.Background(), kube, &fake.Managed{}, tc.spec)
+			if tc.buildErr {
+				if err == nil {
+					t.Fatal("buildTLSConfig() expected an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("buildTLSConfig() unexpected error = %v", err)
+			}
+
+			client, err := NewClient(Config{
+				AuthType: PersonalAccessToken,
+				Token:    "token",
+				BaseURL:  server.URL + "/api/",
+				TLS:      tlsConfig,
+			})
+
+			if err != nil {
+				t.Fatalf("NewClient() unexpected error = %v", err)
+			}
+
+			version, resp, err := client.Server.Version()
+			if resp != nil && resp.Body != nil {
+				_ = resp.Body.Close()
+			}
+
+			if tc.connectFail {
+				if err == nil {
+					t.Fatal("Server.Version() expected a TLS error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Server.Version() unexpected error = %v", err)
+			}
+
+			if ptr.Deref(version, "") != "2025.1.0" {
+				t.Errorf("Server.Version() = %v, want 2025.1.0", ptr.Deref(version, ""))
+			}
+		})
+	}
+}
+
+func TestNewTLSClientConfig(t *testing.T) {
+	t.Parallel()
+
+	if got := newTLSClientConfig(Config{}); got != nil {
+		t.Errorf("newTLSClientConfig() = %v, want nil without TLS configuration", got)
+	}
+
+	got := newTLSClientConfig(Config{InsecureSkipVerify: true, TLS: &TLSConfig{ServerName: "sonarqube.internal"}})
+	if got == nil || !got.InsecureSkipVerify || got.ServerName != "sonarqube.internal" || got.MinVersion != tls.VersionTLS12 {
+		t.Errorf("newTLSClientConfig() = %+v, want InsecureSkipVerify, ServerName and TLS 1.2 minimum", got)
+	}
+}
+
+func TestBuildTLSConfigNil(t *testing.T) {
+	t.Parallel()
+
+	got, err := buildTLSConfig(context.Background(), newFakeClient(), &fake.Managed{}, nil)
+	if err != nil || got != nil {
+		t.Errorf("buildTLSConfig(nil) = %v, %v, want nil, nil", got, err)
+	}
+}
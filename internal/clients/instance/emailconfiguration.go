@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/boxboxjason/sonarqube-client-go/sonar"
+	"github.com/crossplane/provider-sonarqube/apis/instance/v1alpha1"
+	"github.com/crossplane/provider-sonarqube/internal/clients/common"
+	"k8s.io/utils/ptr"
+)
+
+// SonarQube setting keys holding the Email/SMTP configuration.
+const (
+	EmailSettingSMTPHost             = "email.smtp_host.secured"
+	EmailSettingSMTPPort             = "email.smtp_port.secured"
+	EmailSettingSMTPSecureConnection = "email.smtp_secure_connection.secured"
+	EmailSettingSMTPUsername         = "email.smtp_username.secured"
+	EmailSettingSMTPPassword         = "email.smtp_password.secured" //nolint:gosec // This is a setting key, not a credential
+	EmailSettingFrom                 = "email.from"
+	EmailSettingFromName             = "email.fromName"
+	EmailSettingPrefix               = "email.prefix"
+
+	// defaultTestEmailSubject is the subject used for test emails when none is given.
+	defaultTestEmailSubject = "SonarQube test email"
+	// defaultTestEmailMessage is the message used for test emails when none is given.
+	defaultTestEmailMessage = "This is a test email sent by Crossplane provider-sonarqube after the SonarQube email configuration has changed."
+)
+
+// EmailSettingKeys is the list of all the SonarQube setting keys managed by an EmailConfiguration.
+var EmailSettingKeys = []string{
+	EmailSettingSMTPHost,
+	EmailSettingSMTPPort,
+	EmailSettingSMTPSecureConnection,
+	EmailSettingSMTPUsername,
+	EmailSettingSMTPPassword,
+	EmailSettingFrom,
+	EmailSettingFromName,
+	EmailSettingPrefix,
+}
+
+// EmailsClient is the interface for interacting with SonarQube Emails API.
+type EmailsClient interface {
+	Send(opt *sonar.EmailsSendOption) (*http.Response, error)
+}
+
+// NewEmailsClient creates a new EmailsClient with the provided SonarQube client configuration.
+func NewEmailsClient(clientConfig common.Config) (EmailsClient, error) {
+	newClient, err := common.GetClient(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient.Emails, nil
+}
+
+// GenerateEmailConfigurationSettings generates the map of SonarQube setting keys to values from the EmailConfigurationParameters.
+// The password is passed separately as it is resolved from a Secret. Only the settings that are set in the parameters are returned.
+func GenerateEmailConfigurationSettings(params v1alpha1.EmailConfigurationParameters, password *string) map[string]string {
+	settings := map[string]string{
+		EmailSettingSMTPHost: params.Host,
+	}
+
+	if params.Port != nil {
+		settings[EmailSettingSMTPPort] = strconv.FormatInt(int64(*params.Port), 10)
+	}
+
+	assignSettingIfNonNil(settings, EmailSettingSMTPSecureConnection, params.SecureConnection)
+	assignSettingIfNonNil(settings, EmailSettingSMTPUsername, params.Username)
+	assignSettingIfNonNil(settings, EmailSettingSMTPPassword, password)
+	assignSettingIfNonNil(settings, EmailSettingFrom, params.FromAddress)
+	assignSettingIfNonNil(settings, EmailSettingFromName, params.FromName)
+	assignSettingIfNonNil(settings, EmailSettingPrefix, params.Prefix)
+
+	return settings
+}
+
+// assignSettingIfNonNil sets the key in the settings map if the value is not nil.
+func assignSettingIfNonNil(settings map[string]string, key string, value *string) {
+	if value != nil {
+		settings[key] = *value
+	}
+}
+
+// HashSettings computes a stable hash of the given settings.
+// It is used to detect changes of settings whose value is never returned by SonarQube.
+func HashSettings(settings map[string]string) string {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	hash := sha256.New()
+	for _, key := range keys {
+		_, _ = hash.Write([]byte(key))
+		_, _ = hash.Write([]byte{0})
+		_, _ = hash.Write([]byte(settings[key]))
+		_, _ = hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// HashEmailConfiguration computes the hash identifying the given email settings, recorded to detect changes of the secured settings.
+// The SMTP password is identified by the version of the key of the Secret it comes from rather than by its value,
+// so that no digest of the password, which could be brute-forced offline, is published in the status.
+func HashEmailConfiguration(settings map[string]string, passwordVersion string) string {
+	hashed := maps.Clone(settings)
+	if _, exists := hashed[EmailSettingSMTPPassword]; exists {
+		hashed[EmailSettingSMTPPassword] = passwordVersion
+	}
+
+	return HashSettings(hashed)
+}
+
+// GenerateEmailConfigurationValuesOptions generates the options for the Values API call fetching the email settings.
+func GenerateEmailConfigurationValuesOptions() *sonar.SettingsValuesOption {
+	return &sonar.SettingsValuesOption{
+		Keys: slices.Clone(EmailSettingKeys),
+	}
+}
+
+// GenerateEmailConfigurationSetOptions generates the options for the Set API calls applying the given email settings.
+// The options are sorted by key so that the calls are made in a deterministic order.
+func GenerateEmailConfigurationSetOptions(settings map[string]string) []*sonar.SettingsSetOption {
+	options := make([]*sonar.SettingsSetOption, 0, len(settings))
+
+	for _, key := range EmailSettingKeys {
+		value, exists := settings[key]
+		if !exists {
+			continue
+		}
+
+		options = append(options, &sonar.SettingsSetOption{
+			Key:   key,
+			Value: value,
+		})
+	}
+
+	return options
+}
+
+// GenerateEmailConfigurationResetOptions generates the options for the Reset API call resetting the email settings that are not in the given settings.
+// It returns nil if there is nothing to reset.
+func GenerateEmailConfigurationResetOptions(settings map[string]string) *sonar.SettingsResetOption {
+	keys := make([]string, 0, len(EmailSettingKeys))
+
+	for _, key := range EmailSettingKeys {
+		if _, exists := settings[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return GenerateSettingsResetOptionsFromList(keys, nil)
+}
+
+// GenerateEmailsSendOption generates the options for the Send API call from the EmailConfigurationTestParameters.
+func GenerateEmailsSendOption(params v1alpha1.EmailConfigurationTestParameters) *sonar.EmailsSendOption {
+	return &sonar.EmailsSendOption{
+		To:      params.To,
+		Subject: ptr.Deref(params.Subject, defaultTestEmailSubject),
+		Message: ptr.Deref(params.Message, defaultTestEmailMessage),
+	}
+}
+
+// GenerateEmailConfigurationObservation generates the EmailConfigurationObservation from the observed SettingsValues.
+// The configuration hash and the test email result are not returned by SonarQube and must be carried over by the caller.
+func GenerateEmailConfigurationObservation(observed *sonar.SettingsValues) v1alpha1.EmailConfigurationObservation {
+	observation := v1alpha1.EmailConfigurationObservation{}
+
+	if observed == nil {
+		return observation
+	}
+
+	for _, setting := range observed.Settings {
+		switch setting.Key {
+		case EmailSettingFrom:
+			observation.FromAddress = setting.Value
+		case EmailSettingFromName:
+			observation.FromName = setting.Value
+		case EmailSettingPrefix:
+			observation.Prefix = setting.Value
+		}
+	}
+
+	for _, key := range observed.SetSecuredSettings {
+		if slices.Contains(EmailSettingKeys, key) {
+			observation.SecuredSettings = append(observation.SecuredSettings, key)
+		}
+	}
+
+	slices.Sort(observation.SecuredSettings)
+
+	return observation
+}
+
+// IsEmailConfigurationConfigured checks whether an email configuration exists in SonarQube, i.e. whether the SMTP host is set.
+func IsEmailConfigurationConfigured(observation v1alpha1.EmailConfigurationObservation) bool {
+	return slices.Contains(observation.SecuredSettings, EmailSettingSMTPHost)
+}
+
+// IsEmailConfigurationUpToDate checks whether the observed email configuration is up to date with the desired settings, identified by the given hash.
+// Secured settings are compared through the hash of the last applied configuration, as SonarQube never returns their value.
+func IsEmailConfigurationUpToDate(settings map[string]string, hash string, observation v1alpha1.EmailConfigurationObservation) bool {
+	if observation.ConfigurationHash != hash {
+		return false
+	}
+
+	observedPlain := map[string]string{
+		EmailSettingFrom:     observation.FromAddress,
+		EmailSettingFromName: observation.FromName,
+		EmailSettingPrefix:   observation.Prefix,
+	}
+
+	for _, key := range EmailSettingKeys {
+		value, desired := settings[key]
+
+		if observedValue, plain := observedPlain[key]; plain {
+			if desired && value != observedValue {
+				return false
+			}
+
+			continue
+		}
+
+		// Secured settings must be set in SonarQube if and only if they are desired.
+		if desired != slices.Contains(observation.SecuredSettings, key) {
+			return false
+		}
+	}
+
+	return true
+}
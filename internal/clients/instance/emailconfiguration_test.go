@@ -0,0 +1,344 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"testing"
+
+	"github.com/boxboxjason/sonarqube-client-go/sonar"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/provider-sonarqube/apis/instance/v1alpha1"
+)
+
+func TestGenerateEmailConfigurationSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		params   v1alpha1.EmailConfigurationParameters
+		password *string
+		want     map[string]string
+	}{
+		"HostOnly": {
+			params: v1alpha1.EmailConfigurationParameters{
+				Host: "smtp.example.com",
+			},
+			want: map[string]string{
+				EmailSettingSMTPHost: "smtp.example.com",
+			},
+		},
+		"AllFields": {
+			params: v1alpha1.EmailConfigurationParameters{
+				Host:             "smtp.example.com",
+				Port:             ptr.To[int32](587),
+				SecureConnection: ptr.To("starttls"),
+				Username:         ptr.To("sonarqube"),
+				FromAddress:      ptr.To("sonarqube@example.com"),
+				FromName:         ptr.To("SonarQube"),
+				Prefix:           ptr.To("[SONARQUBE]"),
+			},
+			password: ptr.To("secret"),
+			want: map[string]string{
+				EmailSettingSMTPHost:             "smtp.example.com",
+				EmailSettingSMTPPort:             "587",
+				EmailSettingSMTPSecureConnection: "starttls",
+				EmailSettingSMTPUsername:         "sonarqube",
+				EmailSettingSMTPPassword:         "secret",
+				EmailSettingFrom:                 "sonarqube@example.com",
+				EmailSettingFromName:             "SonarQube",
+				EmailSettingPrefix:               "[SONARQUBE]",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := GenerateEmailConfigurationSettings(tc.params, tc.password)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateEmailConfigurationSettings() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHashSettings(t *testing.T) {
+	t.Parallel()
+
+	base := map[string]string{
+		EmailSettingSMTPHost:     "smtp.example.com",
+		EmailSettingSMTPPassword: "secret",
+	}
+
+	if HashSettings(base) != HashSettings(map[string]string{
+		EmailSettingSMTPPassword: "secret",
+		EmailSettingSMTPHost:     "smtp.example.com",
+	}) {
+		t.Error("HashSettings() is not stable across map ordering")
+	}
+
+	if HashSettings(base) == HashSettings(map[string]string{
+		EmailSettingSMTPHost:     "smtp.example.com",
+		EmailSettingSMTPPassword: "rotated",
+	}) {
+		t.Error("HashSettings() did not change when a value changed")
+	}
+
+	if HashSettings(map[string]string{"ab": "c"}) == HashSettings(map[string]string{"a": "bc"}) {
+		t.Error("HashSettings() collides when key and value boundaries move")
+	}
+}
+
+func TestHashEmailConfiguration(t *testing.T) {
+	t.Parallel()
+
+	settings := map[string]string{
+		EmailSettingSMTPHost:     "smtp.example.com",
+		EmailSettingSMTPPassword: "secret",
+	}
+
+	if HashEmailConfiguration(settings, "uid/1/password") == HashSettings(settings) {
+		t.Error("HashEmailConfiguration() is the plain digest of the password")
+	}
+
+	if HashEmailConfiguration(settings, "uid/1/password") != HashEmailConfiguration(map[string]string{
+		EmailSettingSMTPHost:     "smtp.example.com",
+		EmailSettingSMTPPassword: "rotated",
+	}, "uid/1/password") {
+		t.Error("HashEmailConfiguration() depends on the value of the password")
+	}
+
+	if HashEmailConfiguration(settings, "uid/1/password") == HashEmailConfiguration(settings, "uid/2/password") {
+		t.Error("HashEmailConfiguration() did not change with the version of the Secret")
+	}
+}
+
+func TestGenerateEmailConfigurationSetOptions(t *testing.T) {
+	t.Parallel()
+
+	got := GenerateEmailConfigurationSetOptions(map[string]string{
+		EmailSettingPrefix:   "[SQ]",
+		EmailSettingSMTPHost: "smtp.example.com",
+	})
+
+	want := []*sonar.SettingsSetOption{
+		{Key: EmailSettingSMTPHost, Value: "smtp.example.com"},
+		{Key: EmailSettingPrefix, Value: "[SQ]"},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateEmailConfigurationSetOptions() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateEmailConfigurationResetOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		settings map[string]string
+		want     *sonar.SettingsResetOption
+	}{
+		"ResetsUnsetKeys": {
+			settings: map[string]string{
+				EmailSettingSMTPHost:     "smtp.example.com",
+				EmailSettingSMTPPort:     "25",
+				EmailSettingSMTPUsername: "user",
+				EmailSettingSMTPPassword: "secret",
+				EmailSettingFrom:         "sonarqube@example.com",
+			},
+			want: &sonar.SettingsResetOption{
+				Keys: []string{EmailSettingSMTPSecureConnection, EmailSettingFromName, EmailSettingPrefix},
+			},
+		},
+		"NothingToReset": {
+			settings: map[string]string{
+				EmailSettingSMTPHost:             "smtp.example.com",
+				EmailSettingSMTPPort:             "25",
+				EmailSettingSMTPSecureConnection: "ssl",
+				EmailSettingSMTPUsername:         "user",
+				EmailSettingSMTPPassword:         "secret",
+				EmailSettingFrom:                 "sonarqube@example.com",
+				EmailSettingFromName:             "SonarQube",
+				EmailSettingPrefix:               "[SQ]",
+			},
+			want: nil,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := GenerateEmailConfigurationResetOptions(tc.settings)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateEmailConfigurationResetOptions() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateEmailsSendOption(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		params v1alpha1.EmailConfigurationTestParameters
+		want   *sonar.EmailsSendOption
+	}{
+		"Defaults": {
+			params: v1alpha1.EmailConfigurationTestParameters{To: "admin@example.com"},
+			want: &sonar.EmailsSendOption{
+				To:      "admin@example.com",
+				Subject: defaultTestEmailSubject,
+				Message: defaultTestEmailMessage,
+			},
+		},
+		"Custom": {
+			params: v1alpha1.EmailConfigurationTestParameters{
+				To:      "admin@example.com",
+				Subject: ptr.To("Hello"),
+				Message: ptr.To("World"),
+			},
+			want: &sonar.EmailsSendOption{
+				To:      "admin@example.com",
+				Subject: "Hello",
+				Message: "World",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := GenerateEmailsSendOption(tc.params)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateEmailsSendOption() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateEmailConfigurationObservation(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		observed *sonar.SettingsValues
+		want     v1alpha1.EmailConfigurationObservation
+	}{
+		"Nil": {
+			observed: nil,
+			want:     v1alpha1.EmailConfigurationObservation{},
+		},
+		"PlainAndSecuredSettings": {
+			observed: &sonar.SettingsValues{
+				Settings: []sonar.SettingValue{
+					{Key: EmailSettingFrom, Value: "sonarqube@example.com"},
+					{Key: EmailSettingFromName, Value: "SonarQube"},
+					{Key: EmailSettingPrefix, Value: "[SQ]"},
+				},
+				SetSecuredSettings: []string{EmailSettingSMTPPassword, "sonar.auth.github.clientSecret.secured", EmailSettingSMTPHost},
+			},
+			want: v1alpha1.EmailConfigurationObservation{
+				FromAddress:     "sonarqube@example.com",
+				FromName:        "SonarQube",
+				Prefix:          "[SQ]",
+				SecuredSettings: []string{EmailSettingSMTPHost, EmailSettingSMTPPassword},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := GenerateEmailConfigurationObservation(tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateEmailConfigurationObservation() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsEmailConfigurationUpToDate(t *testing.T) {
+	t.Parallel()
+
+	settings := map[string]string{
+		EmailSettingSMTPHost:     "smtp.example.com",
+		EmailSettingSMTPPassword: "secret",
+		EmailSettingFrom:         "sonarqube@example.com",
+	}
+	hash := HashEmailConfiguration(settings, "uid/1/password")
+
+	tests := map[string]struct {
+		observation v1alpha1.EmailConfigurationObservation
+		want        bool
+	}{
+		"UpToDate": {
+			observation: v1alpha1.EmailConfigurationObservation{
+				FromAddress:       "sonarqube@example.com",
+				SecuredSettings:   []string{EmailSettingSMTPHost, EmailSettingSMTPPassword},
+				ConfigurationHash: hash,
+			},
+			want: true,
+		},
+		"HashMismatch": {
+			observation: v1alpha1.EmailConfigurationObservation{
+				FromAddress:       "sonarqube@example.com",
+				SecuredSettings:   []string{EmailSettingSMTPHost, EmailSettingSMTPPassword},
+				ConfigurationHash: "stale",
+			},
+			want: false,
+		},
+		"PlainValueDrifted": {
+			observation: v1alpha1.EmailConfigurationObservation{
+				FromAddress:       "someone@example.com",
+				SecuredSettings:   []string{EmailSettingSMTPHost, EmailSettingSMTPPassword},
+				ConfigurationHash: hash,
+			},
+			want: false,
+		},
+		"SecuredValueReset": {
+			observation: v1alpha1.EmailConfigurationObservation{
+				FromAddress:       "sonarqube@example.com",
+				SecuredSettings:   []string{EmailSettingSMTPHost},
+				ConfigurationHash: hash,
+			},
+			want: false,
+		},
+		"UnwantedSecuredValueSet": {
+			observation: v1alpha1.EmailConfigurationObservation{
+				FromAddress:       "sonarqube@example.com",
+				SecuredSettings:   []string{EmailSettingSMTPHost, EmailSettingSMTPPassword, EmailSettingSMTPUsername},
+				ConfigurationHash: hash,
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := IsEmailConfigurationUpToDate(settings, hash, tc.observation)
+			if got != tc.want {
+				t.Errorf("IsEmailConfigurationUpToDate() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -56,10 +56,13 @@ type QualityGatesClient interface {
 }
 
 // NewQualityGatesClient creates a new QualityGatesClient with the provided SonarQube client configuration.
-func NewQualityGatesClient(clientConfig common.Config) QualityGatesClient {
-	newClient := common.NewClient(clientConfig)
+func NewQualityGatesClient(clientConfig common.Config) (QualityGatesClient, error) {
+	newClient, err := common.GetClient(clientConfig)
+	if err != nil {
+		return nil, err
+	}
 
-	return newClient.Qualitygates
+	return newClient.Qualitygates, nil
 }
 
 // GenerateQualityGateCreateOptions generates SonarQube QualitygatesCreateOption from QualityGateParameters.
@@ -17,15 +17,34 @@ limitations under the License.
 package instance
 
 import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"mime/multipart"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/boxboxjason/sonarqube-client-go/sonar"
 	"github.com/crossplane/provider-sonarqube/apis/instance/v1alpha1"
 	"github.com/crossplane/provider-sonarqube/internal/clients/common"
 	"github.com/crossplane/provider-sonarqube/internal/helpers"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 )
 
+// MinQualityProfileBulkRules is the minimum number of rules activated or deactivated in a single bulk request,
+// smaller sets of rules are changed with individual requests.
+const MinQualityProfileBulkRules = 3
+
 // QualityProfilesClient is the interface for the Quality Profiles SonarQube client.
 //
 //nolint:interfacebloat // This interface wraps the SonarQube Quality Profiles API which has 26 methods
@@ -56,13 +75,177 @@ type QualityProfilesClient interface {
 	SearchUsers(opt *sonar.QualityprofilesSearchUsersOption) (v *sonar.QualityprofilesSearchUsers, resp *http.Response, err error)
 	SetDefault(opt *sonar.QualityprofilesSetDefaultOption) (resp *http.Response, err error)
 	Show(opt *sonar.QualityprofilesShowOption) (v *sonar.QualityprofilesShow, resp *http.Response, err error)
+	// BulkActivateRules is ActivateRules returning the number of rules activated and not activated.
+	BulkActivateRules(opt *sonar.QualityprofilesActivateRulesOption) (v *QualityProfilesBulkChange, resp *http.Response, err error)
+	// BulkDeactivateRules is DeactivateRules returning the number of rules deactivated and not deactivated.
+	BulkDeactivateRules(opt *sonar.QualityprofilesDeactivateRulesOption) (v *QualityProfilesBulkChange, resp *http.Response, err error)
+	// ChangelogEvents is Changelog returning all the parameters of the events, including the severity and the rule parameters.
+	ChangelogEvents(opt *sonar.QualityprofilesChangelogOption) (v *QualityProfilesChangelog, resp *http.Response, err error)
+	// RestoreBackup is Restore uploading the backup as a file, as required by SonarQube, and returning the restored Quality Profile.
+	RestoreBackup(backup string) (v *QualityProfilesRestore, resp *http.Response, err error)
+}
+
+// QualityProfilesRestore is the result of the restoration of a Quality Profile from a backup.
+type QualityProfilesRestore struct {
+	// Profile is the restored Quality Profile
+	Profile sonar.QualityProfile `json:"profile"`
+	// RuleSuccesses is the number of rules of the backup that were activated
+	RuleSuccesses int64 `json:"ruleSuccesses"`
+	// RuleFailures is the number of rules of the backup that could not be activated
+	RuleFailures int64 `json:"ruleFailures"`
+}
+
+// QualityProfilesBulkChange is the result of the bulk activation or deactivation of rules in a Quality Profile.
+type QualityProfilesBulkChange struct {
+	// Succeeded is the number of rules activated or deactivated
+	Succeeded int64 `json:"succeeded"`
+	// Failed is the number of rules that could not be activated or deactivated
+	Failed int64 `json:"failed"`
+	// Errors describe why rules could not be activated or deactivated
+	Errors []QualityProfilesBulkChangeError `json:"errors,omitempty"`
+}
+
+// QualityProfilesBulkChangeError describes why rules could not be activated or deactivated in bulk.
+type QualityProfilesBulkChangeError struct {
+	Msg string `json:"msg"`
+}
+
+// QualityProfilesChangelog is a page of the changelog of a Quality Profile.
+type QualityProfilesChangelog struct {
+	Paging sonar.Paging                    `json:"paging"`
+	Events []QualityProfilesChangelogEvent `json:"events"`
+}
+
+// QualityProfilesChangelogEvent is a change of a rule of a Quality Profile.
+type QualityProfilesChangelogEvent struct {
+	Action      string `json:"action"`
+	AuthorLogin string `json:"authorLogin,omitempty"`
+	AuthorName  string `json:"authorName,omitempty"`
+	Date        string `json:"date"`
+	RuleKey     string `json:"ruleKey"`
+	// Params are the changed severity, impacts and clean code attribute of the rule, and its changed parameters by key
+	Params map[string]json.RawMessage `json:"params,omitempty"`
+}
+
+// qualityProfilesClient implements QualityProfilesClient with the Quality Profiles service of a SonarQube client,
+// which does not return the response of the bulk activation and deactivation of rules nor the severity and the rule parameters
+// of the changelog, and sends backups as query parameters.
+type qualityProfilesClient struct {
+	*sonar.QualityprofilesService
+
+	client *sonar.Client
 }
 
 // NewQualityProfilesClient creates a new QualityProfilesClient with the provided SonarQube client configuration.
-func NewQualityProfilesClient(clientConfig common.Config) QualityProfilesClient {
-	newClient := common.NewClient(clientConfig)
+func NewQualityProfilesClient(clientConfig common.Config) (QualityProfilesClient, error) {
+	newClient, err := common.GetClient(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &qualityProfilesClient{QualityprofilesService: newClient.Qualityprofiles, client: newClient}, nil
+}
+
+// BulkActivateRules activates the rules matching the search query of the option, it calls api/qualityprofiles/activate_rules.
+func (c *qualityProfilesClient) BulkActivateRules(opt *sonar.QualityprofilesActivateRulesOption) (*QualityProfilesBulkChange, *http.Response, error) {
+	err := c.ValidateActivateRulesOpt(opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return c.bulkChange("qualityprofiles/activate_rules", opt)
+}
+
+// BulkDeactivateRules deactivates the rules matching the search query of the option, it calls api/qualityprofiles/deactivate_rules.
+func (c *qualityProfilesClient) BulkDeactivateRules(opt *sonar.QualityprofilesDeactivateRulesOption) (*QualityProfilesBulkChange, *http.Response, error) {
+	err := c.ValidateDeactivateRulesOpt(opt)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	return newClient.Qualityprofiles
+	return c.bulkChange("qualityprofiles/deactivate_rules", opt)
+}
+
+// bulkChange sends a bulk activation or deactivation request and decodes its result.
+func (c *qualityProfilesClient) bulkChange(path string, opt any) (*QualityProfilesBulkChange, *http.Response, error) {
+	req, err := c.client.NewRequest(http.MethodPost, path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	change := &QualityProfilesBulkChange{}
+
+	resp, err := c.client.Do(req, change)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return change, resp, nil
+}
+
+// ChangelogEvents looks up the changelog of a Quality Profile, it calls api/qualityprofiles/changelog.
+func (c *qualityProfilesClient) ChangelogEvents(opt *sonar.QualityprofilesChangelogOption) (*QualityProfilesChangelog, *http.Response, error) {
+	err := c.ValidateChangelogOpt(opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.NewRequest(http.MethodGet, "qualityprofiles/changelog", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changelog := &QualityProfilesChangelog{}
+
+	resp, err := c.client.Do(req, changelog)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return changelog, resp, nil
+}
+
+// RestoreBackup restores a Quality Profile from its XML backup, it calls api/qualityprofiles/restore with the backup as a multipart file.
+func (c *qualityProfilesClient) RestoreBackup(backup string) (*QualityProfilesRestore, *http.Response, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("backup", "backup.xml")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, err = io.WriteString(part, backup)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := c.client.NewRequest(http.MethodPost, "qualityprofiles/restore", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content := body.Bytes()
+	req.Body = io.NopCloser(bytes.NewReader(content))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(content)), nil
+	}
+	req.ContentLength = int64(len(content))
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	restore := &QualityProfilesRestore{}
+
+	resp, err := c.client.Do(req, restore)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return restore, resp, nil
 }
 
 // GenerateCreateQualityProfileOption generates SonarQube QualityprofilesCreateOption from QualityProfileParameters.
@@ -73,14 +256,155 @@ func GenerateCreateQualityProfileOption(params v1alpha1.QualityProfileParameters
 	}
 }
 
-// GenerateDeleteQualityProfileOption generates SonarQube QualityprofilesDeleteOption from QualityProfileParameters.
-func GenerateDeleteQualityProfileOption(params v1alpha1.QualityProfileParameters) *sonar.QualityprofilesDeleteOption {
+// GenerateDeleteQualityProfileOption generates SonarQube QualityprofilesDeleteOption for the Quality Profile shown by its key.
+// The observed name is used, since the name of the spec differs from it while a rename is pending.
+func GenerateDeleteQualityProfileOption(profile sonar.ShownProfile) *sonar.QualityprofilesDeleteOption {
 	return &sonar.QualityprofilesDeleteOption{
+		Language:       profile.Language,
+		QualityProfile: profile.Name,
+	}
+}
+
+// Quality Profile deletion behaviors.
+const (
+	// QualityProfileDeletionReassign reassigns the projects of a deleted Quality Profile to the default Quality Profile of its language.
+	QualityProfileDeletionReassign = "Reassign"
+	// QualityProfileDeletionReparent reassigns the projects of a deleted Quality Profile and attaches its children to its parent.
+	QualityProfileDeletionReparent = "Reparent"
+	// QualityProfileDeletionBlock blocks the deletion of a Quality Profile while projects or children reference it.
+	QualityProfileDeletionBlock = "Block"
+)
+
+// maxProjectsPerPage is the maximum page size of the projects of a Quality Profile.
+const maxProjectsPerPage = 500
+
+// GetQualityProfileDeletionBehavior returns the deletion behavior of the Quality Profile, Reassign by default.
+func GetQualityProfileDeletionBehavior(params v1alpha1.QualityProfileParameters) string {
+	return ptr.Deref(params.DeletionBehavior, QualityProfileDeletionReassign)
+}
+
+// GenerateQualityProfileProjectsOption generates SonarQube QualityprofilesProjectsOption to fetch a page of the projects explicitly using a Quality Profile.
+func GenerateQualityProfileProjectsOption(key string, page int) *sonar.QualityprofilesProjectsOption {
+	return &sonar.QualityprofilesProjectsOption{
+		PaginationArgs: sonar.PaginationArgs{
+			Page:     int64(page),
+			PageSize: maxProjectsPerPage,
+		},
+		Key:      key,
+		Selected: "selected",
+	}
+}
+
+// FetchAllQualityProfileProjects fetches the keys of all the projects explicitly using a Quality Profile, handling pagination.
+func FetchAllQualityProfileProjects(qualityProfilesClient QualityProfilesClient, key string) ([]string, error) {
+	var projects []string
+
+	page := 1
+
+	for {
+		result, resp, err := qualityProfilesClient.Projects(GenerateQualityProfileProjectsOption(key, page)) //nolint:bodyclose // closed via helpers.CloseBody
+		helpers.CloseBody(resp)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, project := range result.Results {
+			projects = append(projects, project.Key)
+		}
+
+		if len(result.Results) == 0 || int64(len(projects)) >= result.Paging.Total {
+			return projects, nil
+		}
+
+		page++
+	}
+}
+
+// GenerateQualityProfileInheritanceOption generates SonarQube QualityprofilesInheritanceOption for the Quality Profile shown by its key.
+func GenerateQualityProfileInheritanceOption(profile sonar.ShownProfile) *sonar.QualityprofilesInheritanceOption {
+	return &sonar.QualityprofilesInheritanceOption{
+		Language:       profile.Language,
+		QualityProfile: profile.Name,
+	}
+}
+
+// GenerateQualityProfileRemoveProjectOption generates SonarQube QualityprofilesRemoveProjectOption to reassign a project
+// of the Quality Profile shown by its key to the default Quality Profile of its language.
+func GenerateQualityProfileRemoveProjectOption(profile sonar.ShownProfile, project string) *sonar.QualityprofilesRemoveProjectOption {
+	return &sonar.QualityprofilesRemoveProjectOption{
+		Language:       profile.Language,
+		Project:        project,
+		QualityProfile: profile.Name,
+	}
+}
+
+// GenerateQualityProfileReparentOption generates SonarQube QualityprofilesChangeParentOption to attach a child Quality Profile to
+// the parent of the Quality Profile described by the inheritance, or to detach it when the Quality Profile has no parent.
+func GenerateQualityProfileReparentOption(language string, inheritance *sonar.QualityprofilesInheritance, child string) *sonar.QualityprofilesChangeParentOption {
+	option := &sonar.QualityprofilesChangeParentOption{
+		Language:       language,
+		QualityProfile: child,
+	}
+
+	// The first ancestor is the direct parent
+	if len(inheritance.Ancestors) > 0 {
+		option.ParentQualityProfile = inheritance.Ancestors[0].Name
+	}
+
+	return option
+}
+
+// CheckQualityProfileDeletion returns the reasons why the deletion of a Quality Profile is blocked by its projects and children
+// for the given deletion behavior, or nil if it can be deleted.
+func CheckQualityProfileDeletion(behavior string, projects []string, children []sonar.InheritanceProfile) []string {
+	var violations []string
+
+	if behavior == QualityProfileDeletionBlock && len(projects) > 0 {
+		violations = append(violations, fmt.Sprintf("used by %d project(s): %s", len(projects), strings.Join(projects, ", ")))
+	}
+
+	if behavior != QualityProfileDeletionReparent && len(children) > 0 {
+		names := make([]string, 0, len(children))
+		for _, child := range children {
+			names = append(names, child.Name)
+		}
+
+		violations = append(violations, fmt.Sprintf("inherited by %d child Quality Profile(s): %s", len(children), strings.Join(names, ", ")))
+	}
+
+	return violations
+}
+
+// GenerateSearchQualityProfileOption generates SonarQube QualityprofilesSearchOption to find the Quality Profile with the name and language of the QualityProfileParameters.
+func GenerateSearchQualityProfileOption(params v1alpha1.QualityProfileParameters) *sonar.QualityprofilesSearchOption {
+	return &sonar.QualityprofilesSearchOption{
 		Language:       params.Language,
 		QualityProfile: params.Name,
 	}
 }
 
+// FindQualityProfileToAdopt returns the Quality Profile of the search result with the name and language of the QualityProfileParameters,
+// or nil if there is none.
+func FindQualityProfileToAdopt(search *sonar.QualityprofilesSearch, params v1alpha1.QualityProfileParameters) *sonar.QualityProfile {
+	return findQualityProfile(search, params.Name, params.Language)
+}
+
+// findQualityProfile returns the Quality Profile of the search result with the given name and language, or nil if there is none.
+func findQualityProfile(search *sonar.QualityprofilesSearch, name, language string) *sonar.QualityProfile {
+	if search == nil {
+		return nil
+	}
+
+	for i := range search.Profiles {
+		if search.Profiles[i].Name == name && search.Profiles[i].Language == language {
+			return &search.Profiles[i]
+		}
+	}
+
+	return nil
+}
+
 // GenerateRenameQualityProfileOption generates SonarQube QualityprofilesRenameOption from QualityProfileParameters.
 func GenerateRenameQualityProfileOption(key string, params v1alpha1.QualityProfileParameters) *sonar.QualityprofilesRenameOption {
 	return &sonar.QualityprofilesRenameOption{
@@ -139,17 +463,190 @@ func IsQualityProfileUpToDate(spec *v1alpha1.QualityProfileParameters, observati
 		return false
 	}
 
-	// Check if all rules are up to date
-	if !AreQualityProfileRulesUpToDate(associations) {
+	// Check if all rules are up to date, ignoring the active rules the resource does not manage
+	if !AreQualityProfileRulesUpToDate(ManagedQualityProfileRules(spec, observation, associations)) {
+		return false
+	}
+
+	// Check if the deactivated rules are inactive, except those a parent Quality Profile keeps active
+	if len(FindActiveDeactivatedRules(spec, observation)) > 0 {
 		return false
 	}
 
 	return true
 }
 
+// Quality Profile rules policies.
+const (
+	// QualityProfileRulesPolicyAuthoritative makes a QualityProfile resource own all the active rules of its Quality Profile.
+	QualityProfileRulesPolicyAuthoritative = "Authoritative"
+	// QualityProfileRulesPolicyAdditive makes a QualityProfile resource own only the rules it has activated itself.
+	QualityProfileRulesPolicyAdditive = "Additive"
+)
+
+// IsAdditiveRulesPolicy checks if the rules of the Quality Profile are managed in Additive policy.
+func IsAdditiveRulesPolicy(params v1alpha1.QualityProfileParameters) bool {
+	return ptr.Deref(params.RulesPolicy, QualityProfileRulesPolicyAuthoritative) == QualityProfileRulesPolicyAdditive
+}
+
+// ManagedQualityProfileRules returns the associations of the rules managed by the resource through its rules and rule selectors.
+// The deactivated rules are left out, they are handled separately. In Additive rules policy, the active rules that are not desired
+// and are not in the activated rules ledger are left out too.
+func ManagedQualityProfileRules(spec *v1alpha1.QualityProfileParameters, observation *v1alpha1.QualityProfileObservation, associations map[string]QualityProfileRuleAssociation) map[string]QualityProfileRuleAssociation {
+	if !IsAdditiveRulesPolicy(*spec) && len(spec.DeactivatedRules) == 0 {
+		return associations
+	}
+
+	managed := make(map[string]QualityProfileRuleAssociation, len(associations))
+
+	for key, assoc := range associations {
+		if assoc.Spec == nil && slices.Contains(spec.DeactivatedRules, key) {
+			continue
+		}
+
+		if assoc.Spec == nil && IsAdditiveRulesPolicy(*spec) && !slices.Contains(observation.ActivatedRules, key) {
+			continue
+		}
+
+		managed[key] = assoc
+	}
+
+	return managed
+}
+
+// RecordActivatedRules adds the rules to the activated rules ledger, keeping it sorted and without duplicates.
+func RecordActivatedRules(observation *v1alpha1.QualityProfileObservation, keys []string) {
+	for _, key := range keys {
+		index, found := slices.BinarySearch(observation.ActivatedRules, key)
+		if !found {
+			observation.ActivatedRules = slices.Insert(observation.ActivatedRules, index, key)
+		}
+	}
+}
+
+// ForgetActivatedRules removes the rules from the activated rules ledger.
+func ForgetActivatedRules(observation *v1alpha1.QualityProfileObservation, keys []string) {
+	observation.ActivatedRules = slices.DeleteFunc(observation.ActivatedRules, func(key string) bool {
+		return slices.Contains(keys, key)
+	})
+
+	if len(observation.ActivatedRules) == 0 {
+		observation.ActivatedRules = nil
+	}
+}
+
+// PruneActivatedRules removes from the activated rules ledger the rules that are neither active nor desired anymore,
+// e.g. the rules deactivated outside of the resource.
+func PruneActivatedRules(observation *v1alpha1.QualityProfileObservation, rules []v1alpha1.QualityProfileRuleParameters) {
+	var obsolete []string
+
+	for _, key := range observation.ActivatedRules {
+		active := slices.ContainsFunc(observation.Rules, func(rule v1alpha1.QualityProfileRuleObservation) bool { return rule.Key == key })
+		desired := slices.ContainsFunc(rules, func(rule v1alpha1.QualityProfileRuleParameters) bool { return rule.Rule == key })
+
+		if !active && !desired {
+			obsolete = append(obsolete, key)
+		}
+	}
+
+	ForgetActivatedRules(observation, obsolete)
+}
+
+// Inheritance of an active rule from a parent Quality Profile.
+const (
+	// RuleInheritanceNone is used for the rules activated in the Quality Profile itself.
+	RuleInheritanceNone = "NONE"
+	// RuleInheritanceInherited is used for the rules activated by a parent Quality Profile.
+	RuleInheritanceInherited = "INHERITED"
+	// RuleInheritanceOverrides is used for the rules activated by a parent Quality Profile whose settings are overridden.
+	RuleInheritanceOverrides = "OVERRIDES"
+)
+
+// IsInheritedRule checks whether an active rule is activated by a parent Quality Profile.
+func IsInheritedRule(observation *v1alpha1.QualityProfileRuleObservation) bool {
+	return observation.Inheritance == RuleInheritanceInherited || observation.Inheritance == RuleInheritanceOverrides
+}
+
+// FindActiveDeactivatedRules finds the active rules listed in the deactivated rules of the spec that have to be deactivated.
+// The inherited rules recorded as conflicts are left out, as a parent Quality Profile keeps them active.
+func FindActiveDeactivatedRules(spec *v1alpha1.QualityProfileParameters, observation *v1alpha1.QualityProfileObservation) []*v1alpha1.QualityProfileRuleObservation {
+	var active []*v1alpha1.QualityProfileRuleObservation
+
+	for idx := range observation.Rules {
+		rule := &observation.Rules[idx]
+		if !slices.Contains(spec.DeactivatedRules, rule.Key) {
+			continue
+		}
+
+		conflicting := slices.ContainsFunc(observation.RuleConflicts, func(conflict v1alpha1.QualityProfileRuleConflict) bool { return conflict.Rule == rule.Key })
+		if conflicting && IsInheritedRule(rule) && rule.Inheritance != RuleInheritanceOverrides {
+			continue
+		}
+
+		active = append(active, rule)
+	}
+
+	return active
+}
+
+// PruneRuleConflicts removes the conflicts of the rules that are no longer deactivated, inactive, or no longer inherited.
+func PruneRuleConflicts(spec *v1alpha1.QualityProfileParameters, observation *v1alpha1.QualityProfileObservation) {
+	observation.RuleConflicts = slices.DeleteFunc(observation.RuleConflicts, func(conflict v1alpha1.QualityProfileRuleConflict) bool {
+		if !slices.Contains(spec.DeactivatedRules, conflict.Rule) {
+			return true
+		}
+
+		return !slices.ContainsFunc(observation.Rules, func(rule v1alpha1.QualityProfileRuleObservation) bool {
+			return rule.Key == conflict.Rule && IsInheritedRule(&rule)
+		})
+	})
+
+	if len(observation.RuleConflicts) == 0 {
+		observation.RuleConflicts = nil
+	}
+}
+
+// RecordRuleConflict records that a deactivated rule stays active, keeping the conflicts sorted by rule and without duplicates.
+func RecordRuleConflict(observation *v1alpha1.QualityProfileObservation, ruleKey string, message string) {
+	index, found := slices.BinarySearchFunc(observation.RuleConflicts, ruleKey, func(conflict v1alpha1.QualityProfileRuleConflict, key string) int {
+		return strings.Compare(conflict.Rule, key)
+	})
+	if found {
+		observation.RuleConflicts[index].Message = message
+
+		return
+	}
+
+	observation.RuleConflicts = slices.Insert(observation.RuleConflicts, index, v1alpha1.QualityProfileRuleConflict{Rule: ruleKey, Message: message})
+}
+
+// CheckQualityProfileDeactivatedRules checks that the deactivated rules are not listed in the rules of the spec.
+// It returns the violations, one per rule listed in both.
+func CheckQualityProfileDeactivatedRules(spec *v1alpha1.QualityProfileParameters) []string {
+	violations := make([]string, 0)
+
+	for idx := range spec.Rules {
+		if slices.Contains(spec.DeactivatedRules, spec.Rules[idx].Rule) {
+			violations = append(violations, spec.Rules[idx].Rule+": listed in both rules and deactivatedRules")
+		}
+	}
+
+	return violations
+}
+
+// GenerateQualityProfileResetRuleOption generates SonarQube QualityprofilesActivateRuleOption resetting an inherited rule to the settings of the parent Quality Profile.
+func GenerateQualityProfileResetRuleOption(qualityProfileKey string, ruleKey string) *sonar.QualityprofilesActivateRuleOption {
+	return &sonar.QualityprofilesActivateRuleOption{
+		Key:   qualityProfileKey,
+		Rule:  ruleKey,
+		Reset: true,
+	}
+}
+
 // LateInitializeQualityProfile fills the empty fields in *QualityProfileParameters with
 // the values seen in QualityProfileObservation.
-func LateInitializeQualityProfile(spec *v1alpha1.QualityProfileParameters, observation *v1alpha1.QualityProfileObservation, associations map[string]QualityProfileRuleAssociation) {
+// Rule fields the SonarQube instance cannot set, according to its capabilities, are not late initialized.
+func LateInitializeQualityProfile(spec *v1alpha1.QualityProfileParameters, observation *v1alpha1.QualityProfileObservation, associations map[string]QualityProfileRuleAssociation, capabilities common.Capabilities) {
 	if spec == nil || observation == nil {
 		return
 	}
@@ -157,7 +654,31 @@ func LateInitializeQualityProfile(spec *v1alpha1.QualityProfileParameters, obser
 	helpers.AssignIfNil(&spec.Default, observation.IsDefault)
 
 	// Late initialize rules
-	LateInitializeQualityProfileRules(associations)
+	LateInitializeQualityProfileRules(associations, capabilities)
+}
+
+// CheckQualityProfileCapabilities checks that the SonarQube instance supports the rule fields set in the QualityProfileParameters.
+// It returns the violations, one per rule and unsupported field.
+func CheckQualityProfileCapabilities(spec *v1alpha1.QualityProfileParameters, capabilities common.Capabilities) []string {
+	violations := make([]string, 0)
+
+	for _, rule := range spec.Rules {
+		if rule.Impacts != nil && len(*rule.Impacts) > 0 {
+			err := capabilities.CheckSupported(common.FeatureRuleImpacts)
+			if err != nil {
+				violations = append(violations, rule.Rule+": "+err.Error())
+			}
+		}
+
+		if ptr.Deref(rule.Prioritized, false) {
+			err := capabilities.CheckSupported(common.FeaturePrioritizedRules)
+			if err != nil {
+				violations = append(violations, rule.Rule+": "+err.Error())
+			}
+		}
+	}
+
+	return violations
 }
 
 // GenerateQualityProfileActivateRuleOption generates SonarQube QualityprofilesActivateRuleOption from QualityProfileRuleParameters
@@ -292,13 +813,17 @@ func FindNotUpToDateQualityProfileRules(associations map[string]QualityProfileRu
 }
 
 // LateInitializeQualityProfileRules fills the empty fields in QualityProfileRuleParameters with the values seen in QualityProfileRuleObservation for the rules that exist in both spec and observation.
-func LateInitializeQualityProfileRules(associations map[string]QualityProfileRuleAssociation) {
+// Impacts and prioritization are only late initialized if the SonarQube instance supports setting them, as they are
+// reported by versions that cannot set them.
+func LateInitializeQualityProfileRules(associations map[string]QualityProfileRuleAssociation, capabilities common.Capabilities) {
 	for key, assoc := range associations {
 		if assoc.Spec != nil && assoc.Observation != nil {
 			helpers.AssignIfNil(&assoc.Spec.Severity, assoc.Observation.Severity)
-			helpers.AssignIfNil(&assoc.Spec.Prioritized, assoc.Observation.Prioritized)
+			if capabilities.Supports(common.FeaturePrioritizedRules) {
+				helpers.AssignIfNil(&assoc.Spec.Prioritized, assoc.Observation.Prioritized)
+			}
 			// Note: impacts is a map, so we only late initialize it if it's currently nil to avoid overwriting any existing values
-			if assoc.Spec.Impacts == nil && len(assoc.Observation.Impacts) > 0 {
+			if assoc.Spec.Impacts == nil && len(assoc.Observation.Impacts) > 0 && capabilities.Supports(common.FeatureRuleImpacts) {
 				impactsMap := make(map[string]string)
 				for idx := range assoc.Observation.Impacts {
 					impactsMap[assoc.Observation.Impacts[idx].SoftwareQuality] = assoc.Observation.Impacts[idx].Severity
@@ -342,3 +867,455 @@ func WereQualityProfileRulesLateInitialized(original, updated []v1alpha1.Quality
 
 	return false
 }
+
+// QualityProfileRuleActivationGroup is a set of rules selected by the same rule selector, activated with its query and settings.
+type QualityProfileRuleActivationGroup struct {
+	// Selector is the rule selector that contributed the rules
+	Selector v1alpha1.QualityProfileRuleSelector
+	// Rules are the rules of the group to activate
+	Rules []*v1alpha1.QualityProfileRuleParameters
+}
+
+// QualityProfileRuleDeactivationGroup is a set of rules of the same repository to deactivate.
+type QualityProfileRuleDeactivationGroup struct {
+	// Repository is the repository of the rules
+	Repository string
+	// Rules are the rules of the group to deactivate
+	Rules []*v1alpha1.QualityProfileRuleObservation
+}
+
+// RuleRepository returns the repository of a rule key, which has the form repository:rule. It returns an empty string if the key has no repository.
+func RuleRepository(ruleKey string) string {
+	repository, _, found := strings.Cut(ruleKey, ":")
+	if !found {
+		return ""
+	}
+
+	return repository
+}
+
+// GroupQualityProfileRulesForBulkActivation groups the rules to activate by the rule selector that contributed them, when the rules
+// of the selector can be activated in bulk. The other rules, including the rules of the spec and the rules of groups smaller than
+// MinQualityProfileBulkRules, are returned to be activated individually.
+// A bulk activation cannot select a list of rules, so only the query of a rule selector identifies the rules it activates.
+func GroupQualityProfileRulesForBulkActivation(rules []*v1alpha1.QualityProfileRuleParameters, selection QualityProfileRuleSelection) ([]QualityProfileRuleActivationGroup, []*v1alpha1.QualityProfileRuleParameters) {
+	var individual []*v1alpha1.QualityProfileRuleParameters
+
+	bySelector := make(map[string][]*v1alpha1.QualityProfileRuleParameters)
+
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+
+		selector, ok := selection.BulkSelector(rule.Rule)
+		if !ok {
+			individual = append(individual, rule)
+
+			continue
+		}
+
+		bySelector[selector.Name] = append(bySelector[selector.Name], rule)
+	}
+
+	groups := make([]QualityProfileRuleActivationGroup, 0, len(bySelector))
+
+	for _, selector := range selection.BulkSelectors {
+		selectorRules := bySelector[selector.Name]
+		if len(selectorRules) < MinQualityProfileBulkRules {
+			individual = append(individual, selectorRules...)
+
+			continue
+		}
+
+		slices.SortFunc(selectorRules, func(a, b *v1alpha1.QualityProfileRuleParameters) int { return strings.Compare(a.Rule, b.Rule) })
+		groups = append(groups, QualityProfileRuleActivationGroup{Selector: selector, Rules: selectorRules})
+	}
+
+	slices.SortFunc(individual, func(a, b *v1alpha1.QualityProfileRuleParameters) int { return strings.Compare(a.Rule, b.Rule) })
+
+	return groups, individual
+}
+
+// GroupQualityProfileRulesForBulkDeactivation groups the rules to deactivate by repository.
+// A repository is deactivated in bulk only if none of its active rules are kept and it has at least MinQualityProfileBulkRules rules to deactivate,
+// the rules of the other repositories are returned to be deactivated individually. active are all the rules active in the Quality Profile.
+func GroupQualityProfileRulesForBulkDeactivation(rules []*v1alpha1.QualityProfileRuleObservation, active []v1alpha1.QualityProfileRuleObservation) ([]QualityProfileRuleDeactivationGroup, []*v1alpha1.QualityProfileRuleObservation) {
+	activeCount := make(map[string]int)
+	for idx := range active {
+		activeCount[RuleRepository(active[idx].Key)]++
+	}
+
+	byRepository := make(map[string][]*v1alpha1.QualityProfileRuleObservation)
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+
+		byRepository[RuleRepository(rule.Key)] = append(byRepository[RuleRepository(rule.Key)], rule)
+	}
+
+	var (
+		groups     []QualityProfileRuleDeactivationGroup
+		individual []*v1alpha1.QualityProfileRuleObservation
+	)
+
+	for _, repository := range slices.Sorted(maps.Keys(byRepository)) {
+		repositoryRules := byRepository[repository]
+		slices.SortFunc(repositoryRules, func(a, b *v1alpha1.QualityProfileRuleObservation) int { return strings.Compare(a.Key, b.Key) })
+
+		if repository == "" || len(repositoryRules) < MinQualityProfileBulkRules || len(repositoryRules) != activeCount[repository] {
+			individual = append(individual, repositoryRules...)
+
+			continue
+		}
+
+		groups = append(groups, QualityProfileRuleDeactivationGroup{Repository: repository, Rules: repositoryRules})
+	}
+
+	return groups, individual
+}
+
+// GenerateQualityProfileActivateRulesOption generates SonarQube QualityprofilesActivateRulesOption activating the rules selected by the
+// rule selector of the group, for the language of the Quality Profile, with the settings of the selector.
+// The filters are those of the search resolving the rule selector, see GenerateRuleSelectorSearchOption.
+func GenerateQualityProfileActivateRulesOption(qualityProfileKey string, language string, group QualityProfileRuleActivationGroup) *sonar.QualityprofilesActivateRulesOption {
+	selector := group.Selector
+
+	return &sonar.QualityprofilesActivateRulesOption{
+		TargetKey:               qualityProfileKey,
+		Languages:               []string{language},
+		Repositories:            selector.Repositories,
+		Tags:                    selector.Tags,
+		Types:                   selector.Types,
+		Severities:              selector.Severities,
+		ImpactSoftwareQualities: selector.SoftwareQualities,
+		Cwe:                     selector.Cwe,
+		OwaspTop10:              selector.OwaspTop10,
+		OwaspTop102021:          selector.OwaspTop102021,
+		TargetSeverity:          ptr.Deref(selector.Severity, ""),
+		PrioritizedRule:         ptr.Deref(selector.Prioritized, false),
+	}
+}
+
+// GenerateQualityProfileDeactivateRulesOption generates SonarQube QualityprofilesDeactivateRulesOption deactivating the rules of a repository
+// that are active in the Quality Profile.
+func GenerateQualityProfileDeactivateRulesOption(qualityProfileKey string, repository string) *sonar.QualityprofilesDeactivateRulesOption {
+	return &sonar.QualityprofilesDeactivateRulesOption{
+		TargetKey:    qualityProfileKey,
+		Qprofile:     qualityProfileKey,
+		Activation:   true,
+		Repositories: []string{repository},
+	}
+}
+
+// QualityProfileBackup is the Quality Profile described by an XML backup.
+type QualityProfileBackup struct {
+	XMLName xml.Name `xml:"profile"`
+	// Name is the name of the Quality Profile
+	Name string `xml:"name"`
+	// Language is the language of the Quality Profile
+	Language string `xml:"language"`
+}
+
+// ParseQualityProfileBackup parses the name and the language of the Quality Profile described by an XML backup.
+func ParseQualityProfileBackup(backup string) (*QualityProfileBackup, error) {
+	parsed := &QualityProfileBackup{}
+
+	err := xml.Unmarshal([]byte(backup), parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	if parsed.Name == "" || parsed.Language == "" {
+		return nil, errors.New("the backup does not define the name and the language of the Quality Profile")
+	}
+
+	return parsed, nil
+}
+
+// HashQualityProfileBackup returns the SHA-256 hash of an XML backup, recorded to restore a backup only when it changes.
+func HashQualityProfileBackup(backup string) string {
+	sum := sha256.Sum256([]byte(backup))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// IsQualityProfileBackupRestored checks if the backup with the given hash is the last one restored in the Quality Profile.
+func IsQualityProfileBackupRestored(observation *v1alpha1.QualityProfileObservation, hash string) bool {
+	return observation.Restore != nil && observation.Restore.BackupHash == hash
+}
+
+// CheckQualityProfileBackup checks that a backup can be restored in the Quality Profile with the given name and language.
+// The name is not checked before the creation of the Quality Profile, when it is empty, since the backup then defines it.
+// It returns the violations, one per mismatching field.
+func CheckQualityProfileBackup(backup *QualityProfileBackup, name, language string) []string {
+	var violations []string
+
+	if backup.Language != language {
+		violations = append(violations, fmt.Sprintf("the backup is for language %s, not %s", backup.Language, language))
+	}
+
+	if name != "" && backup.Name != name {
+		violations = append(violations, fmt.Sprintf("the backup is for Quality Profile %s, not %s, restoring it would create another Quality Profile", backup.Name, name))
+	}
+
+	return violations
+}
+
+// GenerateQualityProfileRestoreObservation generates the report of the restoration of a backup with the given hash.
+func GenerateQualityProfileRestoreObservation(restore *QualityProfilesRestore, hash string) *v1alpha1.QualityProfileRestoreObservation {
+	return &v1alpha1.QualityProfileRestoreObservation{
+		BackupHash:    hash,
+		RuleSuccesses: restore.RuleSuccesses,
+		RuleFailures:  restore.RuleFailures,
+	}
+}
+
+// GenerateQualityProfileBackupOption generates SonarQube QualityprofilesBackupOption for the observed Quality Profile.
+func GenerateQualityProfileBackupOption(observation *v1alpha1.QualityProfileObservation) *sonar.QualityprofilesBackupOption {
+	return &sonar.QualityprofilesBackupOption{
+		Language:       observation.Language,
+		QualityProfile: observation.Name,
+	}
+}
+
+// GetQualityProfileBackupExportKey returns the key of the ConfigMap the backup of the Quality Profile is exported to.
+func GetQualityProfileBackupExportKey(export *v1alpha1.QualityProfileBackupExport) string {
+	return ptr.Deref(export.Key, "backup.xml")
+}
+
+// GenerateQualityProfileBackupExportRevision identifies the content of the observed Quality Profile exported to the ConfigMap of the backup export.
+// The backup of a Quality Profile only changes when it is renamed or its rules are updated.
+func GenerateQualityProfileBackupExportRevision(export *v1alpha1.QualityProfileBackupExport, observation *v1alpha1.QualityProfileObservation) string {
+	rulesUpdatedAt := ""
+	if observation.RulesUpdatedAt != nil {
+		rulesUpdatedAt = observation.RulesUpdatedAt.UTC().Format(time.RFC3339)
+	}
+
+	return strings.Join([]string{export.ConfigMapName, GetQualityProfileBackupExportKey(export), observation.Key, observation.Name, rulesUpdatedAt}, "/")
+}
+
+const (
+	// MaxQualityProfileExternalChanges is the number of external changes kept in the status of a Quality Profile.
+	MaxQualityProfileExternalChanges = 20
+	// maxChangelogEvents is the number of changelog events looked up for external changes at once.
+	maxChangelogEvents = 100
+	// sonarDateTimeLayout is the layout of the date times of the SonarQube API.
+	sonarDateTimeLayout = "2006-01-02T15:04:05-0700"
+)
+
+// GenerateQualityProfileChangelogOption generates SonarQube QualityprofilesChangelogOption to fetch a page of the changes of the
+// observed Quality Profile made since the given time, newest first.
+func GenerateQualityProfileChangelogOption(observation *v1alpha1.QualityProfileObservation, since *metav1.Time, page int) *sonar.QualityprofilesChangelogOption {
+	return &sonar.QualityprofilesChangelogOption{
+		PaginationArgs: sonar.PaginationArgs{
+			Page:     int64(page),
+			PageSize: maxChangelogEvents,
+		},
+		Language:       observation.Language,
+		QualityProfile: observation.Name,
+		Since:          since.Format(sonarDateTimeLayout),
+	}
+}
+
+// FetchAllQualityProfileChangelogEvents fetches all the changes of the observed Quality Profile made since the given time, newest first.
+func FetchAllQualityProfileChangelogEvents(qualityProfilesClient QualityProfilesClient, observation *v1alpha1.QualityProfileObservation, since *metav1.Time) ([]QualityProfilesChangelogEvent, error) {
+	var events []QualityProfilesChangelogEvent
+
+	for page := 1; ; page++ {
+		changelog, resp, err := qualityProfilesClient.ChangelogEvents(GenerateQualityProfileChangelogOption(observation, since, page)) //nolint:bodyclose // closed via helpers.CloseBody
+		helpers.CloseBody(resp)
+
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, changelog.Events...)
+
+		if len(changelog.Events) == 0 || int64(len(events)) >= changelog.Paging.Total {
+			return events, nil
+		}
+	}
+}
+
+// parseSonarDateTime parses a date time of the SonarQube API, which may also be in RFC 3339 format.
+func parseSonarDateTime(value string) (time.Time, error) {
+	parsed, err := time.Parse(sonarDateTimeLayout, value)
+	if err != nil {
+		return time.Parse(time.RFC3339, value)
+	}
+
+	return parsed, nil
+}
+
+// GenerateQualityProfileExternalChange generates the external change recorded by a changelog event.
+// The severity, the prioritized flag and the rule parameters are recorded as key=value, the impacts and the clean code attribute as key=old->new.
+func GenerateQualityProfileExternalChange(event QualityProfilesChangelogEvent) v1alpha1.QualityProfileExternalChange {
+	change := v1alpha1.QualityProfileExternalChange{
+		Author: cmp.Or(event.AuthorLogin, event.AuthorName),
+		Action: event.Action,
+		Rule:   event.RuleKey,
+	}
+
+	date, err := parseSonarDateTime(event.Date)
+	if err == nil {
+		change.Date = &metav1.Time{Time: date}
+	}
+
+	params := maps.Clone(event.Params)
+
+	for _, key := range []string{"severity", "prioritizedRule"} {
+		if value := changelogParamValue(params[key]); value != "" {
+			change.Params = append(change.Params, key+"="+value)
+		}
+
+		delete(params, key)
+	}
+
+	var impacts []sonar.ImpactChange
+	if json.Unmarshal(params["impactChanges"], &impacts) == nil {
+		for _, impact := range impacts {
+			change.Params = append(change.Params, fmt.Sprintf("impact.%s=%s->%s", impact.SoftwareQuality, impact.OldSeverity, impact.NewSeverity))
+		}
+	}
+
+	oldAttribute, newAttribute := changelogParamValue(params["oldCleanCodeAttribute"]), changelogParamValue(params["newCleanCodeAttribute"])
+	if oldAttribute != "" || newAttribute != "" {
+		change.Params = append(change.Params, fmt.Sprintf("cleanCodeAttribute=%s->%s", oldAttribute, newAttribute))
+	}
+
+	for _, key := range []string{"impactChanges", "oldCleanCodeAttribute", "newCleanCodeAttribute", "oldCleanCodeAttributeCategory", "newCleanCodeAttributeCategory", "sonarQubeVersion"} {
+		delete(params, key)
+	}
+
+	// The remaining parameters are the parameters of the rule
+	for _, key := range slices.Sorted(maps.Keys(params)) {
+		change.Params = append(change.Params, key+"="+changelogParamValue(params[key]))
+	}
+
+	return change
+}
+
+// changelogParamValue returns the value of a parameter of a changelog event, unquoted when it is a string.
+func changelogParamValue(raw json.RawMessage) string {
+	var value string
+	if json.Unmarshal(raw, &value) == nil {
+		return value
+	}
+
+	return string(raw)
+}
+
+// DescribeQualityProfileExternalChange describes an external change in a sentence, e.g. for an event.
+func DescribeQualityProfileExternalChange(change v1alpha1.QualityProfileExternalChange) string {
+	description := fmt.Sprintf("%s %s rule %s outside of the resource", cmp.Or(change.Author, "an unknown user"), change.Action, change.Rule)
+
+	if len(change.Params) > 0 {
+		description += " with " + strings.Join(change.Params, ", ")
+	}
+
+	return description
+}
+
+// RecordExternalChanges adds the external changes, newest first, to the most recent external changes of the observation.
+func RecordExternalChanges(observation *v1alpha1.QualityProfileObservation, changes []v1alpha1.QualityProfileExternalChange) {
+	recorded := slices.Concat(changes, observation.ExternalChanges)
+	if len(recorded) > MaxQualityProfileExternalChanges {
+		recorded = recorded[:MaxQualityProfileExternalChanges]
+	}
+
+	observation.ExternalChanges = recorded
+}
+
+// NextChangelogSince returns the time from which the changelog is looked up once the given changes are recorded,
+// just after the newest of them so that they are not recorded again, or nil if none of them is dated.
+func NextChangelogSince(changes []v1alpha1.QualityProfileExternalChange) *metav1.Time {
+	var next *metav1.Time
+
+	for _, change := range changes {
+		if change.Date != nil && (next == nil || change.Date.After(next.Time)) {
+			next = change.Date
+		}
+	}
+
+	if next == nil {
+		return nil
+	}
+
+	return ChangelogSinceAfter(next.Time)
+}
+
+// ChangelogSinceAfter returns the time from which the changelog is looked up for the changes made after the given time.
+// The changelog is looked up from the given second included, so it is the next second.
+func ChangelogSinceAfter(t time.Time) *metav1.Time {
+	return &metav1.Time{Time: t.Truncate(time.Second).Add(time.Second)}
+}
+
+// MaxQualityProfileComparisonKeys is the number of rule keys kept in each list of the comparison of a Quality Profile.
+const MaxQualityProfileComparisonKeys = 200
+
+// GenerateSearchReferenceQualityProfileOption generates SonarQube QualityprofilesSearchOption to find the reference Quality Profile
+// named by the comparison target among the Quality Profiles of the given language.
+func GenerateSearchReferenceQualityProfileOption(target *v1alpha1.QualityProfileComparisonTarget, language string) *sonar.QualityprofilesSearchOption {
+	return &sonar.QualityprofilesSearchOption{
+		Language:       language,
+		QualityProfile: ptr.Deref(target.Name, ""),
+	}
+}
+
+// FindReferenceQualityProfile returns the reference Quality Profile of the search result named by the comparison target
+// in the given language, or nil if there is none.
+func FindReferenceQualityProfile(search *sonar.QualityprofilesSearch, target *v1alpha1.QualityProfileComparisonTarget, language string) *sonar.QualityProfile {
+	return findQualityProfile(search, ptr.Deref(target.Name, ""), language)
+}
+
+// GenerateCompareQualityProfileOption generates SonarQube QualityprofilesCompareOption to compare the Quality Profile, on the left,
+// with the reference Quality Profile, on the right.
+func GenerateCompareQualityProfileOption(key, referenceKey string) *sonar.QualityprofilesCompareOption {
+	return &sonar.QualityprofilesCompareOption{
+		LeftKey:  key,
+		RightKey: referenceKey,
+	}
+}
+
+// GenerateQualityProfileComparisonObservation generates QualityProfileComparisonObservation from SonarQube QualityprofilesCompare,
+// the compared Quality Profile being on the left and the reference Quality Profile on the right.
+func GenerateQualityProfileComparisonObservation(comparison *sonar.QualityprofilesCompare) *v1alpha1.QualityProfileComparisonObservation {
+	onlyInProfile := make([]string, 0, len(comparison.InLeft))
+	for _, rule := range comparison.InLeft {
+		onlyInProfile = append(onlyInProfile, rule.Key)
+	}
+
+	onlyInReference := make([]string, 0, len(comparison.InRight))
+	for _, rule := range comparison.InRight {
+		onlyInReference = append(onlyInReference, rule.Key)
+	}
+
+	modified := make([]string, 0, len(comparison.Modified))
+	for _, rule := range comparison.Modified {
+		modified = append(modified, rule.Key)
+	}
+
+	return &v1alpha1.QualityProfileComparisonObservation{
+		Key:                  comparison.Right.Key,
+		Name:                 comparison.Right.Name,
+		OnlyInProfileCount:   int64(len(onlyInProfile)),
+		OnlyInProfile:        boundComparisonKeys(onlyInProfile),
+		OnlyInReferenceCount: int64(len(onlyInReference)),
+		OnlyInReference:      boundComparisonKeys(onlyInReference),
+		ModifiedCount:        int64(len(modified)),
+		Modified:             boundComparisonKeys(modified),
+	}
+}
+
+// boundComparisonKeys sorts the rule keys and keeps at most MaxQualityProfileComparisonKeys of them, nil if there is none.
+func boundComparisonKeys(keys []string) []string {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	slices.Sort(keys)
+
+	return keys[:min(len(keys), MaxQualityProfileComparisonKeys)]
+}
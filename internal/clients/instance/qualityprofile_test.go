@@ -17,13 +17,24 @@ limitations under the License.
 package instance
 
 import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/boxboxjason/sonarqube-client-go/sonar"
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
 	"github.com/crossplane/provider-sonarqube/apis/instance/v1alpha1"
+	"github.com/crossplane/provider-sonarqube/internal/clients/common"
 )
 
 func TestGenerateCreateQualityProfileOption(t *testing.T) {
@@ -72,11 +83,12 @@ func TestGenerateDeleteQualityProfileOption(t *testing.T) {
 	t.Parallel()
 
 	tests := map[string]struct {
-		params v1alpha1.QualityProfileParameters
-		want   *sonar.QualityprofilesDeleteOption
+		profile sonar.ShownProfile
+		want    *sonar.QualityprofilesDeleteOption
 	}{
 		"BasicDelete": {
-			params: v1alpha1.QualityProfileParameters{
+			profile: sonar.ShownProfile{
+				Key:      "AU-TpxcA-iU5OvuD2FLz",
 				Name:     "my-profile",
 				Language: "java",
 			},
@@ -91,7 +103,7 @@ func TestGenerateDeleteQualityProfileOption(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			got := GenerateDeleteQualityProfileOption(tc.params)
+			got := GenerateDeleteQualityProfileOption(tc.profile)
 			if diff := cmp.Diff(tc.want, got); diff != "" {
 				t.Errorf("GenerateDeleteQualityProfileOption() mismatch (-want +got):\n%s", diff)
 			}
@@ -99,6 +111,64 @@ func TestGenerateDeleteQualityProfileOption(t *testing.T) {
 	}
 }
 
+func TestGenerateSearchQualityProfileOption(t *testing.T) {
+	t.Parallel()
+
+	got := GenerateSearchQualityProfileOption(v1alpha1.QualityProfileParameters{Name: "my-profile", Language: "java"})
+	want := &sonar.QualityprofilesSearchOption{QualityProfile: "my-profile", Language: "java"}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateSearchQualityProfileOption() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFindQualityProfileToAdopt(t *testing.T) {
+	t.Parallel()
+
+	params := v1alpha1.QualityProfileParameters{Name: "my-profile", Language: "java"}
+
+	tests := map[string]struct {
+		search *sonar.QualityprofilesSearch
+		want   *sonar.QualityProfile
+	}{
+		"NilSearch": {
+			search: nil,
+			want:   nil,
+		},
+		"NoMatchingProfile": {
+			search: &sonar.QualityprofilesSearch{Profiles: []sonar.QualityProfile{
+				{Key: "AU-1", Name: "my-profile", Language: "go"},
+				{Key: "AU-2", Name: "other-profile", Language: "java"},
+			}},
+			want: nil,
+		},
+		"MatchingProfile": {
+			search: &sonar.QualityprofilesSearch{Profiles: []sonar.QualityProfile{
+				{Key: "AU-1", Name: "my-profile", Language: "go"},
+				{Key: "AU-2", Name: "my-profile", Language: "java"},
+			}},
+			want: &sonar.QualityProfile{Key: "AU-2", Name: "my-profile", Language: "java"},
+		},
+		"MatchingBuiltInProfile": {
+			search: &sonar.QualityprofilesSearch{Profiles: []sonar.QualityProfile{
+				{Key: "AU-1", Name: "my-profile", Language: "java", IsBuiltIn: true},
+			}},
+			want: &sonar.QualityProfile{Key: "AU-1", Name: "my-profile", Language: "java", IsBuiltIn: true},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := FindQualityProfileToAdopt(tc.search, params)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FindQualityProfileToAdopt() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestGenerateRenameQualityProfileOption(t *testing.T) {
 	t.Parallel()
 
@@ -887,7 +957,7 @@ func TestLateInitializeQualityProfile(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			LateInitializeQualityProfile(tc.spec, tc.observation, nil)
+			LateInitializeQualityProfile(tc.spec, tc.observation, nil, common.Capabilities{})
 
 			if tc.spec != nil {
 				if diff := cmp.Diff(tc.wantDefault, tc.spec.Default); diff != "" {
@@ -897,3 +967,1078 @@ func TestLateInitializeQualityProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckQualityProfileCapabilities(t *testing.T) {
+	t.Parallel()
+
+	lts := common.Capabilities{Version: &common.ServerVersion{Major: 9, Minor: 9}, Edition: common.EditionCommunity}
+
+	spec := &v1alpha1.QualityProfileParameters{
+		Rules: []v1alpha1.QualityProfileRuleParameters{
+			{Rule: "java:S100", Impacts: &map[string]string{"MAINTAINABILITY": "HIGH"}},
+			{Rule: "java:S101", Prioritized: ptr.To(true)},
+			{Rule: "java:S102", Prioritized: ptr.To(false), Severity: ptr.To("MAJOR")},
+		},
+	}
+
+	tests := map[string]struct {
+		capabilities common.Capabilities
+		want         []string
+	}{
+		"Unknown": {
+			capabilities: common.Capabilities{},
+			want:         []string{},
+		},
+		"Recent": {
+			capabilities: common.Capabilities{Version: &common.ServerVersion{Major: 2025, Minor: 1}},
+			want:         []string{},
+		},
+		"LTS": {
+			capabilities: lts,
+			want: []string{
+				"java:S100: using rule impacts requires SonarQube 10.8.0 or later, the SonarQube instance runs version 9.9.0 community edition",
+				"java:S101: using prioritized rules requires SonarQube 10.6.0 or later, the SonarQube instance runs version 9.9.0 community edition",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tc.want, CheckQualityProfileCapabilities(spec, tc.capabilities)); diff != "" {
+				t.Errorf("CheckQualityProfileCapabilities() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeQualityProfileRulesCapabilities(t *testing.T) {
+	t.Parallel()
+
+	newAssociations := func() map[string]QualityProfileRuleAssociation {
+		return map[string]QualityProfileRuleAssociation{
+			"java:S100": {
+				Spec: &v1alpha1.QualityProfileRuleParameters{Rule: "java:S100"},
+				Observation: &v1alpha1.QualityProfileRuleObservation{
+					Key:      "java:S100",
+					Severity: "MAJOR",
+					Impacts:  []v1alpha1.QualityProfileRuleImpact{{SoftwareQuality: "MAINTAINABILITY", Severity: "MEDIUM"}},
+				},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		capabilities common.Capabilities
+		want         v1alpha1.QualityProfileRuleParameters
+	}{
+		"Supported": {
+			capabilities: common.Capabilities{Version: &common.ServerVersion{Major: 2025, Minor: 1}},
+			want: v1alpha1.QualityProfileRuleParameters{
+				Rule:        "java:S100",
+				Severity:    ptr.To("MAJOR"),
+				Prioritized: ptr.To(false),
+				Impacts:     &map[string]string{"MAINTAINABILITY": "MEDIUM"},
+			},
+		},
+		"ReportedButNotSettable": {
+			capabilities: common.Capabilities{Version: &common.ServerVersion{Major: 10, Minor: 4}},
+			want: v1alpha1.QualityProfileRuleParameters{
+				Rule:     "java:S100",
+				Severity: ptr.To("MAJOR"),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			associations := newAssociations()
+			LateInitializeQualityProfileRules(associations, tc.capabilities)
+
+			if diff := cmp.Diff(tc.want, *associations["java:S100"].Spec); diff != "" {
+				t.Errorf("LateInitializeQualityProfileRules() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGroupQualityProfileRulesForBulkActivation(t *testing.T) {
+	t.Parallel()
+
+	rule := func(key string) *v1alpha1.QualityProfileRuleParameters {
+		return &v1alpha1.QualityProfileRuleParameters{Rule: key, Severity: ptr.To("MAJOR")}
+	}
+
+	bugs := v1alpha1.QualityProfileRuleSelector{Name: "bugs", Types: []string{"BUG"}, Severity: ptr.To("MAJOR")}
+	security := v1alpha1.QualityProfileRuleSelector{Name: "security", Repositories: []string{"javasecurity"}}
+
+	selection := QualityProfileRuleSelection{
+		Selectors: map[string]string{
+			"java:S1": "bugs", "java:S2": "bugs", "java:S3": "bugs",
+			"javasecurity:S4": "security", "javasecurity:S5": "security", "javasecurity:S6": "security",
+			"java:S7": "patterns", "java:S8": "patterns", "java:S9": "patterns",
+		},
+		BulkSelectors: []v1alpha1.QualityProfileRuleSelector{bugs, security},
+	}
+
+	tests := map[string]struct {
+		rules          []*v1alpha1.QualityProfileRuleParameters
+		wantGroups     []QualityProfileRuleActivationGroup
+		wantIndividual []*v1alpha1.QualityProfileRuleParameters
+	}{
+		"GroupedBySelector": {
+			rules: []*v1alpha1.QualityProfileRuleParameters{
+				rule("javasecurity:S6"), rule("java:S3"), rule("java:S1"), rule("javasecurity:S4"), rule("java:S2"), rule("javasecurity:S5"),
+			},
+			wantGroups: []QualityProfileRuleActivationGroup{
+				{Selector: bugs, Rules: []*v1alpha1.QualityProfileRuleParameters{rule("java:S1"), rule("java:S2"), rule("java:S3")}},
+				{Selector: security, Rules: []*v1alpha1.QualityProfileRuleParameters{rule("javasecurity:S4"), rule("javasecurity:S5"), rule("javasecurity:S6")}},
+			},
+		},
+		"SmallGroupsAreIndividual": {
+			rules:          []*v1alpha1.QualityProfileRuleParameters{rule("java:S2"), rule("java:S1"), rule("javasecurity:S4")},
+			wantGroups:     []QualityProfileRuleActivationGroup{},
+			wantIndividual: []*v1alpha1.QualityProfileRuleParameters{rule("java:S1"), rule("java:S2"), rule("javasecurity:S4")},
+		},
+		"SpecRulesAndOtherSelectorsAreIndividual": {
+			rules: []*v1alpha1.QualityProfileRuleParameters{
+				rule("java:S1"), rule("java:S2"), rule("java:S3"),
+				rule("java:S9"), rule("java:S8"), rule("java:S7"), rule("java:S10"),
+			},
+			wantGroups: []QualityProfileRuleActivationGroup{
+				{Selector: bugs, Rules: []*v1alpha1.QualityProfileRuleParameters{rule("java:S1"), rule("java:S2"), rule("java:S3")}},
+			},
+			wantIndividual: []*v1alpha1.QualityProfileRuleParameters{rule("java:S10"), rule("java:S7"), rule("java:S8"), rule("java:S9")},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			groups, individual := GroupQualityProfileRulesForBulkActivation(tc.rules, selection)
+			if diff := cmp.Diff(tc.wantGroups, groups); diff != "" {
+				t.Errorf("GroupQualityProfileRulesForBulkActivation() groups mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantIndividual, individual); diff != "" {
+				t.Errorf("GroupQualityProfileRulesForBulkActivation() individual mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGroupQualityProfileRulesForBulkDeactivation(t *testing.T) {
+	t.Parallel()
+
+	active := []v1alpha1.QualityProfileRuleObservation{
+		{Key: "java:S1"}, {Key: "java:S2"}, {Key: "java:S3"},
+		{Key: "javasecurity:S4"}, {Key: "javasecurity:S5"}, {Key: "javasecurity:S6"}, {Key: "javasecurity:S7"},
+		{Key: "common-java:S8"},
+	}
+
+	tests := map[string]struct {
+		rules          []*v1alpha1.QualityProfileRuleObservation
+		wantGroups     []QualityProfileRuleDeactivationGroup
+		wantIndividual []*v1alpha1.QualityProfileRuleObservation
+	}{
+		"WholeRepository": {
+			rules: []*v1alpha1.QualityProfileRuleObservation{&active[2], &active[0], &active[1]},
+			wantGroups: []QualityProfileRuleDeactivationGroup{
+				{Repository: "java", Rules: []*v1alpha1.QualityProfileRuleObservation{&active[0], &active[1], &active[2]}},
+			},
+		},
+		"RepositoryWithKeptRules": {
+			rules:          []*v1alpha1.QualityProfileRuleObservation{&active[3], &active[4], &active[5]},
+			wantIndividual: []*v1alpha1.QualityProfileRuleObservation{&active[3], &active[4], &active[5]},
+		},
+		"SmallRepository": {
+			rules:          []*v1alpha1.QualityProfileRuleObservation{&active[7]},
+			wantIndividual: []*v1alpha1.QualityProfileRuleObservation{&active[7]},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			groups, individual := GroupQualityProfileRulesForBulkDeactivation(tc.rules, active)
+			if diff := cmp.Diff(tc.wantGroups, groups); diff != "" {
+				t.Errorf("GroupQualityProfileRulesForBulkDeactivation() groups mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantIndividual, individual); diff != "" {
+				t.Errorf("GroupQualityProfileRulesForBulkDeactivation() individual mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateQualityProfileBulkRulesOptions(t *testing.T) {
+	t.Parallel()
+
+	activate := GenerateQualityProfileActivateRulesOption("AU-TpxcA-iU5OvuD2FLz", "java", QualityProfileRuleActivationGroup{
+		Selector: v1alpha1.QualityProfileRuleSelector{
+			Name:         "security",
+			Repositories: []string{"java"},
+			Tags:         []string{"owasp"},
+			Cwe:          []string{"89"},
+			Severity:     ptr.To("MAJOR"),
+			Prioritized:  ptr.To(true),
+		},
+	})
+	wantActivate := &sonar.QualityprofilesActivateRulesOption{
+		TargetKey:       "AU-TpxcA-iU5OvuD2FLz",
+		Languages:       []string{"java"},
+		Repositories:    []string{"java"},
+		Tags:            []string{"owasp"},
+		Cwe:             []string{"89"},
+		TargetSeverity:  "MAJOR",
+		PrioritizedRule: true,
+	}
+
+	if diff := cmp.Diff(wantActivate, activate); diff != "" {
+		t.Errorf("GenerateQualityProfileActivateRulesOption() mismatch (-want +got):\n%s", diff)
+	}
+
+	deactivate := GenerateQualityProfileDeactivateRulesOption("AU-TpxcA-iU5OvuD2FLz", "java")
+	wantDeactivate := &sonar.QualityprofilesDeactivateRulesOption{
+		TargetKey:    "AU-TpxcA-iU5OvuD2FLz",
+		Qprofile:     "AU-TpxcA-iU5OvuD2FLz",
+		Activation:   true,
+		Repositories: []string{"java"},
+	}
+
+	if diff := cmp.Diff(wantDeactivate, deactivate); diff != "" {
+		t.Errorf("GenerateQualityProfileDeactivateRulesOption() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestIsAdditiveRulesPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		policy *string
+		want   bool
+	}{
+		"Default":       {policy: nil, want: false},
+		"Authoritative": {policy: ptr.To(QualityProfileRulesPolicyAuthoritative), want: false},
+		"Additive":      {policy: ptr.To(QualityProfileRulesPolicyAdditive), want: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := IsAdditiveRulesPolicy(v1alpha1.QualityProfileParameters{RulesPolicy: tc.policy})
+			if got != tc.want {
+				t.Errorf("IsAdditiveRulesPolicy() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestManagedQualityProfileRules(t *testing.T) {
+	t.Parallel()
+
+	unmanaged := &v1alpha1.QualityProfileRuleObservation{Key: "java:S1"}
+	activated := &v1alpha1.QualityProfileRuleObservation{Key: "java:S2"}
+	desired := &v1alpha1.QualityProfileRuleParameters{Rule: "java:S3"}
+
+	associations := map[string]QualityProfileRuleAssociation{
+		"java:S1": {Observation: unmanaged},
+		"java:S2": {Observation: activated},
+		"java:S3": {Spec: desired},
+	}
+
+	tests := map[string]struct {
+		policy      *string
+		deactivated []string
+		want        map[string]QualityProfileRuleAssociation
+	}{
+		"Authoritative": {
+			policy: nil,
+			want:   associations,
+		},
+		"Additive": {
+			policy: ptr.To(QualityProfileRulesPolicyAdditive),
+			want: map[string]QualityProfileRuleAssociation{
+				"java:S2": {Observation: activated},
+				"java:S3": {Spec: desired},
+			},
+		},
+		"DeactivatedRulesAreLeftOut": {
+			policy:      nil,
+			deactivated: []string{"java:S1"},
+			want: map[string]QualityProfileRuleAssociation{
+				"java:S2": {Observation: activated},
+				"java:S3": {Spec: desired},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := &v1alpha1.QualityProfileParameters{RulesPolicy: tc.policy, DeactivatedRules: tc.deactivated}
+			observation := &v1alpha1.QualityProfileObservation{ActivatedRules: []string{"java:S2"}}
+
+			got := ManagedQualityProfileRules(spec, observation, associations)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ManagedQualityProfileRules() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestActivatedRulesLedger(t *testing.T) {
+	t.Parallel()
+
+	observation := &v1alpha1.QualityProfileObservation{
+		Rules: []v1alpha1.QualityProfileRuleObservation{{Key: "java:S1"}, {Key: "java:S3"}},
+	}
+
+	RecordActivatedRules(observation, []string{"java:S3", "java:S1", "java:S2", "java:S4", "java:S1"})
+	if diff := cmp.Diff([]string{"java:S1", "java:S2", "java:S3", "java:S4"}, observation.ActivatedRules); diff != "" {
+		t.Errorf("RecordActivatedRules() mismatch (-want +got):\n%s", diff)
+	}
+
+	// java:S2 is neither active nor desired, java:S4 is desired but not active yet
+	PruneActivatedRules(observation, []v1alpha1.QualityProfileRuleParameters{{Rule: "java:S4"}})
+	if diff := cmp.Diff([]string{"java:S1", "java:S3", "java:S4"}, observation.ActivatedRules); diff != "" {
+		t.Errorf("PruneActivatedRules() mismatch (-want +got):\n%s", diff)
+	}
+
+	ForgetActivatedRules(observation, []string{"java:S1", "java:S3", "java:S4"})
+	if observation.ActivatedRules != nil {
+		t.Errorf("ForgetActivatedRules() = %v, want nil", observation.ActivatedRules)
+	}
+}
+
+func TestFindActiveDeactivatedRules(t *testing.T) {
+	t.Parallel()
+
+	spec := &v1alpha1.QualityProfileParameters{DeactivatedRules: []string{"java:S1", "java:S2", "java:S3", "java:S4"}}
+	observation := &v1alpha1.QualityProfileObservation{
+		Rules: []v1alpha1.QualityProfileRuleObservation{
+			{Key: "java:S1", Inheritance: RuleInheritanceNone},
+			{Key: "java:S2", Inheritance: RuleInheritanceInherited},
+			{Key: "java:S3", Inheritance: RuleInheritanceOverrides},
+			{Key: "java:S5", Inheritance: RuleInheritanceNone},
+		},
+		RuleConflicts: []v1alpha1.QualityProfileRuleConflict{{Rule: "java:S2"}, {Rule: "java:S3"}},
+	}
+
+	// java:S2 is kept active by the parent, java:S3 still overrides the parent settings and has to be reset, java:S4 is not active
+	want := []*v1alpha1.QualityProfileRuleObservation{&observation.Rules[0], &observation.Rules[2]}
+	if diff := cmp.Diff(want, FindActiveDeactivatedRules(spec, observation)); diff != "" {
+		t.Errorf("FindActiveDeactivatedRules() mismatch (-want +got):\n%s", diff)
+	}
+
+	if IsQualityProfileUpToDate(spec, observation, map[string]QualityProfileRuleAssociation{}) {
+		t.Error("IsQualityProfileUpToDate() = true with active deactivated rules")
+	}
+}
+
+func TestPruneRuleConflicts(t *testing.T) {
+	t.Parallel()
+
+	spec := &v1alpha1.QualityProfileParameters{DeactivatedRules: []string{"java:S1", "java:S2", "java:S3"}}
+	observation := &v1alpha1.QualityProfileObservation{
+		Rules: []v1alpha1.QualityProfileRuleObservation{
+			{Key: "java:S1", Inheritance: RuleInheritanceInherited},
+			{Key: "java:S2", Inheritance: RuleInheritanceNone},
+			{Key: "java:S4", Inheritance: RuleInheritanceInherited},
+		},
+	}
+
+	// java:S2 is no longer inherited, java:S3 is no longer active and java:S4 is no longer deactivated
+	for _, key := range []string{"java:S4", "java:S3", "java:S1", "java:S2", "java:S1"} {
+		RecordRuleConflict(observation, key, "rule is inherited")
+	}
+
+	if diff := cmp.Diff([]string{"java:S1", "java:S2", "java:S3", "java:S4"}, conflictRules(observation)); diff != "" {
+		t.Errorf("RecordRuleConflict() mismatch (-want +got):\n%s", diff)
+	}
+
+	PruneRuleConflicts(spec, observation)
+
+	if diff := cmp.Diff([]string{"java:S1"}, conflictRules(observation)); diff != "" {
+		t.Errorf("PruneRuleConflicts() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// conflictRules returns the keys of the rules of the conflicts.
+func conflictRules(observation *v1alpha1.QualityProfileObservation) []string {
+	rules := make([]string, 0, len(observation.RuleConflicts))
+	for _, conflict := range observation.RuleConflicts {
+		rules = append(rules, conflict.Rule)
+	}
+
+	return rules
+}
+
+func TestCheckQualityProfileDeactivatedRules(t *testing.T) {
+	t.Parallel()
+
+	spec := &v1alpha1.QualityProfileParameters{
+		Rules:            []v1alpha1.QualityProfileRuleParameters{{Rule: "java:S1"}, {Rule: "java:S2"}},
+		DeactivatedRules: []string{"java:S2", "java:S3"},
+	}
+
+	want := []string{"java:S2: listed in both rules and deactivatedRules"}
+	if diff := cmp.Diff(want, CheckQualityProfileDeactivatedRules(spec)); diff != "" {
+		t.Errorf("CheckQualityProfileDeactivatedRules() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetQualityProfileDeletionBehavior(t *testing.T) {
+	t.Parallel()
+
+	if got := GetQualityProfileDeletionBehavior(v1alpha1.QualityProfileParameters{}); got != QualityProfileDeletionReassign {
+		t.Errorf("GetQualityProfileDeletionBehavior() = %q, want %q", got, QualityProfileDeletionReassign)
+	}
+
+	params := v1alpha1.QualityProfileParameters{DeletionBehavior: ptr.To(QualityProfileDeletionBlock)}
+	if got := GetQualityProfileDeletionBehavior(params); got != QualityProfileDeletionBlock {
+		t.Errorf("GetQualityProfileDeletionBehavior() = %q, want %q", got, QualityProfileDeletionBlock)
+	}
+}
+
+func TestGenerateQualityProfileReparentOption(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		inheritance *sonar.QualityprofilesInheritance
+		want        *sonar.QualityprofilesChangeParentOption
+	}{
+		"AttachedToParent": {
+			inheritance: &sonar.QualityprofilesInheritance{
+				Ancestors: []sonar.InheritanceProfile{{Key: "AU-2", Name: "parent"}, {Key: "AU-3", Name: "grandparent"}},
+			},
+			want: &sonar.QualityprofilesChangeParentOption{Language: "java", QualityProfile: "child", ParentQualityProfile: "parent"},
+		},
+		"DetachedWithoutParent": {
+			inheritance: &sonar.QualityprofilesInheritance{},
+			want:        &sonar.QualityprofilesChangeParentOption{Language: "java", QualityProfile: "child"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := GenerateQualityProfileReparentOption("java", tc.inheritance, "child")
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateQualityProfileReparentOption() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckQualityProfileDeletion(t *testing.T) {
+	t.Parallel()
+
+	projects := []string{"project-a", "project-b"}
+	children := []sonar.InheritanceProfile{{Key: "AU-2", Name: "child"}}
+
+	tests := map[string]struct {
+		behavior string
+		projects []string
+		children []sonar.InheritanceProfile
+		want     []string
+	}{
+		"NotReferenced": {
+			behavior: QualityProfileDeletionBlock,
+			want:     nil,
+		},
+		"BlockedByProjects": {
+			behavior: QualityProfileDeletionBlock,
+			projects: projects,
+			want:     []string{"used by 2 project(s): project-a, project-b"},
+		},
+		"BlockedByProjectsAndChildren": {
+			behavior: QualityProfileDeletionBlock,
+			projects: projects,
+			children: children,
+			want:     []string{"used by 2 project(s): project-a, project-b", "inherited by 1 child Quality Profile(s): child"},
+		},
+		"ReassignedProjects": {
+			behavior: QualityProfileDeletionReassign,
+			projects: projects,
+			want:     nil,
+		},
+		"ReassignBlockedByChildren": {
+			behavior: QualityProfileDeletionReassign,
+			children: children,
+			want:     []string{"inherited by 1 child Quality Profile(s): child"},
+		},
+		"ReparentedChildren": {
+			behavior: QualityProfileDeletionReparent,
+			projects: projects,
+			children: children,
+			want:     nil,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := CheckQualityProfileDeletion(tc.behavior, tc.projects, tc.children)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("CheckQualityProfileDeletion() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRestoreBackup(t *testing.T) {
+	t.Parallel()
+
+	backup := `<?xml version="1.0" encoding="UTF-8"?><profile><name>my-profile</name><language>java</language><rules/></profile>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/qualityprofiles/restore" {
+			t.Errorf("RestoreBackup() request = %s %s, want POST /api/qualityprofiles/restore", r.Method, r.URL.Path)
+		}
+
+		file, _, err := r.FormFile("backup")
+		if err != nil {
+			t.Fatalf("RestoreBackup() request has no backup file: %v", err)
+		}
+
+		content, _ := io.ReadAll(file)
+		if string(content) != backup {
+			t.Errorf("RestoreBackup() uploaded %q, want %q", content, backup)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"profile":{"key":"AU-TpxcA-iU5OvuD2FLz","name":"my-profile","language":"java"},"ruleSuccesses":12,"ruleFailures":1}`)
+	}))
+	defer server.Close()
+
+	sonarClient, err := common.NewClient(common.Config{AuthType: common.PersonalAccessToken, Token: "token", BaseURL: server.URL + "/api/"})
+	if err != nil {
+		t.Fatalf("NewClient() returned unexpected error: %v", err)
+	}
+
+	client := &qualityProfilesClient{QualityprofilesService: sonarClient.Qualityprofiles, client: sonarClient}
+
+	got, _, err := client.RestoreBackup(backup) //nolint:bodyclose // the body is closed by the SonarQube client
+	if err != nil {
+		t.Fatalf("RestoreBackup() returned unexpected error: %v", err)
+	}
+
+	want := &QualityProfilesRestore{
+		Profile:       sonar.QualityProfile{Key: "AU-TpxcA-iU5OvuD2FLz", Name: "my-profile", Language: "java"},
+		RuleSuccesses: 12,
+		RuleFailures:  1,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("RestoreBackup() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseQualityProfileBackup(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		backup  string
+		want    *QualityProfileBackup
+		wantErr bool
+	}{
+		"ValidBackup": {
+			backup: `<?xml version="1.0" encoding="UTF-8"?><profile><name>my-profile</name><language>java</language><rules><rule><repositoryKey>java</repositoryKey><key>S1</key></rule></rules></profile>`,
+			want:   &QualityProfileBackup{XMLName: xml.Name{Local: "profile"}, Name: "my-profile", Language: "java"},
+		},
+		"MissingLanguage": {
+			backup:  `<profile><name>my-profile</name></profile>`,
+			wantErr: true,
+		},
+		"NotAProfile": {
+			backup:  `<rules><name>my-profile</name><language>java</language></rules>`,
+			wantErr: true,
+		},
+		"NotXML": {
+			backup:  `{"name": "my-profile"}`,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseQualityProfileBackup(tc.backup)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseQualityProfileBackup() error = %v, wantErr %v", err, tc.wantErr)
+			}
+
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ParseQualityProfileBackup() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsQualityProfileBackupRestored(t *testing.T) {
+	t.Parallel()
+
+	hash := HashQualityProfileBackup("<profile/>")
+	if hash == HashQualityProfileBackup("<profile></profile>") {
+		t.Fatalf("HashQualityProfileBackup() returned the same hash for different backups")
+	}
+
+	if IsQualityProfileBackupRestored(&v1alpha1.QualityProfileObservation{}, hash) {
+		t.Errorf("IsQualityProfileBackupRestored() = true without any restoration, want false")
+	}
+
+	restored := &v1alpha1.QualityProfileObservation{Restore: &v1alpha1.QualityProfileRestoreObservation{BackupHash: hash}}
+	if !IsQualityProfileBackupRestored(restored, hash) {
+		t.Errorf("IsQualityProfileBackupRestored() = false for the restored backup, want true")
+	}
+
+	if IsQualityProfileBackupRestored(restored, HashQualityProfileBackup("<profile></profile>")) {
+		t.Errorf("IsQualityProfileBackupRestored() = true for a changed backup, want false")
+	}
+}
+
+func TestCheckQualityProfileBackup(t *testing.T) {
+	t.Parallel()
+
+	backup := &QualityProfileBackup{Name: "my-profile", Language: "java"}
+
+	tests := map[string]struct {
+		name     string
+		language string
+		want     []string
+	}{
+		"MatchingProfile": {
+			name:     "my-profile",
+			language: "java",
+			want:     nil,
+		},
+		"NameNotCheckedOnCreation": {
+			name:     "",
+			language: "java",
+			want:     nil,
+		},
+		"OtherLanguage": {
+			name:     "",
+			language: "go",
+			want:     []string{"the backup is for language java, not go"},
+		},
+		"OtherProfile": {
+			name:     "renamed-profile",
+			language: "java",
+			want:     []string{"the backup is for Quality Profile my-profile, not renamed-profile, restoring it would create another Quality Profile"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := CheckQualityProfileBackup(backup, tc.name, tc.language)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("CheckQualityProfileBackup() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetQualityProfileBackupExportKey(t *testing.T) {
+	t.Parallel()
+
+	if got := GetQualityProfileBackupExportKey(&v1alpha1.QualityProfileBackupExport{ConfigMapName: "backups"}); got != "backup.xml" {
+		t.Errorf("GetQualityProfileBackupExportKey() = %q, want %q", got, "backup.xml")
+	}
+
+	export := &v1alpha1.QualityProfileBackupExport{ConfigMapName: "backups", Key: ptr.To("java.xml")}
+	if got := GetQualityProfileBackupExportKey(export); got != "java.xml" {
+		t.Errorf("GetQualityProfileBackupExportKey() = %q, want %q", got, "java.xml")
+	}
+}
+
+func TestGenerateQualityProfileBackupExportRevision(t *testing.T) {
+	t.Parallel()
+
+	export := &v1alpha1.QualityProfileBackupExport{ConfigMapName: "backups"}
+	observation := &v1alpha1.QualityProfileObservation{
+		Key:            "AU-1",
+		Name:           "my-profile",
+		RulesUpdatedAt: ptr.To(metav1.NewTime(time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC))),
+	}
+
+	if got, want := GenerateQualityProfileBackupExportRevision(export, observation), "backups/backup.xml/AU-1/my-profile/2026-03-01T10:30:00Z"; got != want {
+		t.Errorf("GenerateQualityProfileBackupExportRevision() = %q, want %q", got, want)
+	}
+
+	updated := observation.DeepCopy()
+	updated.RulesUpdatedAt = ptr.To(metav1.NewTime(time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC)))
+
+	if GenerateQualityProfileBackupExportRevision(export, updated) == GenerateQualityProfileBackupExportRevision(export, observation) {
+		t.Error("GenerateQualityProfileBackupExportRevision() did not change with the rules of the Quality Profile")
+	}
+}
+
+func TestGenerateQualityProfileChangelogOption(t *testing.T) {
+	t.Parallel()
+
+	since := metav1.NewTime(time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC))
+	got := GenerateQualityProfileChangelogOption(&v1alpha1.QualityProfileObservation{Name: "my-profile", Language: "java"}, &since, 2)
+	want := &sonar.QualityprofilesChangelogOption{
+		PaginationArgs: sonar.PaginationArgs{Page: 2, PageSize: maxChangelogEvents},
+		Language:       "java",
+		QualityProfile: "my-profile",
+		Since:          "2026-03-01T10:30:00+0000",
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateQualityProfileChangelogOption() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFetchAllQualityProfileChangelogEvents(t *testing.T) {
+	t.Parallel()
+
+	pages := map[string]string{
+		"1": `{"paging":{"pageIndex":1,"pageSize":1,"total":2},"events":[{"action":"UPDATED","authorLogin":"jdoe","date":"2026-03-01T11:30:00+0100","ruleKey":"java:S1","params":{"severity":"CRITICAL","max":"10"}}]}`,
+		"2": `{"paging":{"pageIndex":2,"pageSize":1,"total":2},"events":[{"action":"ACTIVATED","authorLogin":"jdoe","date":"2026-03-01T11:00:00+0100","ruleKey":"java:S2"}]}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/qualityprofiles/changelog" {
+			t.Errorf("ChangelogEvents() request = %s %s, want GET /api/qualityprofiles/changelog", r.Method, r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, pages[r.URL.Query().Get("p")])
+	}))
+	defer server.Close()
+
+	sonarClient, err := common.NewClient(common.Config{AuthType: common.PersonalAccessToken, Token: "token", BaseURL: server.URL + "/api/"})
+	if err != nil {
+		t.Fatalf("NewClient() returned unexpected error: %v", err)
+	}
+
+	client := &qualityProfilesClient{QualityprofilesService: sonarClient.Qualityprofiles, client: sonarClient}
+	since := metav1.NewTime(time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+
+	got, err := FetchAllQualityProfileChangelogEvents(client, &v1alpha1.QualityProfileObservation{Name: "my-profile", Language: "java"}, &since)
+	if err != nil {
+		t.Fatalf("FetchAllQualityProfileChangelogEvents() returned unexpected error: %v", err)
+	}
+
+	want := []QualityProfilesChangelogEvent{
+		{
+			Action:      "UPDATED",
+			AuthorLogin: "jdoe",
+			Date:        "2026-03-01T11:30:00+0100",
+			RuleKey:     "java:S1",
+			Params:      map[string]json.RawMessage{"severity": json.RawMessage(`"CRITICAL"`), "max": json.RawMessage(`"10"`)},
+		},
+		{Action: "ACTIVATED", AuthorLogin: "jdoe", Date: "2026-03-01T11:00:00+0100", RuleKey: "java:S2"},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FetchAllQualityProfileChangelogEvents() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerateQualityProfileExternalChange(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		event QualityProfilesChangelogEvent
+		want  v1alpha1.QualityProfileExternalChange
+	}{
+		"ActivatedRule": {
+			event: QualityProfilesChangelogEvent{
+				Action:      "ACTIVATED",
+				AuthorLogin: "jdoe",
+				AuthorName:  "John Doe",
+				Date:        "2026-03-01T11:30:00+0100",
+				RuleKey:     "java:S1",
+				Params: map[string]json.RawMessage{
+					"prioritizedRule": json.RawMessage(`"true"`),
+					"impactChanges":   json.RawMessage(`[{"softwareQuality":"MAINTAINABILITY","oldSeverity":"LOW","newSeverity":"HIGH"}]`),
+				},
+			},
+			want: v1alpha1.QualityProfileExternalChange{
+				Date:   &metav1.Time{Time: time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)},
+				Author: "jdoe",
+				Action: "ACTIVATED",
+				Rule:   "java:S1",
+				Params: []string{"prioritizedRule=true", "impact.MAINTAINABILITY=LOW->HIGH"},
+			},
+		},
+		"UpdatedRuleSeverityAndParams": {
+			event: QualityProfilesChangelogEvent{
+				Action:      "UPDATED",
+				AuthorLogin: "jdoe",
+				Date:        "2026-03-01T10:30:00Z",
+				RuleKey:     "java:S107",
+				Params: map[string]json.RawMessage{
+					"severity":         json.RawMessage(`"CRITICAL"`),
+					"max":              json.RawMessage(`"10"`),
+					"format":           json.RawMessage(`"^[a-z]+$"`),
+					"sonarQubeVersion": json.RawMessage(`"2025.1"`),
+				},
+			},
+			want: v1alpha1.QualityProfileExternalChange{
+				Date:   &metav1.Time{Time: time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)},
+				Author: "jdoe",
+				Action: "UPDATED",
+				Rule:   "java:S107",
+				Params: []string{"severity=CRITICAL", "format=^[a-z]+$", "max=10"},
+			},
+		},
+		"DeactivatedRuleWithoutLogin": {
+			event: QualityProfilesChangelogEvent{
+				Action:     "DEACTIVATED",
+				AuthorName: "John Doe",
+				Date:       "2026-03-01T10:30:00Z",
+				RuleKey:    "java:S2",
+			},
+			want: v1alpha1.QualityProfileExternalChange{
+				Date:   &metav1.Time{Time: time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)},
+				Author: "John Doe",
+				Action: "DEACTIVATED",
+				Rule:   "java:S2",
+			},
+		},
+		"UpdatedRuleWithInvalidDate": {
+			event: QualityProfilesChangelogEvent{
+				Action:  "UPDATED",
+				Date:    "yesterday",
+				RuleKey: "java:S3",
+				Params: map[string]json.RawMessage{
+					"oldCleanCodeAttribute":         json.RawMessage(`"CLEAR"`),
+					"newCleanCodeAttribute":         json.RawMessage(`"LOGICAL"`),
+					"oldCleanCodeAttributeCategory": json.RawMessage(`"INTENTIONAL"`),
+					"newCleanCodeAttributeCategory": json.RawMessage(`"INTENTIONAL"`),
+				},
+			},
+			want: v1alpha1.QualityProfileExternalChange{
+				Action: "UPDATED",
+				Rule:   "java:S3",
+				Params: []string{"cleanCodeAttribute=CLEAR->LOGICAL"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := GenerateQualityProfileExternalChange(tc.event)
+			if diff := cmp.Diff(tc.want, got, cmp.Comparer(func(a, b metav1.Time) bool { return a.Equal(&b) })); diff != "" {
+				t.Errorf("GenerateQualityProfileExternalChange() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDescribeQualityProfileExternalChange(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		change v1alpha1.QualityProfileExternalChange
+		want   string
+	}{
+		"WithParams": {
+			change: v1alpha1.QualityProfileExternalChange{Author: "jdoe", Action: "ACTIVATED", Rule: "java:S1", Params: []string{"prioritizedRule=true"}},
+			want:   "jdoe ACTIVATED rule java:S1 outside of the resource with prioritizedRule=true",
+		},
+		"UnknownAuthor": {
+			change: v1alpha1.QualityProfileExternalChange{Action: "DEACTIVATED", Rule: "java:S2"},
+			want:   "an unknown user DEACTIVATED rule java:S2 outside of the resource",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := DescribeQualityProfileExternalChange(tc.change); got != tc.want {
+				t.Errorf("DescribeQualityProfileExternalChange() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRecordExternalChanges(t *testing.T) {
+	t.Parallel()
+
+	changes := func(first, count int) []v1alpha1.QualityProfileExternalChange {
+		result := make([]v1alpha1.QualityProfileExternalChange, 0, count)
+		for i := range count {
+			result = append(result, v1alpha1.QualityProfileExternalChange{Action: "UPDATED", Rule: "java:S" + strconv.Itoa(first+i)})
+		}
+
+		return result
+	}
+
+	observation := &v1alpha1.QualityProfileObservation{ExternalChanges: changes(100, 2)}
+
+	RecordExternalChanges(observation, changes(1, 3))
+	if diff := cmp.Diff(slices.Concat(changes(1, 3), changes(100, 2)), observation.ExternalChanges); diff != "" {
+		t.Errorf("RecordExternalChanges() mismatch (-want +got):\n%s", diff)
+	}
+
+	RecordExternalChanges(observation, changes(200, MaxQualityProfileExternalChanges-1))
+	if diff := cmp.Diff(slices.Concat(changes(200, MaxQualityProfileExternalChanges-1), changes(1, 1)), observation.ExternalChanges); diff != "" {
+		t.Errorf("RecordExternalChanges() did not keep the newest changes (-want +got):\n%s", diff)
+	}
+}
+
+func TestNextChangelogSince(t *testing.T) {
+	t.Parallel()
+
+	older := metav1.NewTime(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	newer := metav1.NewTime(time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC))
+
+	if got := NextChangelogSince(nil); got != nil {
+		t.Errorf("NextChangelogSince() = %v without changes, want nil", got)
+	}
+
+	if got := NextChangelogSince([]v1alpha1.QualityProfileExternalChange{{Action: "UPDATED"}}); got != nil {
+		t.Errorf("NextChangelogSince() = %v without dated changes, want nil", got)
+	}
+
+	got := NextChangelogSince([]v1alpha1.QualityProfileExternalChange{{Date: &older}, {Date: &newer}, {}})
+	if want := newer.Add(time.Second); got == nil || !got.Time.Equal(want) {
+		t.Errorf("NextChangelogSince() = %v, want %v", got, want)
+	}
+}
+
+func TestChangelogSinceAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 1, 10, 30, 0, 500_000_000, time.UTC)
+
+	if got, want := ChangelogSinceAfter(now), time.Date(2026, 3, 1, 10, 30, 1, 0, time.UTC); !got.Time.Equal(want) {
+		t.Errorf("ChangelogSinceAfter() = %v, want %v", got, want)
+	}
+}
+
+func TestFindReferenceQualityProfile(t *testing.T) {
+	t.Parallel()
+
+	target := &v1alpha1.QualityProfileComparisonTarget{Name: ptr.To("Sonar way")}
+
+	tests := map[string]struct {
+		search *sonar.QualityprofilesSearch
+		want   *sonar.QualityProfile
+	}{
+		"NilSearch": {
+			search: nil,
+			want:   nil,
+		},
+		"NoMatchingProfile": {
+			search: &sonar.QualityprofilesSearch{Profiles: []sonar.QualityProfile{
+				{Key: "AU-1", Name: "Sonar way", Language: "go"},
+			}},
+			want: nil,
+		},
+		"MatchingBuiltInProfile": {
+			search: &sonar.QualityprofilesSearch{Profiles: []sonar.QualityProfile{
+				{Key: "AU-1", Name: "Sonar way", Language: "go"},
+				{Key: "AU-2", Name: "Sonar way", Language: "java", IsBuiltIn: true},
+			}},
+			want: &sonar.QualityProfile{Key: "AU-2", Name: "Sonar way", Language: "java", IsBuiltIn: true},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := FindReferenceQualityProfile(tc.search, target, "java")
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FindReferenceQualityProfile() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateQualityProfileComparisonObservation(t *testing.T) {
+	t.Parallel()
+
+	manyRules := make([]sonar.CompareRule, 0, MaxQualityProfileComparisonKeys+1)
+	manyKeys := make([]string, 0, MaxQualityProfileComparisonKeys)
+
+	for i := range MaxQualityProfileComparisonKeys + 1 {
+		key := fmt.Sprintf("java:S%04d", i)
+		manyRules = append(manyRules, sonar.CompareRule{Key: key})
+
+		if i < MaxQualityProfileComparisonKeys {
+			manyKeys = append(manyKeys, key)
+		}
+	}
+
+	slices.Reverse(manyRules)
+
+	tests := map[string]struct {
+		comparison *sonar.QualityprofilesCompare
+		want       *v1alpha1.QualityProfileComparisonObservation
+	}{
+		"SameProfiles": {
+			comparison: &sonar.QualityprofilesCompare{
+				Left:  sonar.CompareProfile{Key: "AU-1", Name: "my-profile"},
+				Right: sonar.CompareProfile{Key: "AU-2", Name: "Sonar way"},
+				Same:  []sonar.CompareRule{{Key: "java:S100"}},
+			},
+			want: &v1alpha1.QualityProfileComparisonObservation{Key: "AU-2", Name: "Sonar way"},
+		},
+		"DifferentProfiles": {
+			comparison: &sonar.QualityprofilesCompare{
+				Left:     sonar.CompareProfile{Key: "AU-1", Name: "my-profile"},
+				Right:    sonar.CompareProfile{Key: "AU-2", Name: "Sonar way"},
+				InLeft:   []sonar.CompareRule{{Key: "java:S300"}, {Key: "java:S200"}},
+				InRight:  []sonar.CompareRule{{Key: "java:S400"}},
+				Modified: []sonar.CompareModifiedRule{{Key: "java:S500", Left: sonar.RuleSetting{Params: map[string]string{"max": "10"}}}},
+				Same:     []sonar.CompareRule{{Key: "java:S100"}},
+			},
+			want: &v1alpha1.QualityProfileComparisonObservation{
+				Key:                  "AU-2",
+				Name:                 "Sonar way",
+				OnlyInProfileCount:   2,
+				OnlyInProfile:        []string{"java:S200", "java:S300"},
+				OnlyInReferenceCount: 1,
+				OnlyInReference:      []string{"java:S400"},
+				ModifiedCount:        1,
+				Modified:             []string{"java:S500"},
+			},
+		},
+		"KeysBounded": {
+			comparison: &sonar.QualityprofilesCompare{
+				Right:   sonar.CompareProfile{Key: "AU-2", Name: "Sonar way"},
+				InRight: manyRules,
+			},
+			want: &v1alpha1.QualityProfileComparisonObservation{
+				Key:                  "AU-2",
+				Name:                 "Sonar way",
+				OnlyInReferenceCount: MaxQualityProfileComparisonKeys + 1,
+				OnlyInReference:      manyKeys,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := GenerateQualityProfileComparisonObservation(tc.comparison)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateQualityProfileComparisonObservation() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,6 +18,11 @@ package instance
 
 import (
 	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/boxboxjason/sonarqube-client-go/sonar"
 	"github.com/crossplane/provider-sonarqube/apis/instance/v1alpha1"
@@ -25,6 +30,8 @@ import (
 	"github.com/crossplane/provider-sonarqube/internal/helpers"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 const (
@@ -46,10 +53,13 @@ type RulesClient interface {
 }
 
 // NewRulesClient creates a new RulesClient with the provided SonarQube client configuration.
-func NewRulesClient(clientConfig common.Config) RulesClient {
-	newClient := common.NewClient(clientConfig)
+func NewRulesClient(clientConfig common.Config) (RulesClient, error) {
+	newClient, err := common.GetClient(clientConfig)
+	if err != nil {
+		return nil, err
+	}
 
-	return newClient.Rules
+	return newClient.Rules, nil
 }
 
 // GenerateQualityProfileRulesSearchOption generates SonarQube RulesSearchOption for a given quality profile key
@@ -152,6 +162,7 @@ type ruleActiveSettings struct {
 	Params      *map[string]string
 	Impacts     []v1alpha1.QualityProfileRuleImpact
 	Prioritized *bool
+	Inherit     string
 }
 
 // findQualityProfileActiveRuleSettings parses the activated rules, confirms that they belong to the quality profile, and returns a map of rule key to its activated settings (severity and parameters).
@@ -172,6 +183,7 @@ func findQualityProfileActiveRuleSettings(qualityProfileId string, activeRules *
 				Params:      &params,
 				Prioritized: &activeRule.PrioritizedRule,
 				Impacts:     GenerateQualityProfileImpactsObservation(&activeRule.Impacts),
+				Inherit:     activeRule.Inherit,
 			}
 		}
 	}
@@ -206,6 +218,8 @@ func GenerateQualityProfileRuleObservation(rule sonar.RuleDetails, activatedSett
 		if activatedSettings.Prioritized != nil {
 			ruleObservation.Prioritized = *activatedSettings.Prioritized
 		}
+
+		ruleObservation.Inheritance = activatedSettings.Inherit
 	}
 
 	return ruleObservation
@@ -288,3 +302,321 @@ func areQualityProfileRuleImpactsUpToDate(spec *map[string]string, observation [
 
 	return cmp.Equal(*spec, impactMap, cmpopts.EquateEmpty())
 }
+
+// fetchAllRules fetches all the rules matching a search using pagination, the search option of each page is generated by searchOption.
+func fetchAllRules(rulesClient RulesClient, searchOption func(page int) *sonar.RulesSearchOption) ([]sonar.RuleDetails, error) {
+	var allRules []sonar.RuleDetails
+
+	page := 1
+
+	for {
+		rules, resp, err := rulesClient.Search(searchOption(page)) //nolint:bodyclose // closed via helpers.CloseBody
+		helpers.CloseBody(resp)
+
+		if err != nil {
+			return nil, err
+		}
+
+		allRules = append(allRules, rules.Rules...)
+
+		if len(rules.Rules) == 0 || int64(len(allRules)) >= rules.Paging.Total {
+			return allRules, nil
+		}
+
+		page++
+	}
+}
+
+// QualityProfileRuleSelection is the effective set of rules of a Quality Profile, made of its rules and the rules selected by its rule selectors.
+type QualityProfileRuleSelection struct {
+	// Rules are the rules to be activated in the Quality Profile
+	Rules []v1alpha1.QualityProfileRuleParameters
+	// Selectors maps the key of each rule contributed by a rule selector to the name of the selector
+	Selectors map[string]string
+	// BulkSelectors are the rule selectors whose rules can be activated with a single bulk request using the query of the selector,
+	// because the query activates exactly the rules the selector contributes, with the settings of the selector
+	BulkSelectors []v1alpha1.QualityProfileRuleSelector
+}
+
+// BulkSelector returns the rule selector that contributed the rule, if its rules can be activated in bulk.
+func (s QualityProfileRuleSelection) BulkSelector(ruleKey string) (v1alpha1.QualityProfileRuleSelector, bool) {
+	name, selected := s.Selectors[ruleKey]
+	if !selected {
+		return v1alpha1.QualityProfileRuleSelector{}, false
+	}
+
+	idx := slices.IndexFunc(s.BulkSelectors, func(selector v1alpha1.QualityProfileRuleSelector) bool { return selector.Name == name })
+	if idx < 0 {
+		return v1alpha1.QualityProfileRuleSelector{}, false
+	}
+
+	return s.BulkSelectors[idx], true
+}
+
+// SelectedRules are the rules selected by a QualityProfileRuleSelector.
+type SelectedRules struct {
+	// Keys are the keys of the selected rules, without the template rules
+	Keys []string
+	// Bulk indicates whether the search of the selector returned exactly the selected rules,
+	// i.e. the selector has no include or exclude pattern and the search returned no template rule
+	Bulk bool
+}
+
+// GenerateRuleSelectorSearchOption generates SonarQube RulesSearchOption to fetch the rules of a language selected by a QualityProfileRuleSelector.
+func GenerateRuleSelectorSearchOption(language string, selector v1alpha1.QualityProfileRuleSelector, page int) *sonar.RulesSearchOption {
+	return &sonar.RulesSearchOption{
+		Languages:               []string{language},
+		Repositories:            selector.Repositories,
+		Tags:                    selector.Tags,
+		Types:                   selector.Types,
+		Severities:              selector.Severities,
+		ImpactSoftwareQualities: selector.SoftwareQualities,
+		Cwe:                     selector.Cwe,
+		OwaspTop10:              selector.OwaspTop10,
+		OwaspTop102021:          selector.OwaspTop102021,
+		PaginationArgs: sonar.PaginationArgs{
+			PageSize: maxRulesPerPage,
+			Page:     int64(page),
+		},
+		// Template rules cannot be activated, they are filtered out from the selected rules
+		Fields: []string{"isTemplate"},
+	}
+}
+
+// CheckQualityProfileRuleSelectors checks that the include and exclude patterns of the rule selectors of a Quality Profile are valid.
+// It returns the violations, one per invalid pattern.
+func CheckQualityProfileRuleSelectors(spec *v1alpha1.QualityProfileParameters) []string {
+	violations := make([]string, 0)
+
+	for _, selector := range spec.RuleSelectors {
+		for _, pattern := range slices.Concat(selector.Include, selector.Exclude) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				violations = append(violations, selector.Name+": invalid pattern "+strconv.Quote(pattern))
+			}
+		}
+	}
+
+	return violations
+}
+
+// MatchesRuleSelectorPatterns checks whether a rule key matches the include patterns of a selector, if any, and none of its exclude patterns.
+func MatchesRuleSelectorPatterns(ruleKey string, selector v1alpha1.QualityProfileRuleSelector) (bool, error) {
+	for _, pattern := range selector.Exclude {
+		matched, err := path.Match(pattern, ruleKey)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid exclude pattern %q of rule selector %s", pattern, selector.Name)
+		}
+
+		if matched {
+			return false, nil
+		}
+	}
+
+	if len(selector.Include) == 0 {
+		return true, nil
+	}
+
+	for _, pattern := range selector.Include {
+		matched, err := path.Match(pattern, ruleKey)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid include pattern %q of rule selector %s", pattern, selector.Name)
+		}
+
+		if matched {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// FetchRuleSelectorRules fetches the keys of the rules of a language selected by a QualityProfileRuleSelector, without the template rules.
+func FetchRuleSelectorRules(rulesClient RulesClient, language string, selector v1alpha1.QualityProfileRuleSelector) (SelectedRules, error) {
+	rules, err := fetchAllRules(rulesClient, func(page int) *sonar.RulesSearchOption {
+		return GenerateRuleSelectorSearchOption(language, selector, page)
+	})
+	if err != nil {
+		return SelectedRules{}, errors.Wrapf(err, "cannot search the rules of rule selector %s", selector.Name)
+	}
+
+	selected := SelectedRules{
+		Keys: make([]string, 0, len(rules)),
+		Bulk: len(selector.Include) == 0 && len(selector.Exclude) == 0,
+	}
+
+	for idx := range rules {
+		if rules[idx].IsTemplate {
+			selected.Bulk = false
+
+			continue
+		}
+
+		matched, err := MatchesRuleSelectorPatterns(rules[idx].Key, selector)
+		if err != nil {
+			return SelectedRules{}, err
+		}
+
+		if matched {
+			selected.Keys = append(selected.Keys, rules[idx].Key)
+		}
+	}
+
+	return selected, nil
+}
+
+// GenerateActiveRuleSelectorSearchOption generates SonarQube RulesSearchOption to find the rules selected by a QualityProfileRuleSelector
+// that are active in a Quality Profile.
+func GenerateActiveRuleSelectorSearchOption(qualityProfileKey string, language string, selector v1alpha1.QualityProfileRuleSelector, page int) *sonar.RulesSearchOption {
+	option := GenerateRuleSelectorSearchOption(language, selector, page)
+	option.Qprofile = qualityProfileKey
+	option.Activation = true
+
+	return option
+}
+
+// FetchAllActiveRuleSelectorKeys fetches the keys of the rules selected by a QualityProfileRuleSelector that are active in a Quality Profile using pagination.
+func FetchAllActiveRuleSelectorKeys(rulesClient RulesClient, qualityProfileKey string, language string, selector v1alpha1.QualityProfileRuleSelector) ([]string, error) {
+	rules, err := fetchAllRules(rulesClient, func(page int) *sonar.RulesSearchOption {
+		return GenerateActiveRuleSelectorSearchOption(qualityProfileKey, language, selector, page)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ruleKeys := make([]string, 0, len(rules))
+	for idx := range rules {
+		ruleKeys = append(ruleKeys, rules[idx].Key)
+	}
+
+	return ruleKeys, nil
+}
+
+// MergeQualityProfileRules merges the rules of a Quality Profile with the rules selected by its rule selectors.
+// selected holds the rules selected by each selector, in the order of the selectors.
+// Rules listed in the spec override the rules selected by the selectors, and the first selector selecting a rule wins over the others.
+// The deactivated rules are never selected. The rules of a selector can be activated in bulk only if it contributes all the rules it selects.
+func MergeQualityProfileRules(spec v1alpha1.QualityProfileParameters, selected []SelectedRules) QualityProfileRuleSelection {
+	selection := QualityProfileRuleSelection{
+		Rules:     make([]v1alpha1.QualityProfileRuleParameters, 0, len(spec.Rules)),
+		Selectors: make(map[string]string),
+	}
+
+	explicit := make(map[string]bool, len(spec.Rules))
+
+	for idx := range spec.Rules {
+		explicit[spec.Rules[idx].Rule] = true
+		selection.Rules = append(selection.Rules, spec.Rules[idx])
+	}
+
+	for idx, selector := range spec.RuleSelectors {
+		if idx >= len(selected) {
+			break
+		}
+
+		bulk := selected[idx].Bulk
+
+		for _, ruleKey := range selected[idx].Keys {
+			if _, exists := selection.Selectors[ruleKey]; exists || explicit[ruleKey] || slices.Contains(spec.DeactivatedRules, ruleKey) {
+				bulk = false
+
+				continue
+			}
+
+			selection.Selectors[ruleKey] = selector.Name
+			selection.Rules = append(selection.Rules, v1alpha1.QualityProfileRuleParameters{
+				Rule:        ruleKey,
+				Severity:    selector.Severity,
+				Prioritized: selector.Prioritized,
+			})
+		}
+
+		if bulk {
+			selection.BulkSelectors = append(selection.BulkSelectors, selector)
+		}
+	}
+
+	return selection
+}
+
+// FetchAllRuleSelectorRules fetches the rules selected by each rule selector of a Quality Profile, in the order of the selectors.
+func FetchAllRuleSelectorRules(rulesClient RulesClient, spec v1alpha1.QualityProfileParameters) ([]SelectedRules, error) {
+	selected := make([]SelectedRules, 0, len(spec.RuleSelectors))
+
+	for _, selector := range spec.RuleSelectors {
+		rules, err := FetchRuleSelectorRules(rulesClient, spec.Language, selector)
+		if err != nil {
+			return nil, err
+		}
+
+		selected = append(selected, rules)
+	}
+
+	return selected, nil
+}
+
+// DefaultRuleSelectorsTTL is the duration the rules selected by the rule selectors of a Quality Profile are cached,
+// so that the rules added to SonarQube, e.g. by a plugin upgrade, are eventually selected.
+const DefaultRuleSelectorsTTL = 10 * time.Minute
+
+// ruleSelectorsCacheEntry is the rules selected by the rule selectors of a generation of a Quality Profile.
+type ruleSelectorsCacheEntry struct {
+	generation int64
+	selected   []SelectedRules
+	expires    time.Time
+}
+
+// RuleSelectorsCache caches the rules selected by the rule selectors of each Quality Profile, so that they are resolved
+// once per generation of its spec instead of on every Observe.
+type RuleSelectorsCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[types.UID]ruleSelectorsCacheEntry
+	now     func() time.Time
+}
+
+// NewRuleSelectorsCache creates a rule selectors cache keeping the selected rules for the given duration.
+func NewRuleSelectorsCache(ttl time.Duration) *RuleSelectorsCache {
+	return &RuleSelectorsCache{ttl: ttl, entries: make(map[types.UID]ruleSelectorsCacheEntry), now: time.Now}
+}
+
+// Get returns the cached rules selected by the rule selectors of the Quality Profile identified by its UID, or resolves them if they are
+// missing, expired or if the spec of the Quality Profile changed. Rules are not cached if the UID is empty or if the resolution fails.
+func (c *RuleSelectorsCache) Get(uid types.UID, generation int64, resolve func() ([]SelectedRules, error)) ([]SelectedRules, error) {
+	if uid == "" {
+		return resolve()
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[uid]
+	c.mu.Unlock()
+
+	if ok && entry.generation == generation && c.now().Before(entry.expires) {
+		return entry.selected, nil
+	}
+
+	selected, err := resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for cachedUID, cached := range c.entries {
+		if !now.Before(cached.expires) {
+			delete(c.entries, cachedUID)
+		}
+	}
+
+	c.entries[uid] = ruleSelectorsCacheEntry{generation: generation, selected: selected, expires: now.Add(c.ttl)}
+
+	return selected, nil
+}
+
+// SetQualityProfileRuleSelectors sets the name of the rule selector that selected each observed rule.
+func SetQualityProfileRuleSelectors(observations []v1alpha1.QualityProfileRuleObservation, selection QualityProfileRuleSelection) {
+	for idx := range observations {
+		observations[idx].Selector = selection.Selectors[observations[idx].Key]
+	}
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package instance
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/boxboxjason/sonarqube-client-go/sonar"
 	"github.com/crossplane/provider-sonarqube/apis/instance/v1alpha1"
@@ -520,3 +522,218 @@ func TestIsQualityProfileRuleUpToDate(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateRuleSelectorSearchOption(t *testing.T) {
+	t.Parallel()
+
+	selector := v1alpha1.QualityProfileRuleSelector{
+		Name:              "security",
+		Repositories:      []string{"java", "javasecurity"},
+		Tags:              []string{"cwe"},
+		Types:             []string{"VULNERABILITY"},
+		Severities:        []string{"CRITICAL", "BLOCKER"},
+		SoftwareQualities: []string{"SECURITY"},
+		Cwe:               []string{"89"},
+		OwaspTop10:        []string{"a1"},
+		OwaspTop102021:    []string{"a3"},
+		Include:           []string{"java*:S*"},
+		Severity:          ptr.To("BLOCKER"),
+	}
+
+	want := &sonar.RulesSearchOption{
+		Languages:               []string{"java"},
+		Repositories:            []string{"java", "javasecurity"},
+		Tags:                    []string{"cwe"},
+		Types:                   []string{"VULNERABILITY"},
+		Severities:              []string{"CRITICAL", "BLOCKER"},
+		ImpactSoftwareQualities: []string{"SECURITY"},
+		Cwe:                     []string{"89"},
+		OwaspTop10:              []string{"a1"},
+		OwaspTop102021:          []string{"a3"},
+		PaginationArgs:          sonar.PaginationArgs{PageSize: maxRulesPerPage, Page: 2},
+		Fields:                  []string{"isTemplate"},
+	}
+
+	got := GenerateRuleSelectorSearchOption("java", selector, 2)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateRuleSelectorSearchOption() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMatchesRuleSelectorPatterns(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		selector v1alpha1.QualityProfileRuleSelector
+		ruleKey  string
+		want     bool
+		wantErr  bool
+	}{
+		"NoPatterns": {
+			selector: v1alpha1.QualityProfileRuleSelector{Name: "all"},
+			ruleKey:  "java:S100",
+			want:     true,
+		},
+		"Included": {
+			selector: v1alpha1.QualityProfileRuleSelector{Name: "s1", Include: []string{"java:S2*", "java:S1*"}},
+			ruleKey:  "java:S100",
+			want:     true,
+		},
+		"NotIncluded": {
+			selector: v1alpha1.QualityProfileRuleSelector{Name: "s1", Include: []string{"java:S1*"}},
+			ruleKey:  "java:S200",
+			want:     false,
+		},
+		"ExcludedOverridesIncluded": {
+			selector: v1alpha1.QualityProfileRuleSelector{Name: "s1", Include: []string{"java:S1*"}, Exclude: []string{"java:S100"}},
+			ruleKey:  "java:S100",
+			want:     false,
+		},
+		"InvalidPattern": {
+			selector: v1alpha1.QualityProfileRuleSelector{Name: "s1", Exclude: []string{"java:S[1"}},
+			ruleKey:  "java:S100",
+			wantErr:  true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := MatchesRuleSelectorPatterns(tc.ruleKey, tc.selector)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("MatchesRuleSelectorPatterns() error = %v, wantErr %v", err, tc.wantErr)
+			}
+
+			if got != tc.want {
+				t.Errorf("MatchesRuleSelectorPatterns() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckQualityProfileRuleSelectors(t *testing.T) {
+	t.Parallel()
+
+	spec := &v1alpha1.QualityProfileParameters{
+		RuleSelectors: []v1alpha1.QualityProfileRuleSelector{
+			{Name: "valid", Include: []string{"java:S1*"}, Exclude: []string{"java:S10?"}},
+			{Name: "invalid", Include: []string{"java:S[1"}, Exclude: []string{"java:\\"}},
+		},
+	}
+
+	want := []string{`invalid: invalid pattern "java:S[1"`, `invalid: invalid pattern "java:\\"`}
+	if diff := cmp.Diff(want, CheckQualityProfileRuleSelectors(spec)); diff != "" {
+		t.Errorf("CheckQualityProfileRuleSelectors() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMergeQualityProfileRules(t *testing.T) {
+	t.Parallel()
+
+	selectors := []v1alpha1.QualityProfileRuleSelector{
+		{Name: "bugs", Severity: ptr.To("MAJOR")},
+		{Name: "security", Prioritized: ptr.To(true)},
+	}
+
+	tests := map[string]struct {
+		rules       []v1alpha1.QualityProfileRuleParameters
+		deactivated []string
+		selected    []SelectedRules
+		want        QualityProfileRuleSelection
+	}{
+		"NoSelectors": {
+			rules: []v1alpha1.QualityProfileRuleParameters{{Rule: "java:S1"}},
+			want: QualityProfileRuleSelection{
+				Rules:     []v1alpha1.QualityProfileRuleParameters{{Rule: "java:S1"}},
+				Selectors: map[string]string{},
+			},
+		},
+		"SelectorsWithTheirSettings": {
+			selected: []SelectedRules{{Keys: []string{"java:S1", "java:S2"}, Bulk: true}, {Keys: []string{"javasecurity:S3"}}},
+			want: QualityProfileRuleSelection{
+				Rules: []v1alpha1.QualityProfileRuleParameters{
+					{Rule: "java:S1", Severity: ptr.To("MAJOR")},
+					{Rule: "java:S2", Severity: ptr.To("MAJOR")},
+					{Rule: "javasecurity:S3", Prioritized: ptr.To(true)},
+				},
+				Selectors:     map[string]string{"java:S1": "bugs", "java:S2": "bugs", "javasecurity:S3": "security"},
+				BulkSelectors: []v1alpha1.QualityProfileRuleSelector{selectors[0]},
+			},
+		},
+		"ExplicitRulesOverrideSelectors": {
+			rules:    []v1alpha1.QualityProfileRuleParameters{{Rule: "java:S1", Parameters: &map[string]string{"max": "5"}}},
+			selected: []SelectedRules{{Keys: []string{"java:S1", "java:S2"}, Bulk: true}, {Keys: []string{"java:S2"}, Bulk: true}},
+			want: QualityProfileRuleSelection{
+				Rules: []v1alpha1.QualityProfileRuleParameters{
+					{Rule: "java:S1", Parameters: &map[string]string{"max": "5"}},
+					{Rule: "java:S2", Severity: ptr.To("MAJOR")},
+				},
+				Selectors: map[string]string{"java:S2": "bugs"},
+			},
+		},
+		"DeactivatedRulesAreNotSelected": {
+			deactivated: []string{"java:S1"},
+			selected:    []SelectedRules{{Keys: []string{"java:S1", "java:S2"}, Bulk: true}},
+			want: QualityProfileRuleSelection{
+				Rules:     []v1alpha1.QualityProfileRuleParameters{{Rule: "java:S2", Severity: ptr.To("MAJOR")}},
+				Selectors: map[string]string{"java:S2": "bugs"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			spec := v1alpha1.QualityProfileParameters{Rules: tc.rules, RuleSelectors: selectors, DeactivatedRules: tc.deactivated}
+
+			got := MergeQualityProfileRules(spec, tc.selected)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("MergeQualityProfileRules() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRuleSelectorsCache(t *testing.T) {
+	t.Parallel()
+
+	cache := NewRuleSelectorsCache(time.Minute)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	resolutions := 0
+	resolve := func() ([]SelectedRules, error) {
+		resolutions++
+
+		return []SelectedRules{{Keys: []string{"java:S1"}, Bulk: true}}, nil
+	}
+
+	if _, err := cache.Get("uid", 1, resolve); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _ = cache.Get("uid", 1, resolve); resolutions != 1 {
+		t.Errorf("Get() resolved again for the same generation within the TTL, %d resolutions", resolutions)
+	}
+
+	if _, _ = cache.Get("uid", 2, resolve); resolutions != 2 {
+		t.Errorf("Get() did not resolve again after the spec changed, %d resolutions", resolutions)
+	}
+
+	now = now.Add(2 * time.Minute)
+
+	if _, _ = cache.Get("uid", 2, resolve); resolutions != 3 {
+		t.Errorf("Get() did not resolve again after the TTL, %d resolutions", resolutions)
+	}
+
+	if _, _ = cache.Get("", 2, resolve); resolutions != 4 {
+		t.Errorf("Get() cached rules without a UID, %d resolutions", resolutions)
+	}
+
+	errBoom := errors.New("boom")
+	if _, err := cache.Get("other", 1, func() ([]SelectedRules, error) { return nil, errBoom }); !errors.Is(err, errBoom) {
+		t.Errorf("Get() error = %v, want %v", err, errBoom)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"net/http"
+
+	"github.com/boxboxjason/sonarqube-client-go/sonar"
+	"github.com/crossplane/provider-sonarqube/internal/clients/common"
+)
+
+// ServerStatusUp is the status of a SonarQube instance that is running and can serve requests.
+const ServerStatusUp = "UP"
+
+// ServerClient is the client for the SonarQube APIs describing the instance itself: authentication, version, status and edition.
+type ServerClient interface {
+	Validate() (v *sonar.AuthenticationValidation, resp *http.Response, err error)
+	Version() (v *string, resp *http.Response, err error)
+	Status() (v *sonar.SystemStatus, resp *http.Response, err error)
+	Global() (v *sonar.NavigationGlobal, resp *http.Response, err error)
+}
+
+// serverClient implements ServerClient with the services of a SonarQube client.
+type serverClient struct {
+	authentication *sonar.AuthenticationService
+	server         *sonar.ServerService
+	system         *sonar.SystemService
+	navigation     *sonar.NavigationService
+}
+
+// NewServerClient creates a new ServerClient with the provided SonarQube client configuration.
+func NewServerClient(clientConfig common.Config) (ServerClient, error) {
+	newClient, err := common.GetClient(clientConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serverClient{
+		authentication: newClient.Authentication,
+		server:         newClient.Server,
+		system:         newClient.System,
+		navigation:     newClient.Navigation,
+	}, nil
+}
+
+// Validate checks whether the credentials of the client are valid, it calls api/authentication/validate.
+func (c *serverClient) Validate() (*sonar.AuthenticationValidation, *http.Response, error) {
+	return c.authentication.Validate()
+}
+
+// Version returns the version of the SonarQube instance, it calls api/server/version.
+func (c *serverClient) Version() (*string, *http.Response, error) {
+	return c.server.Version()
+}
+
+// Status returns the running status of the SonarQube instance, it calls api/system/status.
+func (c *serverClient) Status() (*sonar.SystemStatus, *http.Response, error) {
+	return c.system.Status()
+}
+
+// Global returns the global navigation of the SonarQube instance, which includes its edition, it calls api/navigation/global.
+func (c *serverClient) Global() (*sonar.NavigationGlobal, *http.Response, error) {
+	return c.navigation.Global()
+}
@@ -17,13 +17,21 @@ limitations under the License.
 package instance
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"maps"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/boxboxjason/sonarqube-client-go/sonar"
 	"github.com/crossplane/provider-sonarqube/apis/instance/v1alpha1"
 	"github.com/crossplane/provider-sonarqube/internal/clients/common"
 	"github.com/crossplane/provider-sonarqube/internal/helpers"
+	"k8s.io/utils/ptr"
 )
 
 // SettingsClient is the interface for interacting with SonarQube Settings API
@@ -32,13 +40,17 @@ type SettingsClient interface {
 	Set(opt *sonar.SettingsSetOption) (*http.Response, error)
 	Values(opt *sonar.SettingsValuesOption) (*sonar.SettingsValues, *http.Response, error)
 	Reset(opt *sonar.SettingsResetOption) (*http.Response, error)
+	ListDefinitions(opt *sonar.SettingsListDefinitionsOption) (*sonar.SettingsListDefinitions, *http.Response, error)
 }
 
 // NewSettingsClient creates a new SettingsClient with the provided SonarQube client configuration.
-func NewSettingsClient(clientConfig common.Config) SettingsClient {
-	newClient := common.NewClient(clientConfig)
+func NewSettingsClient(clientConfig common.Config) (SettingsClient, error) {
+	newClient, err := common.GetClient(clientConfig)
+	if err != nil {
+		return nil, err
+	}
 
-	return newClient.Settings
+	return newClient.Settings, nil
 }
 
 // GenerateSettingSetOptions generates the options for the Set API call based on the provided settings parameters and component.
@@ -78,6 +90,22 @@ func GenerateSettingsValuesOptions(params *v1alpha1.SettingsParameters) *sonar.S
 	return settingsValuesOptions
 }
 
+// GenerateSettingsParentValuesOptions generates the options for the Values API call fetching the global values of the settings,
+// which are the parent values of the settings of a component.
+func GenerateSettingsParentValuesOptions(params *v1alpha1.SettingsParameters) *sonar.SettingsValuesOption {
+	return &sonar.SettingsValuesOption{
+		Keys: slices.Sorted(maps.Keys(params.Settings)),
+	}
+}
+
+// GenerateSettingsListDefinitionsOptions generates the options for the ListDefinitions API call based on the provided component.
+func GenerateSettingsListDefinitionsOptions(component *string) *sonar.SettingsListDefinitionsOption {
+	settingsListDefinitionsOptions := &sonar.SettingsListDefinitionsOption{}
+	helpers.AssignIfNonNil(&settingsListDefinitionsOptions.Component, component)
+
+	return settingsListDefinitionsOptions
+}
+
 // GenerateSettingsResetOptions generates the options for the Reset API call based on the provided settings parameters and component.
 func GenerateSettingsResetOptions(params v1alpha1.SettingsParameters) *sonar.SettingsResetOption {
 	keys := make([]string, 0, len(params.Settings))
@@ -113,9 +141,93 @@ func GenerateSettingsObservation(observed *sonar.SettingsValues) v1alpha1.Settin
 		settingsObservation.Settings[setting.Key] = GenerateSettingObservation(&setting)
 	}
 
+	// Secured settings are only reported as being set, without their value.
+	for _, key := range observed.SetSecuredSettings {
+		if _, exists := settingsObservation.Settings[key]; !exists {
+			settingsObservation.Settings[key] = v1alpha1.SettingObservation{Secured: true}
+		}
+	}
+
 	return settingsObservation
 }
 
+// CarryOverAppliedValueHashes copies the hashes of the applied values from the previous observation to the new one.
+// SonarQube does not know about these hashes, so they must be preserved across observations.
+func CarryOverAppliedValueHashes(observation *v1alpha1.SettingsObservation, previous v1alpha1.SettingsObservation) {
+	for key, previousSetting := range previous.Settings {
+		setting, exists := observation.Settings[key]
+		if !exists || previousSetting.AppliedValueHash == "" {
+			continue
+		}
+
+		setting.AppliedValueHash = previousSetting.AppliedValueHash
+		observation.Settings[key] = setting
+	}
+}
+
+// HashSettingParameters computes a stable hash of the value of the given (resolved) setting parameters.
+func HashSettingParameters(params v1alpha1.SettingParameters) string {
+	// Marshalling cannot fail for these types, and maps are encoded with sorted keys.
+	data, _ := json.Marshal(struct {
+		Value       *string            `json:"value,omitempty"`
+		Values      *[]string          `json:"values,omitempty"`
+		FieldValues *map[string]string `json:"fieldValues,omitempty"`
+	}{
+		Value:       params.Value,
+		Values:      params.Values,
+		FieldValues: params.FieldValues,
+	})
+
+	hash := sha256.Sum256(data)
+
+	return hex.EncodeToString(hash[:])
+}
+
+// HashSecretKeyVersion computes the hash identifying the value of a setting sourced from a Secret by the version of the Secret key,
+// as returned by common.GetLocalSecretKeyVersion, so that no digest of the value, which could be brute-forced offline, is published in the status.
+func HashSecretKeyVersion(version string) string {
+	hash := sha256.Sum256([]byte("secret:" + version))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// AppliedValueHash returns the hash recorded for the value applied for the given resolved setting parameters: secretHash,
+// the hash of the version of the Secret the value comes from, or the hash of the value if it does not come from a Secret.
+func AppliedValueHash(params v1alpha1.SettingParameters, secretHash string) string {
+	if secretHash != "" {
+		return secretHash
+	}
+
+	return HashSettingParameters(params)
+}
+
+// RedactSecretSourcedSettings drops the observed values of the settings whose desired value comes from a Secret, so that the value of
+// the Secret is not copied in plain text into the status. Their parent values are dropped for the same reason. The observed value is
+// compared with the resolved desired value first: when they are equal, the hash of the version of the Secret is reported instead.
+// secretHashes maps the keys of the settings sourced from a Secret to the hash of the version of the Secret key.
+// Secured settings are left unchanged, SonarQube never returns their value.
+func RedactSecretSourcedSettings(resolved v1alpha1.SettingsParameters, secretHashes map[string]string, observation *v1alpha1.SettingsObservation) {
+	for key, secretHash := range secretHashes {
+		setting, exists := observation.Settings[key]
+		if !exists || setting.Secured {
+			continue
+		}
+
+		setting.ValueHash = ""
+		if areSettingValuesEqual(resolved.Settings[key], setting) {
+			setting.ValueHash = secretHash
+		}
+
+		setting.Value = ""
+		setting.Values = nil
+		setting.FieldValues = nil
+		setting.ParentValue = ""
+		setting.ParentValues = nil
+		setting.ParentFieldValues = nil
+		observation.Settings[key] = setting
+	}
+}
+
 // GenerateSettingObservation generates the SettingObservation based on the observed SettingValue from SonarQube.
 func GenerateSettingObservation(observed *sonar.SettingValue) v1alpha1.SettingObservation {
 	fieldValues := make(map[string]string)
@@ -128,24 +240,304 @@ func GenerateSettingObservation(observed *sonar.SettingValue) v1alpha1.SettingOb
 		Value:       observed.Value,
 		Values:      observed.Values,
 		FieldValues: fieldValues,
+		Inherited:   observed.Inherited,
+	}
+}
+
+// Levels a setting value can come from.
+// There is no branch level, the SonarQube settings API does not scope settings to branches.
+const (
+	// SettingLevelDefault is used for settings that are not stored in SonarQube and use their default value.
+	SettingLevelDefault = "Default"
+	// SettingLevelGlobal is used for settings stored at the instance level.
+	SettingLevelGlobal = "Global"
+	// SettingLevelProject is used for settings stored on the component (project, application or portfolio).
+	SettingLevelProject = "Project"
+)
+
+// SetSettingsLevels records the level each observed setting value comes from and, for component settings, their parent values.
+// The parents are the global values of the settings, as returned by the Values API call without component, and are ignored when component is nil.
+func SetSettingsLevels(observation *v1alpha1.SettingsObservation, component *string, parents *sonar.SettingsValues) {
+	parentsByKey := make(map[string]sonar.SettingValue)
+
+	if component != nil && parents != nil {
+		for _, parent := range parents.Settings {
+			parentsByKey[parent.Key] = parent
+		}
 	}
+
+	for key, setting := range observation.Settings {
+		if setting.Secured {
+			continue
+		}
+
+		parent, hasParent := parentsByKey[key]
+
+		switch {
+		case !setting.Inherited && component != nil:
+			setting.Level = SettingLevelProject
+		case !setting.Inherited:
+			setting.Level = SettingLevelGlobal
+		case component != nil && hasParent && !parent.Inherited:
+			setting.Level = SettingLevelGlobal
+		default:
+			setting.Level = SettingLevelDefault
+		}
+
+		if hasParent {
+			parentObservation := GenerateSettingObservation(&parent)
+			setting.ParentValue = parentObservation.Value
+			setting.ParentValues = parentObservation.Values
+
+			if len(parentObservation.FieldValues) > 0 {
+				setting.ParentFieldValues = parentObservation.FieldValues
+			}
+		}
+
+		observation.Settings[key] = setting
+	}
+}
+
+// NeedsExplicitStorage checks if the setting must be set again because its value is inherited while explicit storage is forced.
+func NeedsExplicitStorage(params v1alpha1.SettingsParameters, observation v1alpha1.SettingObservation) bool {
+	return ptr.Deref(params.ForceExplicitStorage, false) && observation.Inherited
 }
 
 // IsSettingUpToDate checks if the observed setting is up to date with the desired setting parameters.
-func IsSettingUpToDate(params v1alpha1.SettingParameters, observation v1alpha1.SettingObservation) bool {
+// The parameters must be resolved, i.e. any "valueFrom" must have been replaced by its value, and secretHash is the hash of the version
+// of the Secret the value comes from, empty if it does not come from a Secret.
+// Secured settings are compared through the hash of the last applied value, as SonarQube never returns their value,
+// and settings sourced from a Secret through the hash of the version of the Secret their observed value was equal to.
+func IsSettingUpToDate(params v1alpha1.SettingParameters, secretHash string, observation v1alpha1.SettingObservation) bool {
+	if observation.Secured {
+		return observation.AppliedValueHash == AppliedValueHash(params, secretHash)
+	}
+
+	if secretHash != "" {
+		return observation.ValueHash == secretHash
+	}
+
+	return areSettingValuesEqual(params, observation)
+}
+
+// areSettingValuesEqual checks if the observed value of a setting is equal to the value of the resolved setting parameters.
+func areSettingValuesEqual(params v1alpha1.SettingParameters, observation v1alpha1.SettingObservation) bool {
 	return helpers.IsComparablePtrEqualComparable(params.Value, observation.Value) &&
 		helpers.IsComparableSlicePtrEqualComparableSlice(params.Values, observation.Values) &&
 		helpers.IsComparableMapPtrEqualComparableMap(params.FieldValues, observation.FieldValues)
 }
 
 // AreSettingsUpToDate checks if the observed settings are up to date with the desired settings parameters.
-func AreSettingsUpToDate(params v1alpha1.SettingsParameters, observation v1alpha1.SettingsObservation) bool {
+// secretHashes maps the keys of the settings sourced from a Secret to the hash of the version of the Secret key.
+func AreSettingsUpToDate(params v1alpha1.SettingsParameters, secretHashes map[string]string, observation v1alpha1.SettingsObservation) bool {
 	for key, param := range params.Settings {
 		observationSetting, exists := observation.Settings[key]
-		if !exists || !IsSettingUpToDate(param, observationSetting) {
+		if !exists || !IsSettingUpToDate(param, secretHashes[key], observationSetting) || NeedsExplicitStorage(params, observationSetting) {
 			return false
 		}
 	}
 
 	return true
 }
+
+// Settings management modes.
+const (
+	// SettingsManagementModeAuthoritative makes a Settings resource own all the observed settings of its component.
+	SettingsManagementModeAuthoritative = "Authoritative"
+	// SettingsManagementModeAdditive makes a Settings resource own only the settings it has applied itself.
+	SettingsManagementModeAdditive = "Additive"
+)
+
+// IsAdditiveManagementMode checks if the settings are managed in Additive mode.
+func IsAdditiveManagementMode(params v1alpha1.SettingsParameters) bool {
+	return ptr.Deref(params.ManagementMode, SettingsManagementModeAuthoritative) == SettingsManagementModeAdditive
+}
+
+// ObsoleteAppliedKeys returns the sorted keys of the applied keys ledger that are no longer in the desired settings.
+func ObsoleteAppliedKeys(params v1alpha1.SettingsParameters, observation v1alpha1.SettingsObservation) []string {
+	obsolete := make([]string, 0)
+
+	for _, key := range observation.AppliedKeys {
+		if _, exists := params.Settings[key]; !exists {
+			obsolete = append(obsolete, key)
+		}
+	}
+
+	slices.Sort(obsolete)
+
+	return obsolete
+}
+
+// AreAppliedKeysUpToDate checks if the applied keys ledger matches exactly the keys of the desired settings.
+func AreAppliedKeysUpToDate(params v1alpha1.SettingsParameters, observation v1alpha1.SettingsObservation) bool {
+	for key := range params.Settings {
+		if !slices.Contains(observation.AppliedKeys, key) {
+			return false
+		}
+	}
+
+	return len(ObsoleteAppliedKeys(params, observation)) == 0
+}
+
+// RecordAppliedKey adds the key to the applied keys ledger, keeping it sorted and without duplicates.
+func RecordAppliedKey(observation *v1alpha1.SettingsObservation, key string) {
+	index, found := slices.BinarySearch(observation.AppliedKeys, key)
+	if !found {
+		observation.AppliedKeys = slices.Insert(observation.AppliedKeys, index, key)
+	}
+}
+
+// ForgetAppliedKeys removes the keys from the applied keys ledger.
+func ForgetAppliedKeys(observation *v1alpha1.SettingsObservation, keys []string) {
+	observation.AppliedKeys = slices.DeleteFunc(observation.AppliedKeys, func(key string) bool {
+		return slices.Contains(keys, key)
+	})
+
+	if len(observation.AppliedKeys) == 0 {
+		observation.AppliedKeys = nil
+	}
+}
+
+// Setting definition types that are validated before the settings are applied.
+const (
+	settingTypeBoolean          = "BOOLEAN"
+	settingTypeInteger          = "INTEGER"
+	settingTypeLong             = "LONG"
+	settingTypeFloat            = "FLOAT"
+	settingTypeSingleSelectList = "SINGLE_SELECT_LIST"
+	settingTypePropertySet      = "PROPERTY_SET"
+)
+
+// ValidateSettings checks the (resolved) settings parameters against the setting definitions returned by SonarQube.
+// It checks that each key exists, that the right field among value, values and fieldValues is used for its multiplicity,
+// that property set fields exist and that values match the definition type.
+// It returns one violation per offending key, sorted by key.
+func ValidateSettings(params v1alpha1.SettingsParameters, definitions *sonar.SettingsListDefinitions) []string {
+	definitionsByKey := make(map[string]sonar.SettingDefinition)
+
+	if definitions != nil {
+		for _, definition := range definitions.Definitions {
+			definitionsByKey[definition.Key] = definition
+		}
+	}
+
+	violations := make([]string, 0)
+
+	for key, setting := range params.Settings {
+		definition, exists := definitionsByKey[key]
+		if !exists {
+			violations = append(violations, key+": unknown setting key")
+
+			continue
+		}
+
+		err := validateSetting(setting, definition)
+		if err != "" {
+			violations = append(violations, key+": "+err)
+		}
+	}
+
+	slices.Sort(violations)
+
+	return violations
+}
+
+// validateSetting checks a single setting against its definition and returns the violation, or an empty string.
+func validateSetting(setting v1alpha1.SettingParameters, definition sonar.SettingDefinition) string {
+	hasValue := setting.Value != nil
+	hasValues := setting.Values != nil && len(*setting.Values) > 0
+	hasFieldValues := setting.FieldValues != nil && len(*setting.FieldValues) > 0
+
+	switch {
+	case definition.Type == settingTypePropertySet:
+		if hasValue || hasValues || !hasFieldValues {
+			return "property set setting must use fieldValues"
+		}
+
+		return validateSettingFields(*setting.FieldValues, definition.Fields)
+	case hasFieldValues:
+		return fmt.Sprintf("fieldValues can only be used for property set settings, not %s", definition.Type)
+	case definition.MultiValues && hasValue:
+		return "multi-valued setting must use values instead of value"
+	case !definition.MultiValues && hasValues:
+		return "single-valued setting must use value instead of values"
+	}
+
+	values := make([]string, 0)
+	if hasValue {
+		values = append(values, *setting.Value)
+	}
+
+	if hasValues {
+		values = append(values, *setting.Values...)
+	}
+
+	for _, value := range values {
+		err := validateSettingValue(value, definition.Type, definition.Options)
+		if err != "" {
+			return err
+		}
+	}
+
+	return ""
+}
+
+// validateSettingFields checks that the property set fields exist in the definition and that their values match the field type.
+func validateSettingFields(fieldValues map[string]string, fields []sonar.SettingField) string {
+	fieldsByKey := make(map[string]sonar.SettingField, len(fields))
+	for _, field := range fields {
+		fieldsByKey[field.Key] = field
+	}
+
+	keys := slices.Sorted(maps.Keys(fieldValues))
+
+	unknown := make([]string, 0)
+
+	for _, key := range keys {
+		field, exists := fieldsByKey[key]
+		if !exists {
+			unknown = append(unknown, key)
+
+			continue
+		}
+
+		err := validateSettingValue(fieldValues[key], field.Type, field.Options)
+		if err != "" {
+			return fmt.Sprintf("field %s: %s", key, err)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Sprintf("unknown fields %s", strings.Join(unknown, ", "))
+	}
+
+	return ""
+}
+
+// validateSettingValue checks that a value matches the given setting type and options, and returns the violation, or an empty string.
+func validateSettingValue(value, settingType string, options []string) string {
+	var err error
+
+	switch settingType {
+	case settingTypeBoolean:
+		if value != "true" && value != "false" {
+			return fmt.Sprintf("value %q is not a boolean", value)
+		}
+	case settingTypeInteger:
+		_, err = strconv.ParseInt(value, 10, 32)
+	case settingTypeLong:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case settingTypeFloat:
+		_, err = strconv.ParseFloat(value, 64)
+	case settingTypeSingleSelectList:
+		if len(options) > 0 && !slices.Contains(options, value) {
+			return fmt.Sprintf("value %q is not one of %s", value, strings.Join(options, ", "))
+		}
+	}
+
+	if err != nil {
+		return fmt.Sprintf("value %q is not a valid %s", value, strings.ToLower(settingType))
+	}
+
+	return ""
+}
@@ -528,6 +528,19 @@ func TestGenerateSettingsObservation(t *testing.T) {
 				Settings: map[string]v1alpha1.SettingObservation{},
 			},
 		},
+		"ObservationWithSecuredSettings": {
+			observed: &sonar.SettingsValues{
+				Settings:           []sonar.SettingValue{},
+				SetSecuredSettings: []string{"sonar.auth.github.clientSecret.secured"},
+			},
+			want: v1alpha1.SettingsObservation{
+				Settings: map[string]v1alpha1.SettingObservation{
+					"sonar.auth.github.clientSecret.secured": {
+						Secured: true,
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range tests {
@@ -545,11 +558,67 @@ func TestGenerateSettingsObservation(t *testing.T) {
 func TestIsSettingUpToDate(t *testing.T) {
 	t.Parallel()
 
+	secretHash := HashSecretKeyVersion("uid/1/clientSecret")
+
 	tests := map[string]struct {
 		params      v1alpha1.SettingParameters
+		secretHash  string
 		observation v1alpha1.SettingObservation
 		want        bool
 	}{
+		"SecuredFromSecretWithMatchingVersion": {
+			params:      v1alpha1.SettingParameters{Value: ptr.To("client-secret")},
+			secretHash:  secretHash,
+			observation: v1alpha1.SettingObservation{Secured: true, AppliedValueHash: secretHash},
+			want:        true,
+		},
+		"SecuredFromRotatedSecret": {
+			params:      v1alpha1.SettingParameters{Value: ptr.To("client-secret")},
+			secretHash:  HashSecretKeyVersion("uid/2/clientSecret"),
+			observation: v1alpha1.SettingObservation{Secured: true, AppliedValueHash: secretHash},
+			want:        false,
+		},
+		"FromSecretEqualWhenObserved": {
+			params:      v1alpha1.SettingParameters{Value: ptr.To("cn=admin")},
+			secretHash:  secretHash,
+			observation: v1alpha1.SettingObservation{ValueHash: secretHash},
+			want:        true,
+		},
+		"FromSecretDifferentWhenObserved": {
+			params:      v1alpha1.SettingParameters{Value: ptr.To("cn=admin")},
+			secretHash:  secretHash,
+			observation: v1alpha1.SettingObservation{},
+			want:        false,
+		},
+		"SecuredWithMatchingHash": {
+			params: v1alpha1.SettingParameters{
+				Value: ptr.To("client-secret"),
+			},
+			observation: v1alpha1.SettingObservation{
+				Secured:          true,
+				AppliedValueHash: HashSettingParameters(v1alpha1.SettingParameters{Value: ptr.To("client-secret")}),
+			},
+			want: true,
+		},
+		"SecuredWithDifferentHash": {
+			params: v1alpha1.SettingParameters{
+				Value: ptr.To("rotated-secret"),
+			},
+			observation: v1alpha1.SettingObservation{
+				Secured:          true,
+				AppliedValueHash: HashSettingParameters(v1alpha1.SettingParameters{Value: ptr.To("client-secret")}),
+			},
+			want: false,
+		},
+		"SecuredWithoutHash": {
+			params: v1alpha1.SettingParameters{
+				Value: ptr.To("client-secret"),
+			},
+			observation: v1alpha1.SettingObservation{
+				Secured: true,
+			},
+			want: false,
+		},
 		"MatchingValue": {
 			params: v1alpha1.SettingParameters{
 				Value: ptr.To("https://sonarqube.example.com"),
@@ -669,7 +738,7 @@ func TestIsSettingUpToDate(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			got := IsSettingUpToDate(tc.params, tc.observation)
+			got := IsSettingUpToDate(tc.params, tc.secretHash, tc.observation)
 			if got != tc.want {
 				t.Errorf("IsSettingUpToDate() = %v, want %v", got, tc.want)
 			}
@@ -708,6 +777,43 @@ func TestAreSettingsUpToDate(t *testing.T) {
 			},
 			want: true,
 		},
+		"InheritedValueMatches": {
+			params: v1alpha1.SettingsParameters{
+				Settings: map[string]v1alpha1.SettingParameters{
+					"sonar.cpd.go.minimumtokens": {
+						Value: ptr.To("100"),
+					},
+				},
+			},
+			observation: v1alpha1.SettingsObservation{
+				Settings: map[string]v1alpha1.SettingObservation{
+					"sonar.cpd.go.minimumtokens": {
+						Value:     "100",
+						Inherited: true,
+					},
+				},
+			},
+			want: true,
+		},
+		"InheritedValueWithForcedExplicitStorage": {
+			params: v1alpha1.SettingsParameters{
+				ForceExplicitStorage: ptr.To(true),
+				Settings: map[string]v1alpha1.SettingParameters{
+					"sonar.cpd.go.minimumtokens": {
+						Value: ptr.To("100"),
+					},
+				},
+			},
+			observation: v1alpha1.SettingsObservation{
+				Settings: map[string]v1alpha1.SettingObservation{
+					"sonar.cpd.go.minimumtokens": {
+						Value:     "100",
+						Inherited: true,
+					},
+				},
+			},
+			want: false,
+		},
 		"OneSettingNotUpToDate": {
 			params: v1alpha1.SettingsParameters{
 				Settings: map[string]v1alpha1.SettingParameters{
@@ -786,10 +892,448 @@ func TestAreSettingsUpToDate(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
-			got := AreSettingsUpToDate(tc.params, tc.observation)
+			got := AreSettingsUpToDate(tc.params, nil, tc.observation)
 			if got != tc.want {
 				t.Errorf("AreSettingsUpToDate() = %v, want %v", got, tc.want)
 			}
 		})
 	}
 }
+
+func TestHashSettingParameters(t *testing.T) {
+	t.Parallel()
+
+	value := HashSettingParameters(v1alpha1.SettingParameters{Value: ptr.To("a")})
+
+	if value != HashSettingParameters(v1alpha1.SettingParameters{Value: ptr.To("a")}) {
+		t.Error("HashSettingParameters() is not stable")
+	}
+
+	if value == HashSettingParameters(v1alpha1.SettingParameters{Value: ptr.To("b")}) {
+		t.Error("HashSettingParameters() did not change when the value changed")
+	}
+
+	if value == HashSettingParameters(v1alpha1.SettingParameters{Values: ptr.To([]string{"a"})}) {
+		t.Error("HashSettingParameters() collides between value and values")
+	}
+
+	if HashSettingParameters(v1alpha1.SettingParameters{FieldValues: ptr.To(map[string]string{"a": "1", "b": "2"})}) !=
+		HashSettingParameters(v1alpha1.SettingParameters{FieldValues: ptr.To(map[string]string{"b": "2", "a": "1"})}) {
+		t.Error("HashSettingParameters() is not stable across map ordering")
+	}
+}
+
+func TestCarryOverAppliedValueHashes(t *testing.T) {
+	t.Parallel()
+
+	observation := v1alpha1.SettingsObservation{
+		Settings: map[string]v1alpha1.SettingObservation{
+			"sonar.auth.github.clientSecret.secured": {Secured: true},
+			"sonar.core.serverBaseURL":               {Value: "https://sonarqube.example.com"},
+		},
+	}
+
+	previous := v1alpha1.SettingsObservation{
+		Settings: map[string]v1alpha1.SettingObservation{
+			"sonar.auth.github.clientSecret.secured": {Secured: true, AppliedValueHash: "hash"},
+			"sonar.removed.secured":                  {Secured: true, AppliedValueHash: "other"},
+		},
+	}
+
+	CarryOverAppliedValueHashes(&observation, previous)
+
+	want := v1alpha1.SettingsObservation{
+		Settings: map[string]v1alpha1.SettingObservation{
+			"sonar.auth.github.clientSecret.secured": {Secured: true, AppliedValueHash: "hash"},
+			"sonar.core.serverBaseURL":               {Value: "https://sonarqube.example.com"},
+		},
+	}
+
+	if diff := cmp.Diff(want, observation); diff != "" {
+		t.Errorf("CarryOverAppliedValueHashes() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRedactSecretSourcedSettings(t *testing.T) {
+	t.Parallel()
+
+	bindDnHash := HashSecretKeyVersion("uid/1/bindDn")
+	bindPasswordHash := HashSecretKeyVersion("uid/1/bindPassword")
+	searchBaseHash := HashSecretKeyVersion("uid/1/searchBase")
+
+	resolved := v1alpha1.SettingsParameters{
+		Settings: map[string]v1alpha1.SettingParameters{
+			"ldap.bindDn":              {Value: ptr.To("cn=admin")},
+			"ldap.bindPassword":        {Value: ptr.To("s3cr3t")},
+			"ldap.user.baseDn":         {Value: ptr.To("ou=users")},
+			"sonar.core.serverBaseURL": {Value: ptr.To("https://sonarqube.example.com")},
+		},
+	}
+	secretHashes := map[string]string{
+		"ldap.bindDn":       bindDnHash,
+		"ldap.bindPassword": bindPasswordHash,
+		"ldap.user.baseDn":  searchBaseHash,
+	}
+
+	observation := v1alpha1.SettingsObservation{
+		Settings: map[string]v1alpha1.SettingObservation{
+			"ldap.bindDn":              {Value: "cn=admin", Level: SettingLevelProject, ParentValue: "cn=global"},
+			"ldap.bindPassword":        {Secured: true, AppliedValueHash: "hash"},
+			"ldap.user.baseDn":         {Value: "ou=others", Level: SettingLevelProject},
+			"sonar.core.serverBaseURL": {Value: "https://sonarqube.example.com"},
+		},
+	}
+
+	RedactSecretSourcedSettings(resolved, secretHashes, &observation)
+
+	want := v1alpha1.SettingsObservation{
+		Settings: map[string]v1alpha1.SettingObservation{
+			"ldap.bindDn":              {ValueHash: bindDnHash, Level: SettingLevelProject},
+			"ldap.bindPassword":        {Secured: true, AppliedValueHash: "hash"},
+			"ldap.user.baseDn":         {Level: SettingLevelProject},
+			"sonar.core.serverBaseURL": {Value: "https://sonarqube.example.com"},
+		},
+	}
+
+	if diff := cmp.Diff(want, observation); diff != "" {
+		t.Errorf("RedactSecretSourcedSettings() mismatch (-want +got):\n%s", diff)
+	}
+
+	if !AreSettingsUpToDate(v1alpha1.SettingsParameters{Settings: map[string]v1alpha1.SettingParameters{"ldap.bindDn": resolved.Settings["ldap.bindDn"]}}, secretHashes, observation) {
+		t.Error("AreSettingsUpToDate() = false for the value equal to the Secret, want true")
+	}
+
+	if AreSettingsUpToDate(v1alpha1.SettingsParameters{Settings: map[string]v1alpha1.SettingParameters{"ldap.user.baseDn": resolved.Settings["ldap.user.baseDn"]}}, secretHashes, observation) {
+		t.Error("AreSettingsUpToDate() = true for a value different from the Secret, want false")
+	}
+}
+
+func TestHashSecretKeyVersion(t *testing.T) {
+	t.Parallel()
+
+	if HashSecretKeyVersion("uid/1/key") == HashSecretKeyVersion("uid/2/key") {
+		t.Error("HashSecretKeyVersion() did not change with the version of the Secret")
+	}
+
+	if got := AppliedValueHash(v1alpha1.SettingParameters{Value: ptr.To("s3cr3t")}, HashSecretKeyVersion("uid/1/key")); got != HashSecretKeyVersion("uid/1/key") {
+		t.Errorf("AppliedValueHash() = %q for a value from a Secret, want the hash of the version of the Secret", got)
+	}
+}
+
+func TestValidateSettings(t *testing.T) {
+	t.Parallel()
+
+	definitions := &sonar.SettingsListDefinitions{
+		Definitions: []sonar.SettingDefinition{
+			{Key: "sonar.core.serverBaseURL", Type: "STRING"},
+			{Key: "sonar.forceAuthentication", Type: "BOOLEAN"},
+			{Key: "sonar.dbcleaner.daysBeforeDeletingClosedIssues", Type: "INTEGER"},
+			{Key: "sonar.exclusions", Type: "STRING", MultiValues: true},
+			{Key: "sonar.lf.gravatarServerUrl", Type: "SINGLE_SELECT_LIST", Options: []string{"a", "b"}},
+			{
+				Key:  "sonar.issue.ignore.multicriteria",
+				Type: "PROPERTY_SET",
+				Fields: []sonar.SettingField{
+					{Key: "ruleKey", Type: "STRING"},
+					{Key: "resourceKey", Type: "STRING"},
+				},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		params      v1alpha1.SettingsParameters
+		definitions *sonar.SettingsListDefinitions
+		want        []string
+	}{
+		"Valid": {
+			params: v1alpha1.SettingsParameters{
+				Settings: map[string]v1alpha1.SettingParameters{
+					"sonar.core.serverBaseURL":                       {Value: ptr.To("https://sonarqube.example.com")},
+					"sonar.forceAuthentication":                      {Value: ptr.To("true")},
+					"sonar.dbcleaner.daysBeforeDeletingClosedIssues": {Value: ptr.To("30")},
+					"sonar.exclusions":                               {Values: &[]string{"**/vendor/**"}},
+					"sonar.lf.gravatarServerUrl":                     {Value: ptr.To("a")},
+					"sonar.issue.ignore.multicriteria":               {FieldValues: &map[string]string{"ruleKey": "*", "resourceKey": "**/*"}},
+				},
+			},
+			definitions: definitions,
+			want:        []string{},
+		},
+		"Violations": {
+			params: v1alpha1.SettingsParameters{
+				Settings: map[string]v1alpha1.SettingParameters{
+					"sonar.unknown":                                  {Value: ptr.To("x")},
+					"sonar.forceAuthentication":                      {Value: ptr.To("yes")},
+					"sonar.dbcleaner.daysBeforeDeletingClosedIssues": {Value: ptr.To("thirty")},
+					"sonar.exclusions":                               {Value: ptr.To("**/vendor/**")},
+					"sonar.core.serverBaseURL":                       {Values: &[]string{"https://sonarqube.example.com"}},
+					"sonar.lf.gravatarServerUrl":                     {Value: ptr.To("c")},
+					"sonar.issue.ignore.multicriteria":               {FieldValues: &map[string]string{"ruleKey": "*", "other": "x"}},
+				},
+			},
+			definitions: definitions,
+			want: []string{
+				`sonar.core.serverBaseURL: single-valued setting must use value instead of values`,
+				`sonar.dbcleaner.daysBeforeDeletingClosedIssues: value "thirty" is not a valid integer`,
+				`sonar.exclusions: multi-valued setting must use values instead of value`,
+				`sonar.forceAuthentication: value "yes" is not a boolean`,
+				`sonar.issue.ignore.multicriteria: unknown fields other`,
+				`sonar.lf.gravatarServerUrl: value "c" is not one of a, b`,
+				`sonar.unknown: unknown setting key`,
+			},
+		},
+		"FieldValuesOnPlainSetting": {
+			params: v1alpha1.SettingsParameters{
+				Settings: map[string]v1alpha1.SettingParameters{
+					"sonar.core.serverBaseURL": {FieldValues: &map[string]string{"a": "b"}},
+				},
+			},
+			definitions: definitions,
+			want:        []string{"sonar.core.serverBaseURL: fieldValues can only be used for property set settings, not STRING"},
+		},
+		"NilDefinitions": {
+			params: v1alpha1.SettingsParameters{
+				Settings: map[string]v1alpha1.SettingParameters{
+					"sonar.core.serverBaseURL": {Value: ptr.To("https://sonarqube.example.com")},
+				},
+			},
+			definitions: nil,
+			want:        []string{"sonar.core.serverBaseURL: unknown setting key"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ValidateSettings(tc.params, tc.definitions)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ValidateSettings() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsAdditiveManagementMode(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		mode *string
+		want bool
+	}{
+		"Default":       {mode: nil, want: false},
+		"Authoritative": {mode: ptr.To(SettingsManagementModeAuthoritative), want: false},
+		"Additive":      {mode: ptr.To(SettingsManagementModeAdditive), want: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := IsAdditiveManagementMode(v1alpha1.SettingsParameters{ManagementMode: tc.mode})
+			if got != tc.want {
+				t.Errorf("IsAdditiveManagementMode() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAreAppliedKeysUpToDate(t *testing.T) {
+	t.Parallel()
+
+	params := v1alpha1.SettingsParameters{
+		Settings: map[string]v1alpha1.SettingParameters{
+			"sonar.a": {Value: ptr.To("a")},
+			"sonar.b": {Value: ptr.To("b")},
+		},
+	}
+
+	tests := map[string]struct {
+		appliedKeys  []string
+		wantObsolete []string
+		want         bool
+	}{
+		"UpToDate": {
+			appliedKeys:  []string{"sonar.a", "sonar.b"},
+			wantObsolete: []string{},
+			want:         true,
+		},
+		"NotYetApplied": {
+			appliedKeys:  []string{"sonar.a"},
+			wantObsolete: []string{},
+			want:         false,
+		},
+		"Obsolete": {
+			appliedKeys:  []string{"sonar.a", "sonar.b", "sonar.d", "sonar.c"},
+			wantObsolete: []string{"sonar.c", "sonar.d"},
+			want:         false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			observation := v1alpha1.SettingsObservation{AppliedKeys: tc.appliedKeys}
+
+			if diff := cmp.Diff(tc.wantObsolete, ObsoleteAppliedKeys(params, observation)); diff != "" {
+				t.Errorf("ObsoleteAppliedKeys() mismatch (-want +got):\n%s", diff)
+			}
+
+			if got := AreAppliedKeysUpToDate(params, observation); got != tc.want {
+				t.Errorf("AreAppliedKeysUpToDate() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRecordAndForgetAppliedKeys(t *testing.T) {
+	t.Parallel()
+
+	observation := &v1alpha1.SettingsObservation{}
+
+	RecordAppliedKey(observation, "sonar.b")
+	RecordAppliedKey(observation, "sonar.a")
+	RecordAppliedKey(observation, "sonar.b")
+
+	if diff := cmp.Diff([]string{"sonar.a", "sonar.b"}, observation.AppliedKeys); diff != "" {
+		t.Errorf("RecordAppliedKey() mismatch (-want +got):\n%s", diff)
+	}
+
+	ForgetAppliedKeys(observation, []string{"sonar.a"})
+
+	if diff := cmp.Diff([]string{"sonar.b"}, observation.AppliedKeys); diff != "" {
+		t.Errorf("ForgetAppliedKeys() mismatch (-want +got):\n%s", diff)
+	}
+
+	ForgetAppliedKeys(observation, []string{"sonar.b"})
+
+	if observation.AppliedKeys != nil {
+		t.Errorf("ForgetAppliedKeys() = %v, want nil", observation.AppliedKeys)
+	}
+}
+
+func TestGenerateSettingsParentValuesOptions(t *testing.T) {
+	t.Parallel()
+
+	got := GenerateSettingsParentValuesOptions(&v1alpha1.SettingsParameters{
+		Component: ptr.To("my-project"),
+		Settings: map[string]v1alpha1.SettingParameters{
+			"sonar.b": {Value: ptr.To("b")},
+			"sonar.a": {Value: ptr.To("a")},
+		},
+	})
+
+	want := &sonar.SettingsValuesOption{Keys: []string{"sonar.a", "sonar.b"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateSettingsParentValuesOptions() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSetSettingsLevels(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		observation v1alpha1.SettingsObservation
+		component   *string
+		parents     *sonar.SettingsValues
+		want        v1alpha1.SettingsObservation
+	}{
+		"GlobalSettings": {
+			observation: v1alpha1.SettingsObservation{
+				Settings: map[string]v1alpha1.SettingObservation{
+					"sonar.stored":  {Value: "a"},
+					"sonar.default": {Value: "b", Inherited: true},
+					"sonar.secured": {Secured: true},
+				},
+			},
+			want: v1alpha1.SettingsObservation{
+				Settings: map[string]v1alpha1.SettingObservation{
+					"sonar.stored":  {Value: "a", Level: SettingLevelGlobal},
+					"sonar.default": {Value: "b", Inherited: true, Level: SettingLevelDefault},
+					"sonar.secured": {Secured: true},
+				},
+			},
+		},
+		"ComponentSettings": {
+			observation: v1alpha1.SettingsObservation{
+				Settings: map[string]v1alpha1.SettingObservation{
+					"sonar.project":  {Value: "a"},
+					"sonar.global":   {Values: []string{"b"}, Inherited: true},
+					"sonar.default":  {Value: "c", Inherited: true},
+					"sonar.multiset": {FieldValues: map[string]string{"k": "v"}, Inherited: true},
+				},
+			},
+			component: ptr.To("my-project"),
+			parents: &sonar.SettingsValues{
+				Settings: []sonar.SettingValue{
+					{Key: "sonar.project", Value: "global"},
+					{Key: "sonar.global", Values: []string{"b"}},
+					{Key: "sonar.default", Value: "c", Inherited: true},
+					{Key: "sonar.multiset", FieldValues: []map[string]string{{"k": "v"}}},
+				},
+			},
+			want: v1alpha1.SettingsObservation{
+				Settings: map[string]v1alpha1.SettingObservation{
+					"sonar.project":  {Value: "a", Level: SettingLevelProject, ParentValue: "global"},
+					"sonar.global":   {Values: []string{"b"}, Inherited: true, Level: SettingLevelGlobal, ParentValues: []string{"b"}},
+					"sonar.default":  {Value: "c", Inherited: true, Level: SettingLevelDefault, ParentValue: "c"},
+					"sonar.multiset": {FieldValues: map[string]string{"k": "v"}, Inherited: true, Level: SettingLevelGlobal, ParentFieldValues: map[string]string{"k": "v"}},
+				},
+			},
+		},
+		"ParentsIgnoredWithoutComponent": {
+			observation: v1alpha1.SettingsObservation{
+				Settings: map[string]v1alpha1.SettingObservation{
+					"sonar.stored": {Value: "a"},
+				},
+			},
+			parents: &sonar.SettingsValues{
+				Settings: []sonar.SettingValue{{Key: "sonar.stored", Value: "b"}},
+			},
+			want: v1alpha1.SettingsObservation{
+				Settings: map[string]v1alpha1.SettingObservation{
+					"sonar.stored": {Value: "a", Level: SettingLevelGlobal},
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			SetSettingsLevels(&tc.observation, tc.component, tc.parents)
+
+			if diff := cmp.Diff(tc.want, tc.observation); diff != "" {
+				t.Errorf("SetSettingsLevels() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNeedsExplicitStorage(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		force       *bool
+		observation v1alpha1.SettingObservation
+		want        bool
+	}{
+		"NotForced":          {force: nil, observation: v1alpha1.SettingObservation{Inherited: true}, want: false},
+		"ForcedAndInherited": {force: ptr.To(true), observation: v1alpha1.SettingObservation{Inherited: true}, want: true},
+		"ForcedAndStored":    {force: ptr.To(true), observation: v1alpha1.SettingObservation{}, want: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := NeedsExplicitStorage(v1alpha1.SettingsParameters{ForceExplicitStorage: tc.force}, tc.observation)
+			if got != tc.want {
+				t.Errorf("NeedsExplicitStorage() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
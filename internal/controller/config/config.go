@@ -17,19 +17,29 @@ limitations under the License.
 package config
 
 import (
+	"context"
+
 	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/providerconfig"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+	"github.com/crossplane/provider-sonarqube/internal/clients/common"
+	"github.com/crossplane/provider-sonarqube/internal/clients/instance"
 )
 
-// Setup adds a controller that reconciles ProviderConfigs by accounting for
-// their current usage.
+// Setup adds the controllers that reconcile ProviderConfigs by accounting for
+// their current usage and by periodically checking their health.
 func Setup(mgr ctrl.Manager, opts controller.Options) error {
 	err := setupNamespacedProviderConfig(mgr, opts)
 	if err != nil {
@@ -51,6 +61,7 @@ func setupNamespacedProviderConfig(mgr ctrl.Manager, opts controller.Options) er
 		},
 		&v1alpha1.ProviderConfig{},
 		&v1alpha1.ProviderConfigUsage{},
+		func() healthCheckedProviderConfig { return &v1alpha1.ProviderConfig{} },
 	)
 }
 
@@ -66,6 +77,7 @@ func setupClusterProviderConfig(mgr ctrl.Manager, opts controller.Options) error
 		},
 		&v1alpha1.ClusterProviderConfig{},
 		&v1alpha1.ClusterProviderConfigUsage{},
+		func() healthCheckedProviderConfig { return &v1alpha1.ClusterProviderConfig{} },
 	)
 }
 
@@ -76,6 +88,7 @@ func setupProviderConfig(
 	kinds resource.ProviderConfigKinds,
 	config client.Object,
 	usage client.Object,
+	newConfig func() healthCheckedProviderConfig,
 ) error {
 	name := providerconfig.ControllerName(groupKind)
 
@@ -83,10 +96,64 @@ func setupProviderConfig(
 		providerconfig.WithLogger(opts.Logger.WithValues("controller", name)),
 		providerconfig.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
 
-	return ctrl.NewControllerManagedBy(mgr).
+	err := ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(opts.ForControllerRuntime()).
 		For(config).
 		Watches(usage, &resource.EnqueueRequestForProviderConfig{}).
 		Complete(ratelimiter.NewReconciler(name, reconciler, opts.GlobalRateLimiter))
+	if err != nil {
+		return err
+	}
+
+	return setupHealthCheck(mgr, opts, groupKind, config, newConfig)
+}
+
+func setupHealthCheck(
+	mgr ctrl.Manager,
+	opts controller.Options,
+	groupKind string,
+	config client.Object,
+	newConfig func() healthCheckedProviderConfig,
+) error {
+	name := "health/" + providerconfig.ControllerName(groupKind)
+
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = DefaultHealthCheckInterval
+	}
+
+	reconciler := &healthReconciler{
+		kube:            mgr.GetClient(),
+		log:             opts.Logger.WithValues("controller", name),
+		interval:        interval,
+		newConfig:       newConfig,
+		getConfig:       common.ConfigFromProviderConfig,
+		newServerClient: instance.NewServerClient,
+	}
+
+	// Status updates do not change the generation, the health is checked again after the interval.
+	// The cached SonarQube client of a deleted ProviderConfig is released with its credentials, and its rate limiter with it.
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(opts.ForControllerRuntime()).
+		For(config, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(config, invalidateClientOnDelete(releaseProviderConfig)).
+		Complete(ratelimiter.NewReconciler(name, reconciler, opts.GlobalRateLimiter))
+}
+
+// releaseProviderConfig invalidates the cached SonarQube client and the rate limiter of a deleted ProviderConfig.
+func releaseProviderConfig(providerConfig client.Object) {
+	common.InvalidateClient(providerConfig.GetUID())
+	common.InvalidateRateLimiter(common.ProviderConfigKey(providerConfig))
+}
+
+// invalidateClientOnDelete returns an event handler invalidating the cached SonarQube client of a ProviderConfig when it is deleted.
+// The deleted ProviderConfig cannot be read by a reconciler anymore, so it is taken from the delete event.
+func invalidateClientOnDelete(invalidate func(providerConfig client.Object)) handler.Funcs {
+	return handler.Funcs{
+		DeleteFunc: func(_ context.Context, e ctrlevent.DeleteEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			invalidate(e.Object)
+		},
+	}
 }
@@ -0,0 +1,200 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+	"github.com/crossplane/provider-sonarqube/internal/clients/common"
+	"github.com/crossplane/provider-sonarqube/internal/clients/instance"
+	"github.com/crossplane/provider-sonarqube/internal/helpers"
+)
+
+const (
+	errGetProviderConfig     = "cannot get provider config"
+	errUpdateHealthStatus    = "cannot update provider config health status"
+	errUnknownProviderConfig = "unknown provider config type"
+	errNewServerClient       = "cannot create SonarQube client"
+	errCheckServerStatus     = "cannot get the status of the SonarQube instance"
+	errValidateCredentials   = "cannot validate the credentials against the SonarQube instance"
+	msgServerNotUp           = "the SonarQube instance is not up, its status is %s"
+	msgInvalidCredentials    = "the SonarQube instance rejected the credentials"
+)
+
+// DefaultHealthCheckInterval is the interval between two health checks of a ProviderConfig when no poll interval is configured.
+const DefaultHealthCheckInterval = time.Minute
+
+// A healthCheckedProviderConfig is a ProviderConfig or ClusterProviderConfig whose health is checked.
+type healthCheckedProviderConfig interface {
+	client.Object
+	SetConditions(c ...xpv1.Condition)
+}
+
+// A healthReconciler periodically checks that the SonarQube instance of a ProviderConfig can be reached and accepts its credentials.
+type healthReconciler struct {
+	kube      client.Client
+	log       logging.Logger
+	interval  time.Duration
+	newConfig func() healthCheckedProviderConfig
+	// getConfig builds the SonarQube client configuration of a ProviderConfig
+	getConfig func(ctx context.Context, kube client.Client, providerConfig client.Object) (*common.Config, error)
+	// newServerClient creates the client used to probe the SonarQube instance
+	newServerClient func(config common.Config) (instance.ServerClient, error)
+}
+
+// Reconcile checks the health of a ProviderConfig and records the result in its Ready and Authenticated conditions.
+func (r *healthReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+
+	providerConfig := r.newConfig()
+
+	err := r.kube.Get(ctx, req.NamespacedName, providerConfig)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(client.IgnoreNotFound(err), errGetProviderConfig)
+	}
+
+	if meta.WasDeleted(providerConfig) {
+		return reconcile.Result{}, nil
+	}
+
+	status, err := healthStatus(providerConfig)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	serverClient, err := r.newConfiguredServerClient(ctx, providerConfig)
+	if err != nil {
+		log.Debug("Cannot build the SonarQube client", "error", err)
+		providerConfig.SetConditions(
+			v1alpha1.NotReady(v1alpha1.ReasonInvalidConfig).WithMessage(err.Error()),
+			v1alpha1.AuthenticationUnknown(v1alpha1.ReasonInvalidConfig),
+		)
+
+		return reconcile.Result{RequeueAfter: r.interval}, errors.Wrap(r.kube.Status().Update(ctx, providerConfig), errUpdateHealthStatus)
+	}
+
+	ready, authenticated, server := probeServer(serverClient)
+	server.LastProbeTime = ptr.To(metav1.Now())
+
+	status.Server = server
+	providerConfig.SetConditions(ready, authenticated)
+
+	return reconcile.Result{RequeueAfter: r.interval}, errors.Wrap(r.kube.Status().Update(ctx, providerConfig), errUpdateHealthStatus)
+}
+
+// newConfiguredServerClient builds the SonarQube client configuration of a ProviderConfig and the client used to probe its instance.
+func (r *healthReconciler) newConfiguredServerClient(ctx context.Context, providerConfig client.Object) (instance.ServerClient, error) {
+	config, err := r.getConfig(ctx, r.kube, providerConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	serverClient, err := r.newServerClient(*config)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewServerClient)
+	}
+
+	return serverClient, nil
+}
+
+// probeServer probes the status, version, edition and authentication of a SonarQube instance.
+// It returns the Ready and Authenticated conditions of the ProviderConfig and the observed state of the instance.
+//
+//nolint:bodyclose // closed via helpers.CloseBody
+func probeServer(serverClient instance.ServerClient) (xpv1.Condition, xpv1.Condition, *v1alpha1.ServerObservation) {
+	server := &v1alpha1.ServerObservation{}
+
+	systemStatus, resp, err := serverClient.Status()
+	helpers.CloseBody(resp)
+
+	if err != nil {
+		return v1alpha1.NotReady(v1alpha1.ReasonUnreachable).WithMessage(errors.Wrap(err, errCheckServerStatus).Error()),
+			v1alpha1.AuthenticationUnknown(v1alpha1.ReasonUnreachable),
+			server
+	}
+
+	server.Status = systemStatus.Status
+	server.Version = systemStatus.Version
+
+	// The version is also returned by api/system/status, api/server/version is only used when it is missing.
+	if server.Version == "" {
+		version, resp, err := serverClient.Version()
+		helpers.CloseBody(resp)
+
+		if err == nil && version != nil {
+			server.Version = *version
+		}
+	}
+
+	// The edition is informational, failing to get it does not make the ProviderConfig unhealthy.
+	global, resp, err := serverClient.Global()
+	helpers.CloseBody(resp)
+
+	if err == nil && global != nil {
+		server.Edition = global.Edition
+	}
+
+	validation, resp, err := serverClient.Validate()
+	helpers.CloseBody(resp)
+
+	var authenticated xpv1.Condition
+
+	switch {
+	case resp != nil && (resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden):
+		authenticated = v1alpha1.Unauthenticated().WithMessage(msgInvalidCredentials)
+	case err != nil:
+		authenticated = v1alpha1.AuthenticationUnknown(v1alpha1.ReasonUnreachable).WithMessage(errors.Wrap(err, errValidateCredentials).Error())
+	case validation == nil || !validation.Valid:
+		authenticated = v1alpha1.Unauthenticated().WithMessage(msgInvalidCredentials)
+	default:
+		authenticated = v1alpha1.Authenticated()
+	}
+
+	switch {
+	case server.Status != instance.ServerStatusUp:
+		return v1alpha1.NotReady(v1alpha1.ReasonServerNotUp).WithMessage(errors.Errorf(msgServerNotUp, server.Status).Error()), authenticated, server
+	case authenticated.Status != corev1.ConditionTrue:
+		return v1alpha1.NotReady(authenticated.Reason).WithMessage(authenticated.Message), authenticated, server
+	default:
+		return xpv1.Available(), authenticated, server
+	}
+}
+
+// healthStatus returns the status of a ProviderConfig or ClusterProviderConfig.
+func healthStatus(providerConfig client.Object) (*v1alpha1.ProviderConfigStatus, error) {
+	switch pc := providerConfig.(type) {
+	case *v1alpha1.ProviderConfig:
+		return &pc.Status, nil
+	case *v1alpha1.ClusterProviderConfig:
+		return &pc.Status, nil
+	default:
+		return nil, errors.New(errUnknownProviderConfig)
+	}
+}
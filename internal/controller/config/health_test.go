@@ -0,0 +1,375 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/boxboxjason/sonarqube-client-go/sonar"
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	ctrlevent "sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+	"github.com/crossplane/provider-sonarqube/internal/clients/common"
+	"github.com/crossplane/provider-sonarqube/internal/clients/instance"
+	"github.com/crossplane/provider-sonarqube/internal/fake"
+)
+
+var errBoom = errors.New("boom")
+
+// newHealthyServerClient returns a server client of a running SonarQube instance accepting the credentials.
+func newHealthyServerClient() *fake.MockServerClient {
+	return &fake.MockServerClient{
+		StatusFn: func() (*sonar.SystemStatus, *http.Response, error) {
+			return &sonar.SystemStatus{Status: "UP", Version: "2025.1.0.102418"}, nil, nil
+		},
+		VersionFn: func() (*string, *http.Response, error) {
+			return ptr.To("2025.1.0.102418"), nil, nil
+		},
+		GlobalFn: func() (*sonar.NavigationGlobal, *http.Response, error) {
+			return &sonar.NavigationGlobal{Edition: "developer"}, nil, nil
+		},
+		ValidateFn: func() (*sonar.AuthenticationValidation, *http.Response, error) {
+			return &sonar.AuthenticationValidation{Valid: true}, nil, nil
+		},
+	}
+}
+
+// withoutMessage removes the message of a condition, conditions are compared by type, status and reason.
+func withoutMessage(c xpv1.Condition) xpv1.Condition {
+	c.Message = ""
+
+	return c
+}
+
+func TestProbeServer(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		ready         xpv1.Condition
+		authenticated xpv1.Condition
+		server        *v1alpha1.ServerObservation
+	}
+
+	tests := map[string]struct {
+		client func() *fake.MockServerClient
+		want   want
+	}{
+		"Healthy": {
+			client: newHealthyServerClient,
+			want: want{
+				ready:         xpv1.Available(),
+				authenticated: v1alpha1.Authenticated(),
+				server:        &v1alpha1.ServerObservation{Version: "2025.1.0.102418", Edition: "developer", Status: "UP"},
+			},
+		},
+		"Unreachable": {
+			client: func() *fake.MockServerClient {
+				c := newHealthyServerClient()
+				c.StatusFn = func() (*sonar.SystemStatus, *http.Response, error) { return nil, nil, errBoom }
+
+				return c
+			},
+			want: want{
+				ready:         v1alpha1.NotReady(v1alpha1.ReasonUnreachable),
+				authenticated: v1alpha1.AuthenticationUnknown(v1alpha1.ReasonUnreachable),
+				server:        &v1alpha1.ServerObservation{},
+			},
+		},
+		"ServerNotUp": {
+			client: func() *fake.MockServerClient {
+				c := newHealthyServerClient()
+				c.StatusFn = func() (*sonar.SystemStatus, *http.Response, error) {
+					return &sonar.SystemStatus{Status: "DB_MIGRATION_NEEDED", Version: "10.8.1"}, nil, nil
+				}
+
+				return c
+			},
+			want: want{
+				ready:         v1alpha1.NotReady(v1alpha1.ReasonServerNotUp),
+				authenticated: v1alpha1.Authenticated(),
+				server:        &v1alpha1.ServerObservation{Version: "10.8.1", Edition: "developer", Status: "DB_MIGRATION_NEEDED"},
+			},
+		},
+		"VersionFromServerVersion": {
+			client: func() *fake.MockServerClient {
+				c := newHealthyServerClient()
+				c.StatusFn = func() (*sonar.SystemStatus, *http.Response, error) {
+					return &sonar.SystemStatus{Status: "UP"}, nil, nil
+				}
+
+				return c
+			},
+			want: want{
+				ready:         xpv1.Available(),
+				authenticated: v1alpha1.Authenticated(),
+				server:        &v1alpha1.ServerObservation{Version: "2025.1.0.102418", Edition: "developer", Status: "UP"},
+			},
+		},
+		"EditionUnavailable": {
+			client: func() *fake.MockServerClient {
+				c := newHealthyServerClient()
+				c.GlobalFn = func() (*sonar.NavigationGlobal, *http.Response, error) { return nil, nil, errBoom }
+
+				return c
+			},
+			want: want{
+				ready:         xpv1.Available(),
+				authenticated: v1alpha1.Authenticated(),
+				server:        &v1alpha1.ServerObservation{Version: "2025.1.0.102418", Status: "UP"},
+			},
+		},
+		"InvalidCredentials": {
+			client: func() *fake.MockServerClient {
+				c := newHealthyServerClient()
+				c.ValidateFn = func() (*sonar.AuthenticationValidation, *http.Response, error) {
+					return &sonar.AuthenticationValidation{Valid: false}, nil, nil
+				}
+
+				return c
+			},
+			want: want{
+				ready:         v1alpha1.NotReady(v1alpha1.ReasonUnauthenticated),
+				authenticated: v1alpha1.Unauthenticated(),
+				server:        &v1alpha1.ServerObservation{Version: "2025.1.0.102418", Edition: "developer", Status: "UP"},
+			},
+		},
+		"Unauthorized": {
+			client: func() *fake.MockServerClient {
+				c := newHealthyServerClient()
+				c.ValidateFn = func() (*sonar.AuthenticationValidation, *http.Response, error) {
+					return nil, &http.Response{StatusCode: http.StatusUnauthorized, Body: http.NoBody}, errBoom
+				}
+
+				return c
+			},
+			want: want{
+				ready:         v1alpha1.NotReady(v1alpha1.ReasonUnauthenticated),
+				authenticated: v1alpha1.Unauthenticated(),
+				server:        &v1alpha1.ServerObservation{Version: "2025.1.0.102418", Edition: "developer", Status: "UP"},
+			},
+		},
+		"ValidateFails": {
+			client: func() *fake.MockServerClient {
+				c := newHealthyServerClient()
+				c.ValidateFn = func() (*sonar.AuthenticationValidation, *http.Response, error) { return nil, nil, errBoom }
+
+				return c
+			},
+			want: want{
+				ready:         v1alpha1.NotReady(v1alpha1.ReasonUnreachable),
+				authenticated: v1alpha1.AuthenticationUnknown(v1alpha1.ReasonUnreachable),
+				server:        &v1alpha1.ServerObservation{Version: "2025.1.0.102418", Edition: "developer", Status: "UP"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ready, authenticated, server := probeServer(tc.client())
+
+			if diff := cmp.Diff(tc.want.ready, withoutMessage(ready)); diff != "" {
+				t.Errorf("probeServer() ready mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.authenticated, withoutMessage(authenticated)); diff != "" {
+				t.Errorf("probeServer() authenticated mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.server, server); diff != "" {
+				t.Errorf("probeServer() server mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHealthReconcile(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	_ = v1alpha1.SchemeBuilder.AddToScheme(scheme)
+
+	newProviderConfig := func() *v1alpha1.ProviderConfig {
+		return &v1alpha1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "sonarqube", Namespace: "default"},
+			Spec:       v1alpha1.ProviderConfigSpec{BaseURL: "https://sonarqube.example.com/api"},
+		}
+	}
+
+	type want struct {
+		result        reconcile.Result
+		err           error
+		ready         xpv1.Condition
+		authenticated xpv1.Condition
+		server        *v1alpha1.ServerObservation
+	}
+
+	tests := map[string]struct {
+		objects         []client.Object
+		getConfig       func(ctx context.Context, kube client.Client, providerConfig client.Object) (*common.Config, error)
+		newServerClient func(config common.Config) (instance.ServerClient, error)
+		want            want
+	}{
+		"NotFound": {
+			want: want{
+				result: reconcile.Result{},
+			},
+		},
+		"InvalidConfig": {
+			objects: []client.Object{newProviderConfig()},
+			getConfig: func(ctx context.Context, kube client.Client, providerConfig client.Object) (*common.Config, error) {
+				return nil, errBoom
+			},
+			want: want{
+				result:        reconcile.Result{RequeueAfter: time.Minute},
+				ready:         v1alpha1.NotReady(v1alpha1.ReasonInvalidConfig),
+				authenticated: v1alpha1.AuthenticationUnknown(v1alpha1.ReasonInvalidConfig),
+			},
+		},
+		"InvalidClient": {
+			objects: []client.Object{newProviderConfig()},
+			newServerClient: func(config common.Config) (instance.ServerClient, error) {
+				return nil, errBoom
+			},
+			want: want{
+				result:        reconcile.Result{RequeueAfter: time.Minute},
+				ready:         v1alpha1.NotReady(v1alpha1.ReasonInvalidConfig),
+				authenticated: v1alpha1.AuthenticationUnknown(v1alpha1.ReasonInvalidConfig),
+			},
+		},
+		"Healthy": {
+			objects: []client.Object{newProviderConfig()},
+			want: want{
+				result:        reconcile.Result{RequeueAfter: time.Minute},
+				ready:         xpv1.Available(),
+				authenticated: v1alpha1.Authenticated(),
+				server:        &v1alpha1.ServerObservation{Version: "2025.1.0.102418", Edition: "developer", Status: "UP"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			kube := fakeclient.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tc.objects...).
+				WithStatusSubresource(&v1alpha1.ProviderConfig{}).
+				Build()
+
+			getConfig := tc.getConfig
+			if getConfig == nil {
+				getConfig = func(ctx context.Context, kube client.Client, providerConfig client.Object) (*common.Config, error) {
+					return &common.Config{}, nil
+				}
+			}
+
+			newServerClient := tc.newServerClient
+			if newServerClient == nil {
+				newServerClient = func(config common.Config) (instance.ServerClient, error) {
+					return newHealthyServerClient(), nil
+				}
+			}
+
+			r := &healthReconciler{
+				kube:            kube,
+				log:             logging.NewNopLogger(),
+				interval:        time.Minute,
+				newConfig:       func() healthCheckedProviderConfig { return &v1alpha1.ProviderConfig{} },
+				getConfig:       getConfig,
+				newServerClient: newServerClient,
+			}
+
+			key := types.NamespacedName{Name: "sonarqube", Namespace: "default"}
+
+			result, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Reconcile() error mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.result, result); diff != "" {
+				t.Errorf("Reconcile() result mismatch (-want +got):\n%s", diff)
+			}
+
+			if len(tc.objects) == 0 {
+				return
+			}
+
+			got := &v1alpha1.ProviderConfig{}
+			if err := kube.Get(context.Background(), key, got); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(tc.want.ready, withoutMessage(got.GetCondition(xpv1.TypeReady))); diff != "" {
+				t.Errorf("Reconcile() ready mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.authenticated, withoutMessage(got.GetCondition(v1alpha1.TypeAuthenticated))); diff != "" {
+				t.Errorf("Reconcile() authenticated mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.server, got.Status.Server, cmpopts.IgnoreFields(v1alpha1.ServerObservation{}, "LastProbeTime")); diff != "" {
+				t.Errorf("Reconcile() server mismatch (-want +got):\n%s", diff)
+			}
+
+			if tc.want.server != nil && got.Status.Server.LastProbeTime == nil {
+				t.Error("Reconcile() did not record the time of the health check")
+			}
+		})
+	}
+}
+
+func TestInvalidateClientOnDelete(t *testing.T) {
+	t.Parallel()
+
+	var invalidated []types.UID
+
+	h := invalidateClientOnDelete(func(providerConfig client.Object) {
+		invalidated = append(invalidated, providerConfig.GetUID())
+	})
+
+	providerConfig := &v1alpha1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default", UID: "pc-uid"}}
+
+	h.Update(context.Background(), ctrlevent.UpdateEvent{ObjectOld: providerConfig, ObjectNew: providerConfig}, nil)
+
+	if len(invalidated) != 0 {
+		t.Errorf("Update() invalidated %v, want no client invalidated", invalidated)
+	}
+
+	h.Delete(context.Background(), ctrlevent.DeleteEvent{Object: providerConfig}, nil)
+
+	if diff := cmp.Diff([]types.UID{"pc-uid"}, invalidated); diff != "" {
+		t.Errorf("Delete() invalidated clients mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,365 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emailconfiguration
+
+import (
+	"context"
+
+	stderrors "errors"
+
+	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/statemetrics"
+
+	v1alpha1 "github.com/crossplane/provider-sonarqube/apis/instance/v1alpha1"
+	apisv1alpha1 "github.com/crossplane/provider-sonarqube/apis/v1alpha1"
+	"github.com/crossplane/provider-sonarqube/internal/clients/common"
+	"github.com/crossplane/provider-sonarqube/internal/clients/instance"
+	"github.com/crossplane/provider-sonarqube/internal/helpers"
+)
+
+const (
+	errNotEmailConfiguration = "managed resource is not an EmailConfiguration custom resource"
+	errTrackPCUsage          = "cannot track ProviderConfig usage"
+	errGetPC                 = "cannot get ProviderConfig"
+	errNewClient             = "cannot create SonarQube client"
+
+	errGetPassword   = "cannot get SMTP password from secret"
+	errGetValues     = "failed to get email settings values"
+	errResetSettings = "failed to reset email settings"
+)
+
+// SetupGated adds a controller that reconciles EmailConfiguration managed resources with safe-start support.
+func SetupGated(mgr ctrl.Manager, o controller.Options) error {
+	o.Gate.Register(func() {
+		err := Setup(mgr, o)
+		if err != nil {
+			panic(errors.Wrap(err, "cannot setup EmailConfiguration controller"))
+		}
+	}, v1alpha1.EmailConfigurationGroupVersionKind)
+
+	return nil
+}
+
+func Setup(mgr ctrl.Manager, opts controller.Options) error {
+	name := managed.ControllerName(v1alpha1.EmailConfigurationGroupKind)
+
+	reconcilerOpts := []managed.ReconcilerOption{
+		managed.WithExternalConnector(&connector{
+			kube:                 mgr.GetClient(),
+			usage:                resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newSettingsServiceFn: instance.NewSettingsClient,
+			newEmailsServiceFn:   instance.NewEmailsClient,
+		}),
+		managed.WithLogger(opts.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(opts.PollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+	}
+
+	if opts.Features.Enabled(feature.EnableBetaManagementPolicies) {
+		reconcilerOpts = append(reconcilerOpts, managed.WithManagementPolicies())
+	}
+
+	if opts.Features.Enabled(feature.EnableAlphaChangeLogs) {
+		reconcilerOpts = append(reconcilerOpts, managed.WithChangeLogger(opts.ChangeLogOptions.ChangeLogger))
+	}
+
+	if opts.MetricOptions != nil {
+		reconcilerOpts = append(reconcilerOpts, managed.WithMetricRecorder(opts.MetricOptions.MRMetrics))
+	}
+
+	if opts.MetricOptions != nil && opts.MetricOptions.MRStateMetrics != nil {
+		stateMetricsRecorder := statemetrics.NewMRStateRecorder(
+			mgr.GetClient(), opts.Logger, opts.MetricOptions.MRStateMetrics, &v1alpha1.EmailConfigurationList{}, opts.MetricOptions.PollStateMetricInterval,
+		)
+
+		err := mgr.Add(stateMetricsRecorder)
+		if err != nil {
+			return errors.Wrap(err, "cannot register MR state metrics recorder for kind v1alpha1.EmailConfigurationList")
+		}
+	}
+
+	reconciler := managed.NewReconciler(mgr, resource.ManagedKind(v1alpha1.EmailConfigurationGroupVersionKind), reconcilerOpts...)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(opts.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.EmailConfiguration{}).
+		Complete(ratelimiter.NewReconciler(name, reconciler, opts.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube                 client.Client
+	usage                *resource.ProviderConfigUsageTracker
+	newSettingsServiceFn func(config common.Config) (instance.SettingsClient, error)
+	newEmailsServiceFn   func(config common.Config) (instance.EmailsClient, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, managedResource resource.Managed) (managed.ExternalClient, error) {
+	emailConfiguration, ok := managedResource.(*v1alpha1.EmailConfiguration)
+	if !ok {
+		return nil, errors.New(errNotEmailConfiguration)
+	}
+
+	err := c.usage.Track(ctx, emailConfiguration)
+	if err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	// Switch to ModernManaged resource to get ProviderConfigRef
+	m, isValid := managedResource.(resource.ModernManaged)
+	if !isValid {
+		return nil, errors.New("managed resource is not a ModernManaged")
+	}
+
+	config, err := common.GetConfig(ctx, c.kube, m)
+	if err != nil || config == nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	settingsClient, err := c.newSettingsServiceFn(*config)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	emailsClient, err := c.newEmailsServiceFn(*config)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{
+		kube:           c.kube,
+		settingsClient: settingsClient,
+		emailsClient:   emailsClient,
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	// kube is used to read the Secret holding the SMTP password
+	kube client.Client
+	// settingsClient is used to interact with SonarQube Settings API
+	settingsClient instance.SettingsClient
+	// emailsClient is used to interact with SonarQube Emails API
+	emailsClient instance.EmailsClient
+}
+
+// Observe checks if the email configuration exists in SonarQube and if it matches the desired state specified by the managed resource.
+// The email configuration is considered to exist as long as the SMTP host is set.
+func (c *external) Observe(ctx context.Context, managedResource resource.Managed) (managed.ExternalObservation, error) {
+	emailConfiguration, ok := managedResource.(*v1alpha1.EmailConfiguration)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotEmailConfiguration)
+	}
+
+	observedValues, resp, err := c.settingsClient.Values(instance.GenerateEmailConfigurationValuesOptions()) //nolint:bodyclose // closed via helpers.CloseBody
+	defer helpers.CloseBody(resp)
+
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetValues)
+	}
+
+	// The configuration hash and the test email result are only known to the provider, carry them over.
+	observation := instance.GenerateEmailConfigurationObservation(observedValues)
+	observation.ConfigurationHash = emailConfiguration.Status.AtProvider.ConfigurationHash
+	observation.TestEmail = emailConfiguration.Status.AtProvider.TestEmail
+	emailConfiguration.Status.AtProvider = observation
+
+	if !instance.IsEmailConfigurationConfigured(observation) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	// Deleting: there is no need to resolve the desired state.
+	if !emailConfiguration.DeletionTimestamp.IsZero() {
+		return managed.ExternalObservation{ResourceExists: true}, nil
+	}
+
+	settings, hash, err := c.desiredSettings(ctx, emailConfiguration)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	emailConfiguration.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: instance.IsEmailConfigurationUpToDate(settings, hash, observation),
+	}, nil
+}
+
+// Create applies the email settings in SonarQube.
+// The status written by Create is not persisted, so the hash of the configuration is only recorded, and the test email only sent,
+// by the Update that follows: the first Observe finds no configuration hash.
+func (c *external) Create(ctx context.Context, managedResource resource.Managed) (managed.ExternalCreation, error) {
+	emailConfiguration, ok := managedResource.(*v1alpha1.EmailConfiguration)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotEmailConfiguration)
+	}
+
+	emailConfiguration.SetConditions(xpv1.Creating())
+
+	_, err := c.apply(ctx, emailConfiguration)
+
+	return managed.ExternalCreation{}, err
+}
+
+// Update applies the email configuration in SonarQube, records the hash of the applied configuration and sends a test email if requested.
+// All the settings are set again, as the value of secured settings cannot be compared.
+func (c *external) Update(ctx context.Context, managedResource resource.Managed) (managed.ExternalUpdate, error) {
+	emailConfiguration, ok := managedResource.(*v1alpha1.EmailConfiguration)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotEmailConfiguration)
+	}
+
+	hash, err := c.apply(ctx, emailConfiguration)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	emailConfiguration.Status.AtProvider.ConfigurationHash = hash
+
+	if emailConfiguration.Spec.ForProvider.TestEmail != nil {
+		emailConfiguration.Status.AtProvider.TestEmail = c.sendTestEmail(*emailConfiguration.Spec.ForProvider.TestEmail)
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete resets all the email settings in SonarQube.
+func (c *external) Delete(ctx context.Context, managedResource resource.Managed) (managed.ExternalDelete, error) {
+	emailConfiguration, ok := managedResource.(*v1alpha1.EmailConfiguration)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotEmailConfiguration)
+	}
+
+	emailConfiguration.SetConditions(xpv1.Deleting())
+
+	resp, err := c.settingsClient.Reset(instance.GenerateSettingsResetOptionsFromList(instance.EmailSettingKeys, nil)) //nolint:bodyclose // closed via helpers.CloseBody
+	defer helpers.CloseBody(resp)
+
+	if err != nil {
+		return managed.ExternalDelete{}, errors.Wrap(err, errResetSettings)
+	}
+
+	return managed.ExternalDelete{}, nil
+}
+
+// Disconnect is called when the external resource is disconnected from the provider. There is no cleanup to perform.
+func (c *external) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// desiredSettings resolves the SMTP password and returns the desired email settings and their hash.
+func (c *external) desiredSettings(ctx context.Context, emailConfiguration *v1alpha1.EmailConfiguration) (map[string]string, string, error) {
+	var (
+		password        *string
+		passwordVersion string
+	)
+
+	if selector := emailConfiguration.Spec.ForProvider.PasswordSecretRef; selector != nil {
+		value, err := common.GetTokenValueFromLocalSecret(ctx, c.kube, emailConfiguration, selector)
+		if err != nil {
+			return nil, "", errors.Wrap(err, errGetPassword)
+		}
+
+		passwordVersion, err = common.GetLocalSecretKeyVersion(ctx, c.kube, emailConfiguration, selector)
+		if err != nil {
+			return nil, "", errors.Wrap(err, errGetPassword)
+		}
+
+		password = value
+	}
+
+	settings := instance.GenerateEmailConfigurationSettings(emailConfiguration.Spec.ForProvider, password)
+
+	return settings, instance.HashEmailConfiguration(settings, passwordVersion), nil
+}
+
+// apply sets the desired email settings, resets the ones that are no longer desired and returns the hash of the applied settings.
+func (c *external) apply(ctx context.Context, emailConfiguration *v1alpha1.EmailConfiguration) (string, error) {
+	settings, hash, err := c.desiredSettings(ctx, emailConfiguration)
+	if err != nil {
+		return "", err
+	}
+
+	var errs []error
+
+	for _, setOptions := range instance.GenerateEmailConfigurationSetOptions(settings) {
+		resp, err := c.settingsClient.Set(setOptions) //nolint:bodyclose // closed via helpers.CloseBody
+		if err != nil {
+			errs = append(errs, errors.Errorf("failed to set setting %s: %s", setOptions.Key, err.Error()))
+		}
+
+		helpers.CloseBody(resp)
+	}
+
+	resetOptions := instance.GenerateEmailConfigurationResetOptions(settings)
+	if resetOptions != nil {
+		resp, err := c.settingsClient.Reset(resetOptions) //nolint:bodyclose // closed via helpers.CloseBody
+		if err != nil {
+			errs = append(errs, errors.Wrap(err, errResetSettings))
+		}
+
+		helpers.CloseBody(resp)
+	}
+
+	if len(errs) > 0 {
+		return "", stderrors.Join(errs...)
+	}
+
+	return hash, nil
+}
+
+// sendTestEmail sends a test email and returns its result.
+// A failed test email does not fail the reconciliation, as the configuration has been applied: the failure is reported in status.
+func (c *external) sendTestEmail(params v1alpha1.EmailConfigurationTestParameters) *v1alpha1.EmailConfigurationTestObservation {
+	sentAt := metav1.Now()
+	result := &v1alpha1.EmailConfigurationTestObservation{
+		To:        params.To,
+		SentAt:    &sentAt,
+		Succeeded: true,
+	}
+
+	resp, err := c.emailsClient.Send(instance.GenerateEmailsSendOption(params)) //nolint:bodyclose // closed via helpers.CloseBody
+	defer helpers.CloseBody(resp)
+
+	if err != nil {
+		result.Succeeded = false
+		result.Message = err.Error()
+	}
+
+	return result
+}
@@ -0,0 +1,436 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package emailconfiguration
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/boxboxjason/sonarqube-client-go/sonar"
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1alpha1 "github.com/crossplane/provider-sonarqube/apis/instance/v1alpha1"
+	"github.com/crossplane/provider-sonarqube/internal/clients/instance"
+	"github.com/crossplane/provider-sonarqube/internal/fake"
+)
+
+type notEmailConfiguration struct {
+	resource.Managed
+}
+
+// errComparer compares error messages for testing.
+func errComparer(a, b error) bool {
+	if a == nil && b == nil {
+		return true
+	}
+
+	if a == nil || b == nil {
+		return false
+	}
+
+	return a.Error() == b.Error()
+}
+
+func newFakeKube(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	return fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func passwordSecret(password, resourceVersion string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "smtp", Namespace: "default", UID: "smtp-uid", ResourceVersion: resourceVersion},
+		Data:       map[string][]byte{"password": []byte(password)},
+	}
+}
+
+func emailConfiguration(status v1alpha1.EmailConfigurationObservation) *v1alpha1.EmailConfiguration {
+	return &v1alpha1.EmailConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "email", Namespace: "default"},
+		Spec: v1alpha1.EmailConfigurationSpec{
+			ForProvider: v1alpha1.EmailConfigurationParameters{
+				Host:              "smtp.example.com",
+				FromAddress:       ptr.To("sonarqube@example.com"),
+				PasswordSecretRef: &xpv1.LocalSecretKeySelector{LocalSecretReference: xpv1.LocalSecretReference{Name: "smtp"}, Key: "password"},
+			},
+		},
+		Status: v1alpha1.EmailConfigurationStatus{AtProvider: status},
+	}
+}
+
+func desiredSettings(password string) map[string]string {
+	return map[string]string{
+		instance.EmailSettingSMTPHost:     "smtp.example.com",
+		instance.EmailSettingSMTPPassword: password,
+		instance.EmailSettingFrom:         "sonarqube@example.com",
+	}
+}
+
+// configurationHash returns the hash of the desired settings with the password of the given version of the Secret.
+func configurationHash(password, resourceVersion string) string {
+	return instance.HashEmailConfiguration(desiredSettings(password), "smtp-uid/"+resourceVersion+"/password")
+}
+
+func configuredValues() *sonar.SettingsValues {
+	return &sonar.SettingsValues{
+		Settings:           []sonar.SettingValue{{Key: instance.EmailSettingFrom, Value: "sonarqube@example.com"}},
+		SetSecuredSettings: []string{instance.EmailSettingSMTPHost, instance.EmailSettingSMTPPassword},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		kube   client.Client
+		client *fake.MockSettingsClient
+		mg     resource.Managed
+		want   want
+	}{
+		"NotEmailConfigurationError": {
+			kube:   newFakeKube(),
+			client: &fake.MockSettingsClient{},
+			mg:     &notEmailConfiguration{},
+			want:   want{err: errors.New(errNotEmailConfiguration)},
+		},
+		"ValuesCallFails": {
+			kube: newFakeKube(),
+			client: &fake.MockSettingsClient{
+				ValuesFn: func(opt *sonar.SettingsValuesOption) (*sonar.SettingsValues, *http.Response, error) {
+					return nil, nil, errors.New("api error")
+				},
+			},
+			mg:   emailConfiguration(v1alpha1.EmailConfigurationObservation{}),
+			want: want{err: errors.Wrap(errors.New("api error"), errGetValues)},
+		},
+		"HostNotSetMeansNotExists": {
+			kube: newFakeKube(),
+			client: &fake.MockSettingsClient{
+				ValuesFn: func(opt *sonar.SettingsValuesOption) (*sonar.SettingsValues, *http.Response, error) {
+					return &sonar.SettingsValues{}, nil, nil
+				},
+			},
+			mg:   emailConfiguration(v1alpha1.EmailConfigurationObservation{}),
+			want: want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"PasswordSecretMissing": {
+			kube: newFakeKube(),
+			client: &fake.MockSettingsClient{
+				ValuesFn: func(opt *sonar.SettingsValuesOption) (*sonar.SettingsValues, *http.Response, error) {
+					return configuredValues(), nil, nil
+				},
+			},
+			mg: emailConfiguration(v1alpha1.EmailConfigurationObservation{}),
+			want: want{err: errors.Wrap(
+				errors.Wrap(errors.New(`secrets "smtp" not found`), "Cannot find referenced secret"),
+				errGetPassword,
+			)},
+		},
+		"UpToDate": {
+			kube: newFakeKube(passwordSecret("secret", "1")),
+			client: &fake.MockSettingsClient{
+				ValuesFn: func(opt *sonar.SettingsValuesOption) (*sonar.SettingsValues, *http.Response, error) {
+					return configuredValues(), nil, nil
+				},
+			},
+			mg: emailConfiguration(v1alpha1.EmailConfigurationObservation{
+				ConfigurationHash: configurationHash("secret", "1"),
+			}),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"PasswordRotated": {
+			kube: newFakeKube(passwordSecret("rotated", "2")),
+			client: &fake.MockSettingsClient{
+				ValuesFn: func(opt *sonar.SettingsValuesOption) (*sonar.SettingsValues, *http.Response, error) {
+					return configuredValues(), nil, nil
+				},
+			},
+			mg: emailConfiguration(v1alpha1.EmailConfigurationObservation{
+				ConfigurationHash: configurationHash("secret", "1"),
+			}),
+			want: want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			e := &external{kube: tc.kube, settingsClient: tc.client, emailsClient: &fake.MockEmailsClient{}}
+
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, cmp.Comparer(errComparer)); diff != "" {
+				t.Errorf("Observe() error mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("Observe() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+
+	var keys []string
+
+	sent := 0
+	settingsClient := &fake.MockSettingsClient{
+		SetFn: func(opt *sonar.SettingsSetOption) (*http.Response, error) {
+			keys = append(keys, opt.Key)
+
+			return nil, nil
+		},
+		ResetFn: func(opt *sonar.SettingsResetOption) (*http.Response, error) {
+			return nil, nil
+		},
+	}
+	emailsClient := &fake.MockEmailsClient{SendFn: func(opt *sonar.EmailsSendOption) (*http.Response, error) {
+		sent++
+
+		return nil, nil
+	}}
+
+	cr := emailConfiguration(v1alpha1.EmailConfigurationObservation{})
+	cr.Spec.ForProvider.TestEmail = &v1alpha1.EmailConfigurationTestParameters{To: "admin@example.com"}
+
+	e := &external{kube: newFakeKube(passwordSecret("secret", "1")), settingsClient: settingsClient, emailsClient: emailsClient}
+
+	_, err := e.Create(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Create() returned unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff([]string{instance.EmailSettingSMTPHost, instance.EmailSettingSMTPPassword, instance.EmailSettingFrom}, keys); diff != "" {
+		t.Errorf("Create() set keys mismatch (-want +got):\n%s", diff)
+	}
+
+	// The status written by Create is not persisted, the hash and the test email are left to Update
+	if cr.Status.AtProvider.ConfigurationHash != "" || cr.Status.AtProvider.TestEmail != nil || sent != 0 {
+		t.Errorf("Create() recorded the configuration hash or sent a test email, want them left to Update")
+	}
+}
+
+func TestCreateSendsSingleTestEmail(t *testing.T) {
+	t.Parallel()
+
+	sent := 0
+	settingsClient := &fake.MockSettingsClient{
+		ValuesFn: func(opt *sonar.SettingsValuesOption) (*sonar.SettingsValues, *http.Response, error) {
+			return configuredValues(), nil, nil
+		},
+		SetFn: func(opt *sonar.SettingsSetOption) (*http.Response, error) {
+			return nil, nil
+		},
+		ResetFn: func(opt *sonar.SettingsResetOption) (*http.Response, error) {
+			return nil, nil
+		},
+	}
+	emailsClient := &fake.MockEmailsClient{SendFn: func(opt *sonar.EmailsSendOption) (*http.Response, error) {
+		sent++
+
+		return nil, nil
+	}}
+
+	cr := emailConfiguration(v1alpha1.EmailConfigurationObservation{})
+	cr.Spec.ForProvider.TestEmail = &v1alpha1.EmailConfigurationTestParameters{To: "admin@example.com"}
+
+	e := &external{kube: newFakeKube(passwordSecret("secret", "1")), settingsClient: settingsClient, emailsClient: emailsClient}
+
+	_, err := e.Create(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Create() returned unexpected error: %v", err)
+	}
+
+	// The managed reconciler reloads the resource after Create, discarding its status
+	cr.Status.AtProvider = v1alpha1.EmailConfigurationObservation{}
+
+	// Reconcile until the configuration is up to date
+	for range 3 {
+		observation, err := e.Observe(context.Background(), cr)
+		if err != nil {
+			t.Fatalf("Observe() returned unexpected error: %v", err)
+		}
+
+		if observation.ResourceUpToDate {
+			break
+		}
+
+		_, err = e.Update(context.Background(), cr)
+		if err != nil {
+			t.Fatalf("Update() returned unexpected error: %v", err)
+		}
+	}
+
+	if sent != 1 {
+		t.Errorf("Create() then reconciling sent %d test emails, want 1", sent)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	t.Parallel()
+
+	type want struct {
+		keys      []string
+		reset     []string
+		testEmail *v1alpha1.EmailConfigurationTestObservation
+		err       bool
+	}
+
+	cases := map[string]struct {
+		sendFn    func(opt *sonar.EmailsSendOption) (*http.Response, error)
+		setErr    error
+		testEmail *v1alpha1.EmailConfigurationTestParameters
+		want      want
+	}{
+		"AppliesSettingsWithoutTestEmail": {
+			want: want{
+				keys:  []string{instance.EmailSettingSMTPHost, instance.EmailSettingSMTPPassword, instance.EmailSettingFrom},
+				reset: []string{instance.EmailSettingSMTPPort, instance.EmailSettingSMTPSecureConnection, instance.EmailSettingSMTPUsername, instance.EmailSettingFromName, instance.EmailSettingPrefix},
+			},
+		},
+		"TestEmailSucceeds": {
+			sendFn: func(opt *sonar.EmailsSendOption) (*http.Response, error) {
+				return nil, nil
+			},
+			testEmail: &v1alpha1.EmailConfigurationTestParameters{To: "admin@example.com"},
+			want: want{
+				keys:      []string{instance.EmailSettingSMTPHost, instance.EmailSettingSMTPPassword, instance.EmailSettingFrom},
+				reset:     []string{instance.EmailSettingSMTPPort, instance.EmailSettingSMTPSecureConnection, instance.EmailSettingSMTPUsername, instance.EmailSettingFromName, instance.EmailSettingPrefix},
+				testEmail: &v1alpha1.EmailConfigurationTestObservation{To: "admin@example.com", Succeeded: true},
+			},
+		},
+		"TestEmailFailsIsReportedInStatus": {
+			sendFn: func(opt *sonar.EmailsSendOption) (*http.Response, error) {
+				return nil, errors.New("connection refused")
+			},
+			testEmail: &v1alpha1.EmailConfigurationTestParameters{To: "admin@example.com"},
+			want: want{
+				keys:      []string{instance.EmailSettingSMTPHost, instance.EmailSettingSMTPPassword, instance.EmailSettingFrom},
+				reset:     []string{instance.EmailSettingSMTPPort, instance.EmailSettingSMTPSecureConnection, instance.EmailSettingSMTPUsername, instance.EmailSettingFromName, instance.EmailSettingPrefix},
+				testEmail: &v1alpha1.EmailConfigurationTestObservation{To: "admin@example.com", Succeeded: false, Message: "connection refused"},
+			},
+		},
+		"SetFails": {
+			setErr: errors.New("api error"),
+			want: want{
+				keys:  []string{instance.EmailSettingSMTPHost, instance.EmailSettingSMTPPassword, instance.EmailSettingFrom},
+				reset: []string{instance.EmailSettingSMTPPort, instance.EmailSettingSMTPSecureConnection, instance.EmailSettingSMTPUsername, instance.EmailSettingFromName, instance.EmailSettingPrefix},
+				err:   true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var keys, reset []string
+
+			settingsClient := &fake.MockSettingsClient{
+				SetFn: func(opt *sonar.SettingsSetOption) (*http.Response, error) {
+					keys = append(keys, opt.Key)
+
+					return nil, tc.setErr
+				},
+				ResetFn: func(opt *sonar.SettingsResetOption) (*http.Response, error) {
+					reset = append(reset, opt.Keys...)
+
+					return nil, nil
+				},
+			}
+
+			cr := emailConfiguration(v1alpha1.EmailConfigurationObservation{})
+			cr.Spec.ForProvider.TestEmail = tc.testEmail
+
+			e := &external{kube: newFakeKube(passwordSecret("secret", "1")), settingsClient: settingsClient, emailsClient: &fake.MockEmailsClient{SendFn: tc.sendFn}}
+
+			_, err := e.Update(context.Background(), cr)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("Update() error = %v, wantErr %v", err, tc.want.err)
+			}
+
+			if diff := cmp.Diff(tc.want.keys, keys); diff != "" {
+				t.Errorf("Update() set keys mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.reset, reset); diff != "" {
+				t.Errorf("Update() reset keys mismatch (-want +got):\n%s", diff)
+			}
+
+			if tc.want.err {
+				if cr.Status.AtProvider.ConfigurationHash != "" {
+					t.Errorf("Update() recorded a configuration hash although applying failed")
+				}
+
+				return
+			}
+
+			if cr.Status.AtProvider.ConfigurationHash != configurationHash("secret", "1") {
+				t.Errorf("Update() did not record the hash of the applied configuration")
+			}
+
+			if diff := cmp.Diff(tc.want.testEmail, cr.Status.AtProvider.TestEmail, cmpopts.IgnoreFields(v1alpha1.EmailConfigurationTestObservation{}, "SentAt")); diff != "" {
+				t.Errorf("Update() test email mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	t.Parallel()
+
+	var reset []string
+
+	e := &external{
+		settingsClient: &fake.MockSettingsClient{
+			ResetFn: func(opt *sonar.SettingsResetOption) (*http.Response, error) {
+				reset = opt.Keys
+
+				return nil, nil
+			},
+		},
+	}
+
+	_, err := e.Delete(context.Background(), emailConfiguration(v1alpha1.EmailConfigurationObservation{}))
+	if err != nil {
+		t.Fatalf("Delete() returned unexpected error: %v", err)
+	}
+
+	if diff := cmp.Diff(instance.EmailSettingKeys, reset); diff != "" {
+		t.Errorf("Delete() reset keys mismatch (-want +got):\n%s", diff)
+	}
+}
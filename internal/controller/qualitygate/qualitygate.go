@@ -49,7 +49,9 @@ const (
 	errNotQualityGate = "managed resource is not a QualityGate custom resource"
 	errTrackPCUsage   = "cannot track ProviderConfig usage"
 	errGetPC          = "cannot get ProviderConfig"
+	errNewClient      = "cannot create SonarQube client"
 
+	errShowQualityGate    = "cannot get SonarQube Quality Gate"
 	errCreateQualityGate  = "cannot create SonarQube Quality Gate"
 	errDefaultQualityGate = "cannot set SonarQube Quality Gate as default"
 	errDeleteQualityGate  = "cannot delete SonarQube Quality Gate"
@@ -118,7 +120,7 @@ func Setup(mgr ctrl.Manager, opts controller.Options) error {
 type connector struct {
 	kube         client.Client
 	usage        *resource.ProviderConfigUsageTracker
-	newServiceFn func(config common.Config) instance.QualityGatesClient
+	newServiceFn func(config common.Config) (instance.QualityGatesClient, error)
 }
 
 // Connect typically produces an ExternalClient by:
@@ -148,7 +150,10 @@ func (c *connector) Connect(ctx context.Context, managedResource resource.Manage
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	svc := c.newServiceFn(*config)
+	svc, err := c.newServiceFn(*config)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
 
 	return &external{qualityGatesClient: svc}, nil
 }
@@ -182,8 +187,15 @@ func (c *external) Observe(ctx context.Context, managedResource resource.Managed
 	defer helpers.CloseBody(resp)
 
 	if err != nil {
-		// If the quality gate is not found, treat as resource doesn't exist
-		return managed.ExternalObservation{ResourceExists: false}, nil
+		err = common.ClassifyError(resp, err)
+		// Only a missing quality gate means it does not exist, other errors must not lead to creating a duplicate
+		if common.IsNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+
+		qualityGate.Status.SetConditions(common.RequestFailed(err))
+
+		return managed.ExternalObservation{}, errors.Wrap(err, errShowQualityGate)
 	}
 
 	// Update status with observed state
@@ -100,10 +100,10 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
-		"ShowFailsReturnsNotExists": {
+		"ShowNotFoundReturnsNotExists": {
 			client: &fake.MockQualityGatesClient{
 				ShowFn: func(opt *sonar.QualitygatesShowOption) (*sonar.QualitygatesShow, *http.Response, error) {
-					return nil, nil, errors.New("api error")
+					return nil, &http.Response{StatusCode: http.StatusNotFound}, errors.New("api error")
 				},
 			},
 			args: args{
@@ -125,6 +125,81 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"ShowUnauthorizedReturnsError": {
+			client: &fake.MockQualityGatesClient{
+				ShowFn: func(opt *sonar.QualitygatesShowOption) (*sonar.QualitygatesShow, *http.Response, error) {
+					return nil, &http.Response{StatusCode: http.StatusUnauthorized}, errors.New("api error")
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				mg: func() *v1alpha1.QualityGate {
+					qg := &v1alpha1.QualityGate{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "test-gate",
+							Annotations: map[string]string{},
+						},
+					}
+					meta.SetExternalName(qg, "test-gate")
+
+					return qg
+				}(),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errors.New("api error"), errShowQualityGate),
+			},
+		},
+		"ShowServerErrorReturnsError": {
+			client: &fake.MockQualityGatesClient{
+				ShowFn: func(opt *sonar.QualitygatesShowOption) (*sonar.QualitygatesShow, *http.Response, error) {
+					return nil, &http.Response{StatusCode: http.StatusInternalServerError}, errors.New("api error")
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				mg: func() *v1alpha1.QualityGate {
+					qg := &v1alpha1.QualityGate{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "test-gate",
+							Annotations: map[string]string{},
+						},
+					}
+					meta.SetExternalName(qg, "test-gate")
+
+					return qg
+				}(),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errors.New("api error"), errShowQualityGate),
+			},
+		},
+		"ShowTimeoutReturnsError": {
+			client: &fake.MockQualityGatesClient{
+				ShowFn: func(opt *sonar.QualitygatesShowOption) (*sonar.QualitygatesShow, *http.Response, error) {
+					return nil, nil, errors.New("api error")
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				mg: func() *v1alpha1.QualityGate {
+					qg := &v1alpha1.QualityGate{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "test-gate",
+							Annotations: map[string]string{},
+						},
+					}
+					meta.SetExternalName(qg, "test-gate")
+
+					return qg
+				}(),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errors.New("api error"), errShowQualityGate),
+			},
+		},
 		"SuccessfulObserveResourceExists": {
 			client: &fake.MockQualityGatesClient{
 				ShowFn: func(opt *sonar.QualitygatesShowOption) (*sonar.QualitygatesShow, *http.Response, error) {
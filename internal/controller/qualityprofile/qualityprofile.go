@@ -18,7 +18,11 @@ package qualityprofile
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/boxboxjason/sonarqube-client-go/sonar"
 	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
@@ -26,6 +30,9 @@ import (
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 
 	"github.com/pkg/errors"
@@ -50,12 +57,44 @@ const (
 	errNotQualityProfile = "managed resource is not a QualityProfile custom resource"
 	errTrackPCUsage      = "cannot track ProviderConfig usage"
 	errGetPC             = "cannot get ProviderConfig"
+	errNewClient         = "cannot create SonarQube client"
+	errGetCapabilities   = "cannot detect the version and edition of the SonarQube instance"
 
 	errCreateQualityProfile  = "cannot create SonarQube Quality Profile"
 	errDefaultQualityProfile = "cannot set SonarQube Quality Profile as default"
 	errUpdateQualityProfile  = "cannot update SonarQube Quality Profile"
 	errDeleteQualityProfile  = "cannot delete SonarQube Quality Profile"
+	errDeleteDefault         = "cannot delete the default Quality Profile %s of language %s, set another Quality Profile as default first"
+	errDeletionBlocked       = "cannot delete SonarQube Quality Profile %s: %s"
+	errListProjects          = "cannot list the projects of SonarQube Quality Profile"
+	errInheritance           = "cannot get the inheritance of SonarQube Quality Profile"
+	errReparentChild         = "cannot reparent child Quality Profile %s"
+	errReassignProject       = "cannot reassign project %s to the default Quality Profile"
 	errShowQualityProfile    = "cannot get SonarQube Quality Profile"
+	errSearchQualityProfile  = "cannot search SonarQube Quality Profile to adopt"
+	errAdoptBuiltIn          = "cannot adopt the built-in Quality Profile %s, built-in Quality Profiles cannot be managed"
+	errResolveRuleSelectors  = "cannot resolve Quality Profile rule selectors"
+	errInvalidRuleSelectors  = "invalid Quality Profile rule selectors: %s"
+	errInvalidDeactivated    = "invalid Quality Profile deactivated rules: %s"
+	errUnsupportedFeatures   = "the SonarQube instance does not support fields of the Quality Profile: %s"
+	errResolveBackup         = "cannot resolve the backup of the Quality Profile source"
+	errInvalidBackup         = "invalid Quality Profile backup: %s"
+	errRestoreBackup         = "cannot restore SonarQube Quality Profile from its backup"
+	errRestoreExisting       = "cannot restore the backup into the existing Quality Profile %s, set adoptExisting to restore it"
+	errRestoredOther         = "the backup was restored into the Quality Profile %s instead of %s"
+	errExportBackup          = "cannot export the backup of SonarQube Quality Profile"
+	errBackupNotControlled   = "cannot export the backup to the ConfigMap %s, it is not controlled by this QualityProfile"
+	errChangelog             = "cannot look up the changelog of SonarQube Quality Profile"
+	errSearchReference       = "cannot search the reference Quality Profile to compare with"
+	errReferenceNotFound     = "cannot find the reference Quality Profile %s of language %s to compare with"
+	errCompareQualityProfile = "cannot compare SonarQube Quality Profile with its reference"
+
+	reasonExternalChange event.Reason = "ExternalChange"
+	reasonChangelog      event.Reason = "CannotLookUpChangelog"
+	reasonComparison     event.Reason = "CannotCompareQualityProfile"
+
+	// annotationKeyRestore persists the restoration of the backup made by Create, as the managed reconciler discards the status written by Create.
+	annotationKeyRestore = "instance.sonarqube.crossplane.io/restore"
 )
 
 // SetupGated adds a controller that reconciles QualityProfile managed resources with safe-start support.
@@ -72,15 +111,19 @@ func SetupGated(mgr ctrl.Manager, o controller.Options) error {
 
 func Setup(mgr ctrl.Manager, opts controller.Options) error {
 	name := managed.ControllerName(v1alpha1.QualityProfileGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	options := []managed.ReconcilerOption{
 		managed.WithExternalConnector(&connector{
-			kube:  mgr.GetClient(),
-			usage: resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			kube:     mgr.GetClient(),
+			usage:    resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			recorder: recorder,
 		}),
+		// The external name is the key generated by SonarQube, it is set on creation or adoption
+		managed.WithInitializers(),
 		managed.WithLogger(opts.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(opts.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithRecorder(recorder),
 	}
 
 	if opts.Features.Enabled(feature.EnableBetaManagementPolicies) {
@@ -116,11 +159,15 @@ func Setup(mgr ctrl.Manager, opts controller.Options) error {
 		Complete(ratelimiter.NewReconciler(name, reconciler, opts.GlobalRateLimiter))
 }
 
+// ruleSelectors is the rule selectors cache shared by the reconciles of all the Quality Profiles.
+var ruleSelectors = instance.NewRuleSelectorsCache(instance.DefaultRuleSelectorsTTL)
+
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
-	kube  client.Client
-	usage *resource.ProviderConfigUsageTracker
+	kube     client.Client
+	usage    *resource.ProviderConfigUsageTracker
+	recorder event.Recorder
 }
 
 // Connect typically produces an ExternalClient by:
@@ -150,19 +197,47 @@ func (c *connector) Connect(ctx context.Context, managedResource resource.Manage
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
+	qualityProfilesClient, err := instance.NewQualityProfilesClient(*config)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	rulesClient, err := instance.NewRulesClient(*config)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	// The capabilities are used to reject the fields the SonarQube instance does not support before calling it.
+	capabilities, err := common.GetCapabilities(*config)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCapabilities)
+	}
+
 	return &external{
-		qualityProfilesClient: instance.NewQualityProfilesClient(*config),
-		rulesClient:           instance.NewRulesClient(*config),
+		kube:                  c.kube,
+		recorder:              c.recorder,
+		qualityProfilesClient: qualityProfilesClient,
+		rulesClient:           rulesClient,
+		ruleSelectors:         ruleSelectors,
+		capabilities:          capabilities,
 	}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
+	// kube is used to read the backup the Quality Profile is restored from and to write its exported backup
+	kube client.Client
+	// recorder emits an event for each change made to the Quality Profile outside of the resource
+	recorder event.Recorder
 	// qualityProfilesClient is used to interact with SonarQube Quality Profiles API
 	qualityProfilesClient instance.QualityProfilesClient
 	// rulesClient is used to interact with SonarQube Rules API
 	rulesClient instance.RulesClient
+	// ruleSelectors caches the rules selected by the rule selectors, they are resolved on every Observe if nil
+	ruleSelectors *instance.RuleSelectorsCache
+	// capabilities are the version and edition of the SonarQube instance, used to reject unsupported fields
+	capabilities common.Capabilities
 }
 
 // Observe checks if the external resource exists and if it matches the
@@ -176,8 +251,42 @@ func (c *external) Observe(ctx context.Context, managedResource resource.Managed
 	// Use external name as the identifier to check if the resource exists
 	// This allows returning early when the external name is not set
 	externalName := meta.GetExternalName(profile)
+	adopted := false
+
 	if externalName == "" {
-		return managed.ExternalObservation{ResourceExists: false}, nil
+		if !ptr.Deref(profile.Spec.ForProvider.AdoptExisting, false) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+
+		key, err := c.findQualityProfileToAdopt(profile)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+
+		if key == "" {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+
+		// Adopt the existing Quality Profile, the external name is persisted with the late-initialized spec
+		meta.SetExternalName(profile, key)
+		externalName = key
+		adopted = true
+	}
+
+	// Reject the fields the SonarQube instance does not support before they are sent to it
+	violations := instance.CheckQualityProfileCapabilities(&profile.Spec.ForProvider, c.capabilities)
+	if len(violations) > 0 {
+		return managed.ExternalObservation{}, errors.Errorf(errUnsupportedFeatures, strings.Join(violations, "; "))
+	}
+
+	violations = instance.CheckQualityProfileRuleSelectors(&profile.Spec.ForProvider)
+	if len(violations) > 0 {
+		return managed.ExternalObservation{}, errors.Errorf(errInvalidRuleSelectors, strings.Join(violations, "; "))
+	}
+
+	violations = instance.CheckQualityProfileDeactivatedRules(&profile.Spec.ForProvider)
+	if len(violations) > 0 {
+		return managed.ExternalObservation{}, errors.Errorf(errInvalidDeactivated, strings.Join(violations, "; "))
 	}
 
 	// Retrieve the Quality Profile from SonarQube
@@ -187,18 +296,54 @@ func (c *external) Observe(ctx context.Context, managedResource resource.Managed
 	defer helpers.CloseBody(resp)
 
 	if err != nil {
-		// If the quality profile is not found, treat as resource doesn't exist
-		return managed.ExternalObservation{ResourceExists: false}, nil
+		err = common.ClassifyError(resp, err)
+		// Only a missing quality profile means it does not exist, other errors must not lead to creating a duplicate
+		if common.IsNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+
+		profile.Status.SetConditions(common.RequestFailed(err))
+
+		return managed.ExternalObservation{}, errors.Wrap(err, errShowQualityProfile)
 	}
 
 	// Retrieve Quality Profile Rules (paginated)
 	rules, err := instance.FetchAllQualityProfileRules(c.rulesClient, externalName)
 	if err != nil {
+		err = common.ClassifyError(nil, err)
+		profile.Status.SetConditions(common.RequestFailed(err))
+
 		return managed.ExternalObservation{}, errors.Wrap(err, errShowQualityProfile)
 	}
 
-	// Update status with observed state
+	// Resolve the rules selected by the rule selectors
+	selected, err := c.resolveRuleSelectors(profile)
+	if err != nil {
+		err = common.ClassifyError(nil, err)
+		profile.Status.SetConditions(common.RequestFailed(err))
+
+		return managed.ExternalObservation{}, errors.Wrap(err, errResolveRuleSelectors)
+	}
+
+	// Update status with observed state, keeping the activated rules ledger, the rule conflicts and the report of the last rules synchronization
+	previous := profile.Status.AtProvider
 	profile.Status.AtProvider = instance.GenerateQualityProfileObservation(qualityProfile, rules)
+	profile.Status.AtProvider.ActivatedRules = previous.ActivatedRules
+	profile.Status.AtProvider.RuleConflicts = previous.RuleConflicts
+	profile.Status.AtProvider.RulesSync = previous.RulesSync
+	profile.Status.AtProvider.Restore = previous.Restore
+	if profile.Status.AtProvider.Restore == nil {
+		profile.Status.AtProvider.Restore = annotatedRestore(profile)
+	}
+	profile.Status.AtProvider.BackupExportTime = previous.BackupExportTime
+	profile.Status.AtProvider.BackupExportRevision = previous.BackupExportRevision
+	profile.Status.AtProvider.ChangelogSince = previous.ChangelogSince
+	profile.Status.AtProvider.ExternalChanges = previous.ExternalChanges
+	if profile.Spec.ForProvider.CompareTo != nil {
+		profile.Status.AtProvider.Comparison = previous.Comparison
+	}
+	instance.PruneRuleConflicts(&profile.Spec.ForProvider, &profile.Status.AtProvider)
+	setRulesConflictCondition(profile)
 	profile.Status.SetConditions(xpv1.Available())
 	current := profile.Spec.ForProvider.DeepCopy()
 
@@ -206,23 +351,101 @@ func (c *external) Observe(ctx context.Context, managedResource resource.Managed
 	associations := instance.GenerateQualityProfileRulesAssociation(profile.Spec.ForProvider.Rules, profile.Status.AtProvider.Rules)
 
 	// Late initialize the spec with observed state (includes conditions)
-	instance.LateInitializeQualityProfile(&profile.Spec.ForProvider, &profile.Status.AtProvider, associations)
+	instance.LateInitializeQualityProfile(&profile.Spec.ForProvider, &profile.Status.AtProvider, associations, c.capabilities)
 
 	// Check if rules were late-initialized
 	rulesLateInitialized := instance.WereQualityProfileRulesLateInitialized(current.Rules, profile.Spec.ForProvider.Rules)
 
+	// The rules selected by the rule selectors are compared with the observation too, the rules of the spec override them
+	selection := instance.MergeQualityProfileRules(profile.Spec.ForProvider, selected)
+	instance.SetQualityProfileRuleSelectors(profile.Status.AtProvider.Rules, selection)
+	instance.PruneActivatedRules(&profile.Status.AtProvider, selection.Rules)
+	associations = instance.GenerateQualityProfileRulesAssociation(selection.Rules, profile.Status.AtProvider.Rules)
+	upToDate := instance.IsQualityProfileUpToDate(&profile.Spec.ForProvider, &profile.Status.AtProvider, associations)
+
+	// A changed backup is restored again
+	if profile.Spec.ForProvider.Source != nil {
+		backup, err := c.resolveBackup(ctx, profile)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+
+		upToDate = upToDate && instance.IsQualityProfileBackupRestored(&profile.Status.AtProvider, instance.HashQualityProfileBackup(backup))
+	}
+
+	// Report who changed a drifted Quality Profile outside of the resource before the changes are reverted
+	switch {
+	case profile.Status.AtProvider.ChangelogSince == nil:
+		profile.Status.AtProvider.ChangelogSince = instance.ChangelogSinceAfter(time.Now())
+	case !upToDate:
+		c.reportExternalChanges(profile)
+	}
+
+	// Report how far the Quality Profile diverges from its reference, e.g. the rules added to a built-in Quality Profile by an upgrade
+	if profile.Spec.ForProvider.CompareTo != nil {
+		c.compareQualityProfile(profile)
+	}
+
+	// The backup of a synchronized Quality Profile is exported
+	if upToDate && profile.Spec.ForProvider.BackupExport != nil {
+		err = c.exportBackup(ctx, profile)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errExportBackup)
+		}
+	}
+
 	return managed.ExternalObservation{
 		ResourceExists:   true,
-		ResourceUpToDate: instance.IsQualityProfileUpToDate(&profile.Spec.ForProvider, &profile.Status.AtProvider, associations),
+		ResourceUpToDate: upToDate,
 		// Check both regular fields and conditions for late-initialization
 		ResourceLateInitialized: !cmp.Equal(
 			current,
 			&profile.Spec.ForProvider,
 			cmpopts.IgnoreFields(v1alpha1.QualityProfileParameters{}, "Rules"),
-		) || rulesLateInitialized,
+		) || rulesLateInitialized || adopted,
 	}, nil
 }
 
+// resolveRuleSelectors returns the rules selected by the rule selectors of the Quality Profile.
+// They are resolved through the Rules API once per generation of the spec, and again when the cache expires.
+func (c *external) resolveRuleSelectors(profile *v1alpha1.QualityProfile) ([]instance.SelectedRules, error) {
+	resolve := func() ([]instance.SelectedRules, error) {
+		return instance.FetchAllRuleSelectorRules(c.rulesClient, profile.Spec.ForProvider)
+	}
+
+	if c.ruleSelectors == nil || len(profile.Spec.ForProvider.RuleSelectors) == 0 {
+		return resolve()
+	}
+
+	return c.ruleSelectors.Get(profile.GetUID(), profile.GetGeneration(), resolve)
+}
+
+// findQualityProfileToAdopt searches the Quality Profile with the name and language of the spec
+// and returns its key, or an empty string if there is none.
+func (c *external) findQualityProfileToAdopt(profile *v1alpha1.QualityProfile) (string, error) {
+	search, resp, err := c.qualityProfilesClient.Search(instance.GenerateSearchQualityProfileOption(profile.Spec.ForProvider)) //nolint:bodyclose // closed via helpers.CloseBody
+	defer helpers.CloseBody(resp)
+
+	if err != nil {
+		err = common.ClassifyError(resp, err)
+		profile.Status.SetConditions(common.RequestFailed(err))
+
+		return "", errors.Wrap(err, errSearchQualityProfile)
+	}
+
+	existing := instance.FindQualityProfileToAdopt(search, profile.Spec.ForProvider)
+	if existing == nil {
+		return "", nil
+	}
+
+	// Built-in Quality Profiles are read-only, creating a new one would fail on the name conflict too
+	if existing.IsBuiltIn {
+		return "", errors.Errorf(errAdoptBuiltIn, existing.Name)
+	}
+
+	return existing.Key, nil
+}
+
 // Create creates the external resource and sets the external name.
 func (c *external) Create(ctx context.Context, managedResource resource.Managed) (managed.ExternalCreation, error) {
 	profile, ok := managedResource.(*v1alpha1.QualityProfile)
@@ -232,15 +455,22 @@ func (c *external) Create(ctx context.Context, managedResource resource.Managed)
 
 	profile.Status.SetConditions(xpv1.Creating())
 
-	qualityProfile, resp, err := c.qualityProfilesClient.Create(instance.GenerateCreateQualityProfileOption(profile.Spec.ForProvider)) //nolint:bodyclose // closed via helpers.CloseBody
-	defer helpers.CloseBody(resp)
+	if profile.Spec.ForProvider.Source != nil {
+		err := c.createFromBackup(ctx, profile)
+		if err != nil {
+			return managed.ExternalCreation{}, err
+		}
+	} else {
+		qualityProfile, resp, err := c.qualityProfilesClient.Create(instance.GenerateCreateQualityProfileOption(profile.Spec.ForProvider)) //nolint:bodyclose // closed via helpers.CloseBody
+		defer helpers.CloseBody(resp)
 
-	if err != nil {
-		return managed.ExternalCreation{}, errors.Wrap(err, errCreateQualityProfile)
-	}
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreateQualityProfile)
+		}
 
-	// Set the external name to the Key of the created Quality Profile
-	meta.SetExternalName(profile, qualityProfile.Profile.Key)
+		// Set the external name to the Key of the created Quality Profile
+		meta.SetExternalName(profile, qualityProfile.Profile.Key)
+	}
 
 	// Set Quality Profile as default if specified in the spec
 	if ptr.Deref(profile.Spec.ForProvider.Default, false) {
@@ -267,6 +497,14 @@ func (c *external) Update(ctx context.Context, managedResource resource.Managed)
 		return managed.ExternalUpdate{}, fmt.Errorf("external name is not set for Quality Profile %s", profile.Name)
 	}
 
+	// Restore the backup of the source again when it has changed
+	if profile.Spec.ForProvider.Source != nil {
+		err := c.restoreChangedBackup(ctx, profile, externalName)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
 	// Set Quality Profile as default if specified in the spec (idempotent)
 	if ptr.Deref(profile.Spec.ForProvider.Default, false) {
 		updateSetDefaultResp, err := c.qualityProfilesClient.SetDefault(instance.GenerateQualityprofilesSetDefaultOption(profile.Spec.ForProvider)) //nolint:bodyclose // closed via helpers.CloseBody
@@ -287,17 +525,328 @@ func (c *external) Update(ctx context.Context, managedResource resource.Managed)
 		}
 	}
 
-	associations := instance.GenerateQualityProfileRulesAssociation(profile.Spec.ForProvider.Rules, profile.Status.AtProvider.Rules)
+	// Resolve the rules selected by the rule selectors, the rules of the spec override them
+	selected, err := c.resolveRuleSelectors(profile)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errResolveRuleSelectors)
+	}
+
+	selection := instance.MergeQualityProfileRules(profile.Spec.ForProvider, selected)
+
+	// In Additive rules policy, the active rules not activated by this resource are left untouched
+	associations := instance.ManagedQualityProfileRules(
+		&profile.Spec.ForProvider,
+		&profile.Status.AtProvider,
+		instance.GenerateQualityProfileRulesAssociation(selection.Rules, profile.Status.AtProvider.Rules),
+	)
 
 	// Sync Quality Profile Rules
-	err := c.syncQualityProfileRules(profile, associations)
+	err = c.syncQualityProfileRules(profile, selection, associations)
+	setRulesConflictCondition(profile)
+
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, "cannot sync Quality Profile Rules")
 	}
 
+	// The changes made from now on are external changes
+	profile.Status.AtProvider.ChangelogSince = instance.ChangelogSinceAfter(time.Now())
+
 	return managed.ExternalUpdate{}, nil
 }
 
+// reportExternalChanges looks up the changes made to the Quality Profile since its last synchronization, which were made outside of the resource.
+// An event is emitted for each of them and they are recorded in the status. A failed lookup is reported by an event only, so that it does not
+// prevent the synchronization.
+func (c *external) reportExternalChanges(profile *v1alpha1.QualityProfile) {
+	observation := &profile.Status.AtProvider
+
+	changelog, err := instance.FetchAllQualityProfileChangelogEvents(c.qualityProfilesClient, observation, observation.ChangelogSince)
+	if err != nil {
+		c.recorder.Event(profile, event.Warning(reasonChangelog, errors.Wrap(err, errChangelog)))
+
+		return
+	}
+
+	changes := make([]v1alpha1.QualityProfileExternalChange, 0, len(changelog))
+	for _, changelogEvent := range changelog {
+		changes = append(changes, instance.GenerateQualityProfileExternalChange(changelogEvent))
+	}
+
+	// The changelog is newest first, the events are emitted in the order of the changes
+	for _, change := range slices.Backward(changes) {
+		c.recorder.Event(profile, event.Normal(reasonExternalChange, instance.DescribeQualityProfileExternalChange(change)))
+	}
+
+	instance.RecordExternalChanges(observation, changes)
+
+	if next := instance.NextChangelogSince(changes); next != nil {
+		observation.ChangelogSince = next
+	}
+}
+
+// compareQualityProfile compares the observed Quality Profile with the reference Quality Profile of compareTo and records their differences.
+// A failed comparison is reported in an event and keeps the previous comparison, it does not prevent the synchronization of the Quality Profile.
+func (c *external) compareQualityProfile(profile *v1alpha1.QualityProfile) {
+	observation := &profile.Status.AtProvider
+	target := profile.Spec.ForProvider.CompareTo
+
+	referenceKey, err := c.findReferenceQualityProfile(target, observation.Language)
+	if err != nil {
+		c.recorder.Event(profile, event.Warning(reasonComparison, err))
+
+		return
+	}
+
+	comparison, resp, err := c.qualityProfilesClient.Compare(instance.GenerateCompareQualityProfileOption(observation.Key, referenceKey)) //nolint:bodyclose // closed via helpers.CloseBody
+	defer helpers.CloseBody(resp)
+
+	if err != nil {
+		c.recorder.Event(profile, event.Warning(reasonComparison, errors.Wrap(common.ClassifyError(resp, err), errCompareQualityProfile)))
+
+		return
+	}
+
+	observation.Comparison = instance.GenerateQualityProfileComparisonObservation(comparison)
+	observation.Comparison.Time = ptr.To(metav1.Now())
+}
+
+// findReferenceQualityProfile returns the key of the reference Quality Profile of the comparison target,
+// searching the Quality Profiles of the given language when the target is named.
+func (c *external) findReferenceQualityProfile(target *v1alpha1.QualityProfileComparisonTarget, language string) (string, error) {
+	if target.Key != nil {
+		return *target.Key, nil
+	}
+
+	search, resp, err := c.qualityProfilesClient.Search(instance.GenerateSearchReferenceQualityProfileOption(target, language)) //nolint:bodyclose // closed via helpers.CloseBody
+	defer helpers.CloseBody(resp)
+
+	if err != nil {
+		return "", errors.Wrap(common.ClassifyError(resp, err), errSearchReference)
+	}
+
+	reference := instance.FindReferenceQualityProfile(search, target, language)
+	if reference == nil {
+		return "", errors.Errorf(errReferenceNotFound, ptr.Deref(target.Name, ""), language)
+	}
+
+	return reference.Key, nil
+}
+
+// resolveBackup returns the XML backup referenced by the source of the Quality Profile.
+func (c *external) resolveBackup(ctx context.Context, profile *v1alpha1.QualityProfile) (string, error) {
+	source := profile.Spec.ForProvider.Source
+
+	var (
+		value *string
+		err   error
+	)
+
+	switch {
+	case source.SecretKeyRef != nil:
+		value, err = common.GetTokenValueFromLocalSecret(ctx, c.kube, profile, source.SecretKeyRef)
+	case source.ConfigMapKeyRef != nil:
+		value, err = common.GetValueFromLocalConfigMap(ctx, c.kube, profile, source.ConfigMapKeyRef.Name, source.ConfigMapKeyRef.Key)
+	default:
+		err = errors.New("one of secretKeyRef or configMapKeyRef must be set")
+	}
+
+	if err != nil {
+		return "", errors.Wrap(err, errResolveBackup)
+	}
+
+	return *value, nil
+}
+
+// createFromBackup creates the Quality Profile by restoring the backup of its source and sets the external name.
+// A Quality Profile with the name of the backup is only overwritten when adoptExisting allows it.
+func (c *external) createFromBackup(ctx context.Context, profile *v1alpha1.QualityProfile) error {
+	backup, err := c.resolveBackup(ctx, profile)
+	if err != nil {
+		return err
+	}
+
+	parsed, err := instance.ParseQualityProfileBackup(backup)
+	if err != nil {
+		return errors.Errorf(errInvalidBackup, err.Error())
+	}
+
+	violations := instance.CheckQualityProfileBackup(parsed, "", profile.Spec.ForProvider.Language)
+	if len(violations) > 0 {
+		return errors.Errorf(errInvalidBackup, strings.Join(violations, "; "))
+	}
+
+	search, resp, err := c.qualityProfilesClient.Search(instance.GenerateSearchQualityProfileOption(v1alpha1.QualityProfileParameters{Name: parsed.Name, Language: parsed.Language})) //nolint:bodyclose // closed via helpers.CloseBody
+	defer helpers.CloseBody(resp)
+
+	if err != nil {
+		return errors.Wrap(err, errSearchQualityProfile)
+	}
+
+	existing := instance.FindQualityProfileToAdopt(search, v1alpha1.QualityProfileParameters{Name: parsed.Name, Language: parsed.Language})
+	if existing != nil && existing.IsBuiltIn {
+		return errors.Errorf(errAdoptBuiltIn, existing.Name)
+	}
+
+	if existing != nil && !ptr.Deref(profile.Spec.ForProvider.AdoptExisting, false) {
+		return errors.Errorf(errRestoreExisting, existing.Name)
+	}
+
+	restore, restoreResp, err := c.qualityProfilesClient.RestoreBackup(backup) //nolint:bodyclose // closed via helpers.CloseBody
+	defer helpers.CloseBody(restoreResp)
+
+	if err != nil {
+		return errors.Wrap(err, errRestoreBackup)
+	}
+
+	// Set the external name to the Key of the restored Quality Profile
+	meta.SetExternalName(profile, restore.Profile.Key)
+	annotateRestore(profile, restore, backup)
+
+	return nil
+}
+
+// restoreChangedBackup restores the backup of the source in the Quality Profile when it differs from the last restored one.
+func (c *external) restoreChangedBackup(ctx context.Context, profile *v1alpha1.QualityProfile, externalName string) error {
+	backup, err := c.resolveBackup(ctx, profile)
+	if err != nil {
+		return err
+	}
+
+	if instance.IsQualityProfileBackupRestored(&profile.Status.AtProvider, instance.HashQualityProfileBackup(backup)) {
+		return nil
+	}
+
+	parsed, err := instance.ParseQualityProfileBackup(backup)
+	if err != nil {
+		return errors.Errorf(errInvalidBackup, err.Error())
+	}
+
+	// SonarQube restores a backup into the Quality Profile named in it, which must be this one
+	violations := instance.CheckQualityProfileBackup(parsed, profile.Status.AtProvider.Name, profile.Status.AtProvider.Language)
+	if len(violations) > 0 {
+		return errors.Errorf(errInvalidBackup, strings.Join(violations, "; "))
+	}
+
+	restore, resp, err := c.qualityProfilesClient.RestoreBackup(backup) //nolint:bodyclose // closed via helpers.CloseBody
+	defer helpers.CloseBody(resp)
+
+	if err != nil {
+		return errors.Wrap(err, errRestoreBackup)
+	}
+
+	if restore.Profile.Key != externalName {
+		return errors.Errorf(errRestoredOther, restore.Profile.Key, externalName)
+	}
+
+	recordRestore(profile, restore, backup)
+
+	return nil
+}
+
+// recordRestore reports the restoration of the backup in the status of the Quality Profile.
+func recordRestore(profile *v1alpha1.QualityProfile, restore *instance.QualityProfilesRestore, backup string) {
+	profile.Status.AtProvider.Restore = newRestoreObservation(restore, backup)
+}
+
+// annotateRestore records the restoration of the backup made by Create in an annotation, which is persisted unlike the status.
+// The next Observe reports it in the status, so that the backup is not restored a second time.
+func annotateRestore(profile *v1alpha1.QualityProfile, restore *instance.QualityProfilesRestore, backup string) {
+	// Marshalling cannot fail for this type
+	data, _ := json.Marshal(newRestoreObservation(restore, backup))
+	meta.AddAnnotations(profile, map[string]string{annotationKeyRestore: string(data)})
+}
+
+// annotatedRestore returns the restoration of the backup recorded by Create, or nil if there is none.
+func annotatedRestore(profile *v1alpha1.QualityProfile) *v1alpha1.QualityProfileRestoreObservation {
+	data, exists := profile.GetAnnotations()[annotationKeyRestore]
+	if !exists {
+		return nil
+	}
+
+	observation := &v1alpha1.QualityProfileRestoreObservation{}
+	if json.Unmarshal([]byte(data), observation) != nil {
+		return nil
+	}
+
+	return observation
+}
+
+// newRestoreObservation reports the restoration of the backup done now.
+func newRestoreObservation(restore *instance.QualityProfilesRestore, backup string) *v1alpha1.QualityProfileRestoreObservation {
+	observation := instance.GenerateQualityProfileRestoreObservation(restore, instance.HashQualityProfileBackup(backup))
+	observation.Time = ptr.To(metav1.Now())
+
+	return observation
+}
+
+// exportBackup writes the backup of the Quality Profile to the ConfigMap of its backup export when it has changed.
+// The backup is only fetched when the content of the Quality Profile changed since the last export, or the exported backup is missing.
+func (c *external) exportBackup(ctx context.Context, profile *v1alpha1.QualityProfile) error {
+	export := profile.Spec.ForProvider.BackupExport
+	key := instance.GetQualityProfileBackupExportKey(export)
+	revision := instance.GenerateQualityProfileBackupExportRevision(export, &profile.Status.AtProvider)
+
+	configMap := &corev1.ConfigMap{}
+
+	err := c.kube.Get(ctx, types.NamespacedName{Name: export.ConfigMapName, Namespace: profile.GetNamespace()}, configMap)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+
+	exists := err == nil
+
+	// A ConfigMap created by someone else, or by another QualityProfile, is never taken over
+	if exists && !metav1.IsControlledBy(configMap, profile) {
+		return errors.Errorf(errBackupNotControlled, export.ConfigMapName)
+	}
+
+	if _, exported := configMap.Data[key]; exported && profile.Status.AtProvider.BackupExportRevision == revision {
+		return nil
+	}
+
+	backup, resp, err := c.qualityProfilesClient.Backup(instance.GenerateQualityProfileBackupOption(&profile.Status.AtProvider)) //nolint:bodyclose // closed via helpers.CloseBody
+	defer helpers.CloseBody(resp)
+
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !exists:
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            export.ConfigMapName,
+				Namespace:       profile.GetNamespace(),
+				OwnerReferences: []metav1.OwnerReference{meta.AsController(meta.TypedReferenceTo(profile, v1alpha1.QualityProfileGroupVersionKind))},
+			},
+			Data: map[string]string{key: *backup},
+		}
+
+		err = c.kube.Create(ctx, configMap)
+	case configMap.Data[key] != *backup:
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+
+		configMap.Data[key] = *backup
+		err = c.kube.Update(ctx, configMap)
+	default:
+		// The backup did not change, e.g. the rules were updated back and forth
+		profile.Status.AtProvider.BackupExportRevision = revision
+
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	profile.Status.AtProvider.BackupExportTime = ptr.To(metav1.Now())
+	profile.Status.AtProvider.BackupExportRevision = revision
+
+	return nil
+}
+
 // Delete deletes the external resource.
 func (c *external) Delete(ctx context.Context, managedResource resource.Managed) (managed.ExternalDelete, error) {
 	profile, ok := managedResource.(*v1alpha1.QualityProfile)
@@ -313,7 +862,35 @@ func (c *external) Delete(ctx context.Context, managedResource resource.Managed)
 		return managed.ExternalDelete{}, nil
 	}
 
-	destroyResp, err := c.qualityProfilesClient.Delete(instance.GenerateDeleteQualityProfileOption(profile.Spec.ForProvider)) //nolint:bodyclose // closed via helpers.CloseBody
+	// Resolve the Quality Profile by its key, the name of the spec may not match it while a rename is pending
+	qualityProfile, resp, err := c.qualityProfilesClient.Show(&sonar.QualityprofilesShowOption{ //nolint:bodyclose // closed via helpers.CloseBody
+		Key: externalName,
+	})
+	defer helpers.CloseBody(resp)
+
+	if err != nil {
+		err = common.ClassifyError(resp, err)
+		if common.IsNotFound(err) {
+			return managed.ExternalDelete{}, nil
+		}
+
+		return managed.ExternalDelete{}, errors.Wrap(err, errShowQualityProfile)
+	}
+
+	shown := qualityProfile.Profile
+	if shown.IsDefault {
+		message := fmt.Sprintf(errDeleteDefault, shown.Name, shown.Language)
+		profile.Status.SetConditions(v1alpha1.DeletionBlocked(v1alpha1.ReasonDefaultProfile, message))
+
+		return managed.ExternalDelete{}, errors.New(message)
+	}
+
+	err = c.releaseQualityProfile(profile, shown)
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
+	destroyResp, err := c.qualityProfilesClient.Delete(instance.GenerateDeleteQualityProfileOption(shown)) //nolint:bodyclose // closed via helpers.CloseBody
 	defer helpers.CloseBody(destroyResp)
 
 	if err != nil {
@@ -323,12 +900,62 @@ func (c *external) Delete(ctx context.Context, managedResource resource.Managed)
 	return managed.ExternalDelete{}, nil
 }
 
+// releaseQualityProfile handles the projects and the child Quality Profiles referencing the Quality Profile before its deletion,
+// according to its deletion behavior. The deletion is blocked with a condition when they cannot be released.
+func (c *external) releaseQualityProfile(profile *v1alpha1.QualityProfile, shown sonar.ShownProfile) error {
+	projects, err := instance.FetchAllQualityProfileProjects(c.qualityProfilesClient, shown.Key)
+	if err != nil {
+		return errors.Wrap(err, errListProjects)
+	}
+
+	inheritance, resp, err := c.qualityProfilesClient.Inheritance(instance.GenerateQualityProfileInheritanceOption(shown)) //nolint:bodyclose // closed via helpers.CloseBody
+	defer helpers.CloseBody(resp)
+
+	if err != nil {
+		return errors.Wrap(err, errInheritance)
+	}
+
+	behavior := instance.GetQualityProfileDeletionBehavior(profile.Spec.ForProvider)
+
+	violations := instance.CheckQualityProfileDeletion(behavior, projects, inheritance.Children)
+	if len(violations) > 0 {
+		message := fmt.Sprintf(errDeletionBlocked, shown.Name, strings.Join(violations, "; "))
+		profile.Status.SetConditions(v1alpha1.DeletionBlocked(v1alpha1.ReasonProfileInUse, message))
+
+		return errors.New(message)
+	}
+
+	// Attach the children to the parent, SonarQube would delete them along with the Quality Profile otherwise
+	for _, child := range inheritance.Children {
+		changeResp, err := c.qualityProfilesClient.ChangeParent(instance.GenerateQualityProfileReparentOption(shown.Language, inheritance, child.Name)) //nolint:bodyclose // closed via helpers.CloseBody
+		helpers.CloseBody(changeResp)
+
+		if err != nil {
+			return errors.Wrapf(err, errReparentChild, child.Name)
+		}
+	}
+
+	for _, project := range projects {
+		removeResp, err := c.qualityProfilesClient.RemoveProject(instance.GenerateQualityProfileRemoveProjectOption(shown, project)) //nolint:bodyclose // closed via helpers.CloseBody
+		helpers.CloseBody(removeResp)
+
+		if err != nil {
+			return errors.Wrapf(err, errReassignProject, project)
+		}
+	}
+
+	return nil
+}
+
 func (c *external) Disconnect(ctx context.Context) error {
 	return nil
 }
 
-func (c *external) syncQualityProfileRules(profile *v1alpha1.QualityProfile, associations map[string]instance.QualityProfileRuleAssociation) error {
-	if len(associations) == 0 {
+// syncQualityProfileRules activates, updates and deactivates the rules of the Quality Profile so that the active rules are the rules of the selection,
+// which are the rules of the spec merged with the rules selected by its rule selectors.
+func (c *external) syncQualityProfileRules(profile *v1alpha1.QualityProfile, selection instance.QualityProfileRuleSelection, associations map[string]instance.QualityProfileRuleAssociation) error {
+	deactivatedRules := instance.FindActiveDeactivatedRules(&profile.Spec.ForProvider, &profile.Status.AtProvider)
+	if len(associations) == 0 && len(deactivatedRules) == 0 {
 		return nil
 	}
 
@@ -337,20 +964,38 @@ func (c *external) syncQualityProfileRules(profile *v1alpha1.QualityProfile, ass
 		return fmt.Errorf("external name is not set for Quality Profile %s", profile.Name)
 	}
 
+	sync := &v1alpha1.QualityProfileRulesSyncObservation{}
+
+	// Keep the rules to activate and deactivate, to record the changes in the activated rules ledger
+	toActivate := instance.FindNonExistingQualityProfileRules(associations)
+	toDeactivate := instance.FindMissingQualityProfileRules(associations)
+
 	var aggregatedErrors []error
 
+	// Phase 0: Deactivate the rules the spec explicitly deactivates, or reset them when a parent Quality Profile forces their activation
+	explicitErrors := c.deactivateExplicitlyDeactivatedRules(profile, deactivatedRules, sync)
+	aggregatedErrors = append(aggregatedErrors, explicitErrors...)
+
 	// Phase 1: Deactivate rules that should not be active (in observation but not in spec)
-	deactivateErrors := c.deactivateUnwantedQualityProfileRules(externalName, associations)
+	deactivateErrors := c.deactivateUnwantedQualityProfileRules(externalName, profile.Status.AtProvider.Rules, associations, sync)
 	aggregatedErrors = append(aggregatedErrors, deactivateErrors...)
 
 	// Phase 2: Activate rules that should be active (in spec but not in observation)
-	activateErrors := c.activateMissingQualityProfileRules(externalName, associations)
+	activateErrors := c.activateMissingQualityProfileRules(externalName, profile.Spec.ForProvider.Language, selection, associations, sync)
 	aggregatedErrors = append(aggregatedErrors, activateErrors...)
 
 	// Phase 3: Update rules that are out of date (in both but with different parameters)
-	updateErrors := c.updateOutdatedQualityProfileRules(externalName, associations)
+	updateErrors := c.updateOutdatedQualityProfileRules(externalName, associations, sync)
 	aggregatedErrors = append(aggregatedErrors, updateErrors...)
 
+	// Report the changes, the status is persisted even if the synchronization failed
+	sync.Time = ptr.To(metav1.Now())
+	profile.Status.AtProvider.RulesSync = sync
+
+	if instance.IsAdditiveRulesPolicy(profile.Spec.ForProvider) {
+		recordActivatedRules(profile, associations, toActivate, toDeactivate)
+	}
+
 	if len(aggregatedErrors) > 0 {
 		return errors.Errorf("encountered %d error(s) during Quality Profile rules sync: %v", len(aggregatedErrors), aggregatedErrors)
 	}
@@ -358,26 +1003,165 @@ func (c *external) syncQualityProfileRules(profile *v1alpha1.QualityProfile, ass
 	return nil
 }
 
+// recordActivatedRules records the rules activated by the synchronization in the activated rules ledger and forgets the rules it deactivated.
+// It relies on the synchronization marking the associations of the activated rules up to date and removing those of the deactivated rules.
+func recordActivatedRules(profile *v1alpha1.QualityProfile, associations map[string]instance.QualityProfileRuleAssociation, toActivate []*v1alpha1.QualityProfileRuleParameters, toDeactivate []*v1alpha1.QualityProfileRuleObservation) {
+	activated := make([]string, 0, len(toActivate))
+
+	for _, ruleSpec := range toActivate {
+		if associations[ruleSpec.Rule].UpToDate {
+			activated = append(activated, ruleSpec.Rule)
+		}
+	}
+
+	deactivated := make([]string, 0, len(toDeactivate))
+
+	for _, ruleObservation := range toDeactivate {
+		if _, exists := associations[ruleObservation.Key]; !exists {
+			deactivated = append(deactivated, ruleObservation.Key)
+		}
+	}
+
+	instance.RecordActivatedRules(&profile.Status.AtProvider, activated)
+	instance.ForgetActivatedRules(&profile.Status.AtProvider, deactivated)
+}
+
+// setRulesConflictCondition reports whether deactivated rules are kept active by a parent Quality Profile.
+// The condition is only set on Quality Profiles that deactivate rules or have conflicts.
+func setRulesConflictCondition(profile *v1alpha1.QualityProfile) {
+	conflicts := profile.Status.AtProvider.RuleConflicts
+	if len(conflicts) == 0 {
+		if len(profile.Spec.ForProvider.DeactivatedRules) > 0 || profile.GetCondition(v1alpha1.TypeRulesConflict).Status == corev1.ConditionTrue {
+			profile.Status.SetConditions(v1alpha1.NoRulesConflict())
+		}
+
+		return
+	}
+
+	messages := make([]string, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		messages = append(messages, conflict.Rule+": "+conflict.Message)
+	}
+
+	profile.Status.SetConditions(v1alpha1.RulesConflict("deactivated rules are forced by a parent Quality Profile: " + strings.Join(messages, "; ")))
+}
+
+// deactivateExplicitlyDeactivatedRules deactivates the active rules listed in the deactivated rules of the spec.
+// When SonarQube refuses to deactivate an inherited rule, the rule is recorded as a conflict and, if it overrides the settings of
+// the parent Quality Profile, reset to them. Returns a slice of errors encountered during deactivation.
+func (c *external) deactivateExplicitlyDeactivatedRules(profile *v1alpha1.QualityProfile, rules []*v1alpha1.QualityProfileRuleObservation, sync *v1alpha1.QualityProfileRulesSyncObservation) []error {
+	var errs []error
+
+	externalName := meta.GetExternalName(profile)
+
+	for _, ruleObservation := range rules {
+		deactivateResp, err := c.qualityProfilesClient.DeactivateRule(instance.GenerateQualityProfileDeactivateRuleOption(externalName, ruleObservation.Key)) //nolint:bodyclose // closed via helpers.CloseBody
+		helpers.CloseBody(deactivateResp)
+
+		sync.IndividualRequests++
+
+		if err == nil {
+			sync.Deactivated++
+
+			instance.ForgetActivatedRules(&profile.Status.AtProvider, []string{ruleObservation.Key})
+
+			continue
+		}
+
+		err = common.ClassifyError(deactivateResp, err)
+		if !instance.IsInheritedRule(ruleObservation) || !common.IsRequestRejected(err) {
+			sync.Failed++
+			errs = append(errs, errors.Wrapf(err, "cannot deactivate rule %s", ruleObservation.Key))
+
+			continue
+		}
+
+		// A parent Quality Profile forces the activation of the rule, only its overridden settings can be reset
+		instance.RecordRuleConflict(&profile.Status.AtProvider, ruleObservation.Key, err.Error())
+
+		if ruleObservation.Inheritance != instance.RuleInheritanceOverrides {
+			continue
+		}
+
+		resetResp, err := c.qualityProfilesClient.ActivateRule(instance.GenerateQualityProfileResetRuleOption(externalName, ruleObservation.Key)) //nolint:bodyclose // closed via helpers.CloseBody
+		helpers.CloseBody(resetResp)
+
+		sync.IndividualRequests++
+
+		if err != nil {
+			sync.Failed++
+			errs = append(errs, errors.Wrapf(err, "cannot reset rule %s", ruleObservation.Key))
+		}
+	}
+
+	return errs
+}
+
+// bulkChangeError returns an error describing the rules a bulk request could not change, or nil if it changed all of them.
+// The target describes the rules the bulk request changes, e.g. "repository java".
+func bulkChangeError(change *instance.QualityProfilesBulkChange, action string, target string) error {
+	if change.Failed == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(change.Errors))
+	for _, changeError := range change.Errors {
+		messages = append(messages, changeError.Msg)
+	}
+
+	return errors.Errorf("cannot %s %d rule(s) of %s: %s", action, change.Failed, target, strings.Join(messages, "; "))
+}
+
 // deactivateUnwantedQualityProfileRules deactivates rules that are in the observation but not in the spec.
+// The rules of a repository are deactivated with a single bulk request when none of its active rules are kept.
 // Returns a slice of errors encountered during deactivation.
-func (c *external) deactivateUnwantedQualityProfileRules(externalName string, associations map[string]instance.QualityProfileRuleAssociation) []error {
+func (c *external) deactivateUnwantedQualityProfileRules(externalName string, active []v1alpha1.QualityProfileRuleObservation, associations map[string]instance.QualityProfileRuleAssociation, sync *v1alpha1.QualityProfileRulesSyncObservation) []error {
 	var errs []error
 
-	missingRules := instance.FindMissingQualityProfileRules(associations)
+	groups, missingRules := instance.GroupQualityProfileRulesForBulkDeactivation(instance.FindMissingQualityProfileRules(associations), active)
+
+	for _, group := range groups {
+		change, deactivateResp, err := c.qualityProfilesClient.BulkDeactivateRules(instance.GenerateQualityProfileDeactivateRulesOption(externalName, group.Repository)) //nolint:bodyclose // closed via helpers.CloseBody
+		helpers.CloseBody(deactivateResp)
+
+		sync.BulkRequests++
+
+		if err != nil {
+			sync.Failed += int64(len(group.Rules))
+			errs = append(errs, errors.Wrapf(err, "cannot deactivate rules of repository %s", group.Repository))
+
+			continue
+		}
+
+		sync.Deactivated += change.Succeeded
+		sync.Failed += change.Failed
+
+		// The rules of a partially failed bulk deactivation are kept, those still active are found again by the next Observe
+		if err := bulkChangeError(change, "deactivate", "repository "+group.Repository); err != nil {
+			errs = append(errs, err)
 
-	for _, ruleObservation := range missingRules {
-		if ruleObservation == nil {
 			continue
 		}
 
+		for _, ruleObservation := range group.Rules {
+			delete(associations, ruleObservation.Key)
+		}
+	}
+
+	for _, ruleObservation := range missingRules {
 		deactivateResp, err := c.qualityProfilesClient.DeactivateRule(instance.GenerateQualityProfileDeactivateRuleOption(externalName, ruleObservation.Key)) //nolint:bodyclose // closed via helpers.CloseBody
 		helpers.CloseBody(deactivateResp)
 
+		sync.IndividualRequests++
+
 		if err != nil {
+			sync.Failed++
 			errs = append(errs, errors.Wrapf(err, "cannot deactivate rule %s", ruleObservation.Key))
 
 			continue
 		}
+
+		sync.Deactivated++
 		// Remove from associations after successful deactivation
 		delete(associations, ruleObservation.Key)
 	}
@@ -386,25 +1170,72 @@ func (c *external) deactivateUnwantedQualityProfileRules(externalName string, as
 }
 
 // activateMissingQualityProfileRules activates rules that are in the spec but not in the observation.
+// The rules contributed by a rule selector are activated with a single bulk request using the query of the selector,
+// when that query activates exactly the rules of the selector, since a bulk request cannot select a list of rules.
 // Returns a slice of errors encountered during activation.
-func (c *external) activateMissingQualityProfileRules(externalName string, associations map[string]instance.QualityProfileRuleAssociation) []error {
+func (c *external) activateMissingQualityProfileRules(externalName string, language string, selection instance.QualityProfileRuleSelection, associations map[string]instance.QualityProfileRuleAssociation, sync *v1alpha1.QualityProfileRulesSyncObservation) []error {
 	var errs []error
 
-	nonExistingRules := instance.FindNonExistingQualityProfileRules(associations)
+	groups, nonExistingRules := instance.GroupQualityProfileRulesForBulkActivation(instance.FindNonExistingQualityProfileRules(associations), selection)
+
+	for _, group := range groups {
+		change, activateResp, err := c.qualityProfilesClient.BulkActivateRules(instance.GenerateQualityProfileActivateRulesOption(externalName, language, group)) //nolint:bodyclose // closed via helpers.CloseBody
+		helpers.CloseBody(activateResp)
+
+		sync.BulkRequests++
+
+		if err != nil {
+			sync.Failed += int64(len(group.Rules))
+			errs = append(errs, errors.Wrapf(err, "cannot activate rules of rule selector %s", group.Selector.Name))
 
-	for _, ruleSpec := range nonExistingRules {
-		if ruleSpec == nil {
 			continue
 		}
 
+		sync.Activated += change.Succeeded
+		sync.Failed += change.Failed
+
+		activated := group.Rules
+
+		// The response does not tell which rules were activated, so the active rules of the selector are observed again
+		if err := bulkChangeError(change, "activate", "rule selector "+group.Selector.Name); err != nil {
+			errs = append(errs, err)
+
+			activated, err = c.findActivatedQualityProfileRules(externalName, language, group)
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		for _, ruleSpec := range activated {
+			associations[ruleSpec.Rule] = instance.QualityProfileRuleAssociation{
+				Spec:        ruleSpec,
+				Observation: nil, // Will be populated on next Observe
+				UpToDate:    true,
+			}
+		}
+	}
+
+	for _, ruleSpec := range nonExistingRules {
+		// The query of its rule selector would activate other rules or other settings, so the rule is activated on its own
+		if _, selected := selection.Selectors[ruleSpec.Rule]; selected {
+			if _, bulk := selection.BulkSelector(ruleSpec.Rule); !bulk {
+				sync.BulkFallbackRules++
+			}
+		}
+
 		activateResp, err := c.qualityProfilesClient.ActivateRule(instance.GenerateQualityProfileActivateRuleOption(externalName, *ruleSpec)) //nolint:bodyclose // closed via helpers.CloseBody
 		helpers.CloseBody(activateResp)
 
+		sync.IndividualRequests++
+
 		if err != nil {
+			sync.Failed++
 			errs = append(errs, errors.Wrapf(err, "cannot activate rule %s", ruleSpec.Rule))
 
 			continue
 		}
+
+		sync.Activated++
 		// Update association to reflect the activation (mark as up to date)
 		associations[ruleSpec.Rule] = instance.QualityProfileRuleAssociation{
 			Spec:        ruleSpec,
@@ -416,10 +1247,29 @@ func (c *external) activateMissingQualityProfileRules(externalName string, assoc
 	return errs
 }
 
+// findActivatedQualityProfileRules returns the rules of the group that are active in the Quality Profile, e.g. after a partially failed bulk activation.
+// It returns no rules if the active rules cannot be observed, the rules are then found again by the next Observe.
+func (c *external) findActivatedQualityProfileRules(externalName string, language string, group instance.QualityProfileRuleActivationGroup) ([]*v1alpha1.QualityProfileRuleParameters, error) {
+	active, err := instance.FetchAllActiveRuleSelectorKeys(c.rulesClient, externalName, language, group.Selector)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot observe the active rules of rule selector %s", group.Selector.Name)
+	}
+
+	activated := make([]*v1alpha1.QualityProfileRuleParameters, 0, len(group.Rules))
+
+	for _, ruleSpec := range group.Rules {
+		if slices.Contains(active, ruleSpec.Rule) {
+			activated = append(activated, ruleSpec)
+		}
+	}
+
+	return activated, nil
+}
+
 // updateOutdatedQualityProfileRules updates rules that have different parameters between spec and observation.
 // For SonarQube, updating a rule means re-activating it with the new parameters.
 // Returns a slice of errors encountered during update.
-func (c *external) updateOutdatedQualityProfileRules(externalName string, associations map[string]instance.QualityProfileRuleAssociation) []error {
+func (c *external) updateOutdatedQualityProfileRules(externalName string, associations map[string]instance.QualityProfileRuleAssociation, sync *v1alpha1.QualityProfileRulesSyncObservation) []error {
 	var errs []error
 
 	outdatedRules := instance.FindNotUpToDateQualityProfileRules(associations)
@@ -432,11 +1282,16 @@ func (c *external) updateOutdatedQualityProfileRules(externalName string, associ
 		activateResp, err := c.qualityProfilesClient.ActivateRule(instance.GenerateQualityProfileActivateRuleOption(externalName, *assoc.Spec)) //nolint:bodyclose // closed via helpers.CloseBody
 		helpers.CloseBody(activateResp)
 
+		sync.IndividualRequests++
+
 		if err != nil {
+			sync.Failed++
 			errs = append(errs, errors.Wrapf(err, "cannot update rule %s", assoc.Spec.Rule))
 
 			continue
 		}
+
+		sync.Activated++
 		// Update association to reflect the update
 		associations[assoc.Spec.Rule] = instance.QualityProfileRuleAssociation{
 			Spec:        assoc.Spec,
@@ -18,19 +18,31 @@ package qualityprofile
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/boxboxjason/sonarqube-client-go/sonar"
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/v2/pkg/event"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	v1alpha1 "github.com/crossplane/provider-sonarqube/apis/instance/v1alpha1"
+	"github.com/crossplane/provider-sonarqube/internal/clients/common"
 	"github.com/crossplane/provider-sonarqube/internal/clients/instance"
 	"github.com/crossplane/provider-sonarqube/internal/fake"
 )
@@ -82,9 +94,41 @@ func TestObserve(t *testing.T) {
 	cases := map[string]struct {
 		qualityProfilesClient *fake.MockQualityProfilesClient
 		rulesClient           *fake.MockRulesClient
+		capabilities          common.Capabilities
 		args                  args
 		want                  want
 	}{
+		"UnsupportedFieldsAreRejected": {
+			qualityProfilesClient: &fake.MockQualityProfilesClient{},
+			rulesClient:           &fake.MockRulesClient{},
+			capabilities:          common.Capabilities{Version: &common.ServerVersion{Major: 9, Minor: 9}, Edition: common.EditionCommunity},
+			args: args{
+				ctx: context.Background(),
+				mg: func() *v1alpha1.QualityProfile {
+					qp := &v1alpha1.QualityProfile{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "test-profile",
+							Annotations: map[string]string{},
+						},
+						Spec: v1alpha1.QualityProfileSpec{
+							ForProvider: v1alpha1.QualityProfileParameters{
+								Rules: []v1alpha1.QualityProfileRuleParameters{
+									{Rule: "java:S100", Prioritized: ptr.To(true)},
+								},
+							},
+						},
+					}
+					meta.SetExternalName(qp, "AU-TpxcA-iU5OvuD2FLz")
+
+					return qp
+				}(),
+			},
+			want: want{
+				o: managed.ExternalObservation{},
+				err: errors.Errorf(errUnsupportedFeatures,
+					"java:S100: using prioritized rules requires SonarQube 10.6.0 or later, the SonarQube instance runs version 9.9.0 community edition"),
+			},
+		},
 		"NotQualityProfileError": {
 			qualityProfilesClient: &fake.MockQualityProfilesClient{},
 			rulesClient:           &fake.MockRulesClient{},
@@ -114,10 +158,10 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
-		"ShowFailsReturnsNotExists": {
+		"ShowNotFoundReturnsNotExists": {
 			qualityProfilesClient: &fake.MockQualityProfilesClient{
 				ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
-					return nil, nil, errors.New("api error")
+					return nil, &http.Response{StatusCode: http.StatusNotFound}, errors.New("api error")
 				},
 			},
 			rulesClient: &fake.MockRulesClient{},
@@ -140,6 +184,84 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"ShowUnauthorizedReturnsError": {
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
+					return nil, &http.Response{StatusCode: http.StatusUnauthorized}, errors.New("api error")
+				},
+			},
+			rulesClient: &fake.MockRulesClient{},
+			args: args{
+				ctx: context.Background(),
+				mg: func() *v1alpha1.QualityProfile {
+					qp := &v1alpha1.QualityProfile{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "test-profile",
+							Annotations: map[string]string{},
+						},
+					}
+					meta.SetExternalName(qp, "AU-TpxcA-iU5OvuD2FLz")
+
+					return qp
+				}(),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errors.New("api error"), errShowQualityProfile),
+			},
+		},
+		"ShowServerErrorReturnsError": {
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
+					return nil, &http.Response{StatusCode: http.StatusInternalServerError}, errors.New("api error")
+				},
+			},
+			rulesClient: &fake.MockRulesClient{},
+			args: args{
+				ctx: context.Background(),
+				mg: func() *v1alpha1.QualityProfile {
+					qp := &v1alpha1.QualityProfile{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "test-profile",
+							Annotations: map[string]string{},
+						},
+					}
+					meta.SetExternalName(qp, "AU-TpxcA-iU5OvuD2FLz")
+
+					return qp
+				}(),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errors.New("api error"), errShowQualityProfile),
+			},
+		},
+		"ShowTimeoutReturnsError": {
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
+					return nil, nil, errors.New("api error")
+				},
+			},
+			rulesClient: &fake.MockRulesClient{},
+			args: args{
+				ctx: context.Background(),
+				mg: func() *v1alpha1.QualityProfile {
+					qp := &v1alpha1.QualityProfile{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:        "test-profile",
+							Annotations: map[string]string{},
+						},
+					}
+					meta.SetExternalName(qp, "AU-TpxcA-iU5OvuD2FLz")
+
+					return qp
+				}(),
+			},
+			want: want{
+				o:   managed.ExternalObservation{},
+				err: errors.Wrap(errors.New("api error"), errShowQualityProfile),
+			},
+		},
 		"SuccessfulObserveResourceExists": {
 			qualityProfilesClient: &fake.MockQualityProfilesClient{
 				ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
@@ -316,6 +438,7 @@ func TestObserve(t *testing.T) {
 			e := &external{
 				qualityProfilesClient: tc.qualityProfilesClient,
 				rulesClient:           tc.rulesClient,
+				capabilities:          tc.capabilities,
 			}
 			got, err := e.Observe(tc.args.ctx, tc.args.mg)
 
@@ -504,6 +627,15 @@ func TestDelete(t *testing.T) {
 		},
 		"SuccessfulDelete": {
 			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
+					return &sonar.QualityprofilesShow{Profile: sonar.ShownProfile{Key: "AU-TpxcA-iU5OvuD2FLz", Name: "test-profile", Language: "java"}}, nil, nil
+				},
+				ProjectsFn: func(opt *sonar.QualityprofilesProjectsOption) (*sonar.QualityprofilesProjects, *http.Response, error) {
+					return &sonar.QualityprofilesProjects{}, nil, nil
+				},
+				InheritanceFn: func(opt *sonar.QualityprofilesInheritanceOption) (*sonar.QualityprofilesInheritance, *http.Response, error) {
+					return &sonar.QualityprofilesInheritance{}, nil, nil
+				},
 				DeleteFn: func(opt *sonar.QualityprofilesDeleteOption) (*http.Response, error) {
 					return mockHTTPResponse(), nil
 				},
@@ -534,6 +666,15 @@ func TestDelete(t *testing.T) {
 		},
 		"DeleteFails": {
 			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
+					return &sonar.QualityprofilesShow{Profile: sonar.ShownProfile{Key: "AU-TpxcA-iU5OvuD2FLz", Name: "test-profile", Language: "java"}}, nil, nil
+				},
+				ProjectsFn: func(opt *sonar.QualityprofilesProjectsOption) (*sonar.QualityprofilesProjects, *http.Response, error) {
+					return &sonar.QualityprofilesProjects{}, nil, nil
+				},
+				InheritanceFn: func(opt *sonar.QualityprofilesInheritanceOption) (*sonar.QualityprofilesInheritance, *http.Response, error) {
+					return &sonar.QualityprofilesInheritance{}, nil, nil
+				},
 				DeleteFn: func(opt *sonar.QualityprofilesDeleteOption) (*http.Response, error) {
 					return nil, errors.New("api error")
 				},
@@ -744,7 +885,7 @@ func TestSyncQualityProfileRules(t *testing.T) {
 			t.Parallel()
 
 			e := &external{qualityProfilesClient: tc.qualityProfilesClient}
-			err := e.syncQualityProfileRules(tc.args.cr, tc.args.associations)
+			err := e.syncQualityProfileRules(tc.args.cr, instance.MergeQualityProfileRules(tc.args.cr.Spec.ForProvider, nil), tc.args.associations)
 
 			// Special case for error aggregation test
 			if name == "ErrorAggregation" {
@@ -761,3 +902,1737 @@ func TestSyncQualityProfileRules(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncQualityProfileRulesBulk(t *testing.T) {
+	t.Parallel()
+
+	errActivateRule := errors.New("rule activated individually")
+
+	qualityProfile := func(specRules []v1alpha1.QualityProfileRuleParameters, activeRules ...string) *v1alpha1.QualityProfile {
+		qp := &v1alpha1.QualityProfile{
+			Spec: v1alpha1.QualityProfileSpec{
+				ForProvider: v1alpha1.QualityProfileParameters{Name: "Sonar way", Language: "java", Rules: specRules},
+			},
+		}
+		for _, key := range activeRules {
+			qp.Status.AtProvider.Rules = append(qp.Status.AtProvider.Rules, v1alpha1.QualityProfileRuleObservation{Key: key})
+		}
+
+		meta.SetExternalName(qp, "AU-TpxcA-iU5OvuD2FLz")
+
+		return qp
+	}
+
+	additive := func(qp *v1alpha1.QualityProfile) *v1alpha1.QualityProfile {
+		qp.Spec.ForProvider.RulesPolicy = ptr.To(instance.QualityProfileRulesPolicyAdditive)
+
+		return qp
+	}
+
+	searchRepository := func(keys ...string) func(opt *sonar.RulesSearchOption) (*sonar.RulesSearch, *http.Response, error) {
+		return func(opt *sonar.RulesSearchOption) (*sonar.RulesSearch, *http.Response, error) {
+			rules := make([]sonar.RuleDetails, 0, len(keys))
+			for _, key := range keys {
+				rules = append(rules, sonar.RuleDetails{Key: key})
+			}
+
+			return &sonar.RulesSearch{Rules: rules, Paging: sonar.Paging{Total: int64(len(keys))}}, mockHTTPResponse(), nil
+		}
+	}
+
+	// searchActive returns the active rules for searches on the Quality Profile, and the rules of the repository otherwise
+	searchActive := func(active []string, keys ...string) func(opt *sonar.RulesSearchOption) (*sonar.RulesSearch, *http.Response, error) {
+		return func(opt *sonar.RulesSearchOption) (*sonar.RulesSearch, *http.Response, error) {
+			if opt.Qprofile != "" {
+				return searchRepository(active...)(opt)
+			}
+
+			return searchRepository(keys...)(opt)
+		}
+	}
+
+	defaultRules := []v1alpha1.QualityProfileRuleParameters{{Rule: "java:S1"}, {Rule: "java:S2"}, {Rule: "java:S3"}}
+	bugs := []v1alpha1.QualityProfileRuleSelector{{Name: "bugs", Types: []string{"BUG"}}}
+	bugRules := []instance.SelectedRules{{Keys: []string{"java:S1", "java:S2", "java:S3"}, Bulk: true}}
+
+	// withSelectors adds rule selectors to the Quality Profile
+	withSelectors := func(qp *v1alpha1.QualityProfile, selectors []v1alpha1.QualityProfileRuleSelector) *v1alpha1.QualityProfile {
+		qp.Spec.ForProvider.RuleSelectors = selectors
+
+		return qp
+	}
+
+	type want struct {
+		sync      *v1alpha1.QualityProfileRulesSyncObservation
+		activated []string
+		err       error
+	}
+
+	cases := map[string]struct {
+		qualityProfilesClient *fake.MockQualityProfilesClient
+		rulesClient           *fake.MockRulesClient
+		cr                    *v1alpha1.QualityProfile
+		selected              []instance.SelectedRules
+		want                  want
+	}{
+		"BulkActivateSelector": {
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				BulkActivateRulesFn: func(opt *sonar.QualityprofilesActivateRulesOption) (*instance.QualityProfilesBulkChange, *http.Response, error) {
+					if !cmp.Equal(opt.Types, []string{"BUG"}) || !cmp.Equal(opt.Languages, []string{"java"}) {
+						return nil, nil, errors.New("the bulk activation does not use the query of the rule selector")
+					}
+
+					return &instance.QualityProfilesBulkChange{Succeeded: 3}, mockHTTPResponse(), nil
+				},
+				ActivateRuleFn: func(opt *sonar.QualityprofilesActivateRuleOption) (*http.Response, error) {
+					return nil, errActivateRule
+				},
+			},
+			cr:       withSelectors(qualityProfile(nil), bugs),
+			selected: bugRules,
+			want: want{
+				sync: &v1alpha1.QualityProfileRulesSyncObservation{Activated: 3, BulkRequests: 1},
+			},
+		},
+		"CustomParamsActivatedIndividually": {
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				BulkActivateRulesFn: func(opt *sonar.QualityprofilesActivateRulesOption) (*instance.QualityProfilesBulkChange, *http.Response, error) {
+					return &instance.QualityProfilesBulkChange{Succeeded: 3}, mockHTTPResponse(), nil
+				},
+				ActivateRuleFn: func(opt *sonar.QualityprofilesActivateRuleOption) (*http.Response, error) {
+					if len(opt.Params) == 0 {
+						return nil, errActivateRule
+					}
+
+					return mockHTTPResponse(), nil
+				},
+			},
+			cr: withSelectors(qualityProfile([]v1alpha1.QualityProfileRuleParameters{{
+				Rule:       "javasecurity:S4",
+				Parameters: &map[string]string{"max": "5"},
+			}}), bugs),
+			selected: bugRules,
+			want: want{
+				sync: &v1alpha1.QualityProfileRulesSyncObservation{Activated: 4, BulkRequests: 1, IndividualRequests: 1},
+			},
+		},
+		"SpecRulesActivatedIndividually": {
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				BulkActivateRulesFn: func(opt *sonar.QualityprofilesActivateRulesOption) (*instance.QualityProfilesBulkChange, *http.Response, error) {
+					return nil, nil, errors.New("the bulk activation would activate the whole repository")
+				},
+				ActivateRuleFn: func(opt *sonar.QualityprofilesActivateRuleOption) (*http.Response, error) {
+					return mockHTTPResponse(), nil
+				},
+			},
+			cr: qualityProfile(defaultRules),
+			want: want{
+				sync: &v1alpha1.QualityProfileRulesSyncObservation{Activated: 3, IndividualRequests: 3},
+			},
+		},
+		"SelectorWithPatternsActivatedIndividually": {
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				BulkActivateRulesFn: func(opt *sonar.QualityprofilesActivateRulesOption) (*instance.QualityProfilesBulkChange, *http.Response, error) {
+					return nil, nil, errors.New("the bulk activation would activate the excluded rules")
+				},
+				ActivateRuleFn: func(opt *sonar.QualityprofilesActivateRuleOption) (*http.Response, error) {
+					return mockHTTPResponse(), nil
+				},
+			},
+			cr:       withSelectors(qualityProfile(nil), []v1alpha1.QualityProfileRuleSelector{{Name: "bugs", Types: []string{"BUG"}, Exclude: []string{"java:S4"}}}),
+			selected: []instance.SelectedRules{{Keys: []string{"java:S1", "java:S2", "java:S3"}}},
+			want: want{
+				sync: &v1alpha1.QualityProfileRulesSyncObservation{Activated: 3, IndividualRequests: 3, BulkFallbackRules: 3},
+			},
+		},
+		"BulkActivationPartiallyFails": {
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				BulkActivateRulesFn: func(opt *sonar.QualityprofilesActivateRulesOption) (*instance.QualityProfilesBulkChange, *http.Response, error) {
+					return &instance.QualityProfilesBulkChange{
+						Succeeded: 2,
+						Failed:    1,
+						Errors:    []instance.QualityProfilesBulkChangeError{{Msg: "Rule java:S3 cannot be activated"}},
+					}, mockHTTPResponse(), nil
+				},
+			},
+			rulesClient: &fake.MockRulesClient{SearchFn: searchActive([]string{"java:S1", "java:S2"}, "java:S1", "java:S2", "java:S3")},
+			cr:          additive(withSelectors(qualityProfile(nil), bugs)),
+			selected:    bugRules,
+			want: want{
+				sync:      &v1alpha1.QualityProfileRulesSyncObservation{Activated: 2, Failed: 1, BulkRequests: 1},
+				activated: []string{"java:S1", "java:S2"},
+				err:       errors.New("encountered 1 error(s) during Quality Profile rules sync: [cannot activate 1 rule(s) of rule selector bugs: Rule java:S3 cannot be activated]"),
+			},
+		},
+		"BulkActivationPartiallyFailsUnobserved": {
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				BulkActivateRulesFn: func(opt *sonar.QualityprofilesActivateRulesOption) (*instance.QualityProfilesBulkChange, *http.Response, error) {
+					return &instance.QualityProfilesBulkChange{Succeeded: 2, Failed: 1}, mockHTTPResponse(), nil
+				},
+			},
+			rulesClient: &fake.MockRulesClient{SearchFn: func(opt *sonar.RulesSearchOption) (*sonar.RulesSearch, *http.Response, error) {
+				return nil, nil, errors.New("boom")
+			}},
+			cr:       additive(withSelectors(qualityProfile(nil), bugs)),
+			selected: bugRules,
+			want: want{
+				sync: &v1alpha1.QualityProfileRulesSyncObservation{Activated: 2, Failed: 1, BulkRequests: 1},
+				err:  errors.New("encountered 2 error(s) during Quality Profile rules sync: [cannot activate 1 rule(s) of rule selector bugs:  cannot observe the active rules of rule selector bugs: boom]"),
+			},
+		},
+		"BulkDeactivateRepository": {
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				BulkDeactivateRulesFn: func(opt *sonar.QualityprofilesDeactivateRulesOption) (*instance.QualityProfilesBulkChange, *http.Response, error) {
+					return &instance.QualityProfilesBulkChange{Succeeded: 3}, mockHTTPResponse(), nil
+				},
+			},
+			cr: qualityProfile(nil, "java:S1", "java:S2", "java:S3"),
+			want: want{
+				sync: &v1alpha1.QualityProfileRulesSyncObservation{Deactivated: 3, BulkRequests: 1},
+			},
+		},
+		"RepositoryWithKeptRulesDeactivatedIndividually": {
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				DeactivateRuleFn: func(opt *sonar.QualityprofilesDeactivateRuleOption) (*http.Response, error) {
+					return mockHTTPResponse(), nil
+				},
+			},
+			cr: qualityProfile([]v1alpha1.QualityProfileRuleParameters{{Rule: "java:S4"}}, "java:S1", "java:S2", "java:S3", "java:S4"),
+			want: want{
+				sync: &v1alpha1.QualityProfileRulesSyncObservation{Deactivated: 3, IndividualRequests: 3},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			e := &external{qualityProfilesClient: tc.qualityProfilesClient, rulesClient: tc.rulesClient}
+			selection := instance.MergeQualityProfileRules(tc.cr.Spec.ForProvider, tc.selected)
+			associations := instance.GenerateQualityProfileRulesAssociation(selection.Rules, tc.cr.Status.AtProvider.Rules)
+
+			err := e.syncQualityProfileRules(tc.cr, selection, associations)
+			if diff := cmp.Diff(tc.want.err, err, cmp.Comparer(errComparer)); diff != "" {
+				t.Errorf("syncQualityProfileRules() error mismatch (-want +got):\n%s", diff)
+			}
+
+			if tc.cr.Status.AtProvider.RulesSync == nil || tc.cr.Status.AtProvider.RulesSync.Time == nil {
+				t.Fatal("syncQualityProfileRules() did not report the synchronization")
+			}
+
+			if diff := cmp.Diff(tc.want.sync, tc.cr.Status.AtProvider.RulesSync, cmpopts.IgnoreFields(v1alpha1.QualityProfileRulesSyncObservation{}, "Time")); diff != "" {
+				t.Errorf("syncQualityProfileRules() sync mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.activated, tc.cr.Status.AtProvider.ActivatedRules, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("syncQualityProfileRules() activated rules mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserveRuleSelectors(t *testing.T) {
+	t.Parallel()
+
+	qualityProfilesClient := &fake.MockQualityProfilesClient{
+		ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
+			return &sonar.QualityprofilesShow{
+				Profile: sonar.ShownProfile{Key: "AU-TpxcA-iU5OvuD2FLz", Name: "test-profile", Language: "java", IsDefault: false},
+			}, nil, nil
+		},
+	}
+
+	// rulesClient returns the active rules for searches on the Quality Profile, and the selected rules otherwise
+	rulesClient := func(active []string, selected []string) *fake.MockRulesClient {
+		return &fake.MockRulesClient{
+			SearchFn: func(opt *sonar.RulesSearchOption) (*sonar.RulesSearch, *http.Response, error) {
+				keys := selected
+				if opt.Qprofile != "" {
+					keys = active
+				}
+
+				rules := make([]sonar.RuleDetails, 0, len(keys))
+				for _, key := range keys {
+					rules = append(rules, sonar.RuleDetails{Key: key})
+				}
+
+				return &sonar.RulesSearch{Rules: rules, Paging: sonar.Paging{Total: int64(len(keys))}}, nil, nil
+			},
+		}
+	}
+
+	qualityProfile := func(selector v1alpha1.QualityProfileRuleSelector) *v1alpha1.QualityProfile {
+		qp := &v1alpha1.QualityProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-profile", Annotations: map[string]string{}},
+			Spec: v1alpha1.QualityProfileSpec{
+				ForProvider: v1alpha1.QualityProfileParameters{
+					Name:          "test-profile",
+					Language:      "java",
+					Default:       ptr.To(false),
+					Rules:         []v1alpha1.QualityProfileRuleParameters{{Rule: "java:S2"}},
+					RuleSelectors: []v1alpha1.QualityProfileRuleSelector{selector},
+				},
+			},
+		}
+		meta.SetExternalName(qp, "AU-TpxcA-iU5OvuD2FLz")
+
+		return qp
+	}
+
+	// The explicit rule java:S2 is late initialized from its observation in every case
+	type want struct {
+		o         managed.ExternalObservation
+		selectors map[string]string
+		err       error
+	}
+
+	cases := map[string]struct {
+		rulesClient *fake.MockRulesClient
+		cr          *v1alpha1.QualityProfile
+		want        want
+	}{
+		"SelectedRulesActive": {
+			rulesClient: rulesClient([]string{"java:S1", "java:S2", "java:S3"}, []string{"java:S1", "java:S2"}),
+			cr:          qualityProfile(v1alpha1.QualityProfileRuleSelector{Name: "bugs", Types: []string{"BUG"}}),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ResourceLateInitialized: true},
+				selectors: map[string]string{"java:S1": "bugs", "java:S2": "", "java:S3": ""},
+			},
+		},
+		"SelectedRulesUpToDate": {
+			rulesClient: rulesClient([]string{"java:S1", "java:S2"}, []string{"java:S1", "java:S2"}),
+			cr:          qualityProfile(v1alpha1.QualityProfileRuleSelector{Name: "bugs", Types: []string{"BUG"}}),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+				selectors: map[string]string{"java:S1": "bugs", "java:S2": ""},
+			},
+		},
+		"SelectedRuleNotActive": {
+			rulesClient: rulesClient([]string{"java:S2"}, []string{"java:S1", "java:S2"}),
+			cr:          qualityProfile(v1alpha1.QualityProfileRuleSelector{Name: "bugs", Types: []string{"BUG"}}),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ResourceLateInitialized: true},
+				selectors: map[string]string{"java:S2": ""},
+			},
+		},
+		"InvalidPattern": {
+			rulesClient: rulesClient(nil, nil),
+			cr:          qualityProfile(v1alpha1.QualityProfileRuleSelector{Name: "bugs", Include: []string{"java:S[1"}}),
+			want: want{
+				err: errors.Errorf(errInvalidRuleSelectors, `bugs: invalid pattern "java:S[1"`),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			e := &external{qualityProfilesClient: qualityProfilesClient, rulesClient: tc.rulesClient}
+			got, err := e.Observe(context.Background(), tc.cr)
+
+			if diff := cmp.Diff(tc.want.err, err, cmp.Comparer(errComparer)); diff != "" {
+				t.Errorf("Observe() error mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("Observe() mismatch (-want +got):\n%s", diff)
+			}
+
+			var selectors map[string]string
+			for _, rule := range tc.cr.Status.AtProvider.Rules {
+				if selectors == nil {
+					selectors = make(map[string]string)
+				}
+
+				selectors[rule.Key] = rule.Selector
+			}
+
+			if diff := cmp.Diff(tc.want.selectors, selectors); diff != "" {
+				t.Errorf("Observe() rule selectors mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRuleSelectorsResolvedOncePerGeneration(t *testing.T) {
+	t.Parallel()
+
+	selectorSearches := 0
+
+	e := &external{
+		qualityProfilesClient: &fake.MockQualityProfilesClient{
+			ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
+				return &sonar.QualityprofilesShow{
+					Profile: sonar.ShownProfile{Key: "AU-TpxcA-iU5OvuD2FLz", Name: "test-profile", Language: "java"},
+				}, nil, nil
+			},
+		},
+		rulesClient: &fake.MockRulesClient{
+			SearchFn: func(opt *sonar.RulesSearchOption) (*sonar.RulesSearch, *http.Response, error) {
+				if opt.Qprofile == "" {
+					selectorSearches++
+				}
+
+				return &sonar.RulesSearch{Rules: []sonar.RuleDetails{{Key: "java:S1"}}, Paging: sonar.Paging{Total: 1}}, nil, nil
+			},
+		},
+		ruleSelectors: instance.NewRuleSelectorsCache(time.Hour),
+	}
+
+	qp := &v1alpha1.QualityProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-profile", UID: "qp-uid", Generation: 1},
+		Spec: v1alpha1.QualityProfileSpec{
+			ForProvider: v1alpha1.QualityProfileParameters{
+				Name:          "test-profile",
+				Language:      "java",
+				Default:       ptr.To(false),
+				RuleSelectors: []v1alpha1.QualityProfileRuleSelector{{Name: "bugs", Types: []string{"BUG"}}},
+			},
+		},
+	}
+	meta.SetExternalName(qp, "AU-TpxcA-iU5OvuD2FLz")
+
+	for range 2 {
+		if _, err := e.Observe(context.Background(), qp); err != nil {
+			t.Fatalf("Observe() unexpected error = %v", err)
+		}
+	}
+
+	if selectorSearches != 1 {
+		t.Errorf("Observe() searched the rules of the selectors %d times for the same generation, want 1", selectorSearches)
+	}
+
+	qp.Generation = 2
+
+	if _, err := e.Observe(context.Background(), qp); err != nil {
+		t.Fatalf("Observe() unexpected error = %v", err)
+	}
+
+	if selectorSearches != 2 {
+		t.Errorf("Observe() searched the rules of the selectors %d times after the spec changed, want 2", selectorSearches)
+	}
+}
+
+func TestAdditiveRulesPolicy(t *testing.T) {
+	t.Parallel()
+
+	// java:S1 is active but managed elsewhere, java:S2 was activated by the resource and is no longer desired
+	newQualityProfile := func(activatedRules ...string) *v1alpha1.QualityProfile {
+		qp := &v1alpha1.QualityProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-profile", Annotations: map[string]string{}},
+			Spec: v1alpha1.QualityProfileSpec{
+				ForProvider: v1alpha1.QualityProfileParameters{
+					Name:        "test-profile",
+					Language:    "java",
+					Default:     ptr.To(false),
+					RulesPolicy: ptr.To(instance.QualityProfileRulesPolicyAdditive),
+					Rules:       []v1alpha1.QualityProfileRuleParameters{{Rule: "java:S3", Severity: ptr.To(""), Prioritized: ptr.To(false)}},
+				},
+			},
+			Status: v1alpha1.QualityProfileStatus{
+				AtProvider: v1alpha1.QualityProfileObservation{
+					Name:           "test-profile",
+					Language:       "java",
+					Rules:          []v1alpha1.QualityProfileRuleObservation{{Key: "java:S1"}, {Key: "java:S2"}},
+					ActivatedRules: activatedRules,
+				},
+			},
+		}
+		meta.SetExternalName(qp, "AU-TpxcA-iU5OvuD2FLz")
+
+		return qp
+	}
+
+	qualityProfilesClient := func(deactivated *[]string) *fake.MockQualityProfilesClient {
+		return &fake.MockQualityProfilesClient{
+			ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
+				return &sonar.QualityprofilesShow{Profile: sonar.ShownProfile{Key: "AU-TpxcA-iU5OvuD2FLz", Name: "test-profile", Language: "java"}}, nil, nil
+			},
+			ActivateRuleFn: func(opt *sonar.QualityprofilesActivateRuleOption) (*http.Response, error) {
+				return mockHTTPResponse(), nil
+			},
+			DeactivateRuleFn: func(opt *sonar.QualityprofilesDeactivateRuleOption) (*http.Response, error) {
+				*deactivated = append(*deactivated, opt.Rule)
+
+				return mockHTTPResponse(), nil
+			},
+		}
+	}
+
+	rulesClient := func(active ...string) *fake.MockRulesClient {
+		return &fake.MockRulesClient{
+			SearchFn: func(opt *sonar.RulesSearchOption) (*sonar.RulesSearch, *http.Response, error) {
+				rules := make([]sonar.RuleDetails, 0, len(active))
+				for _, key := range active {
+					rules = append(rules, sonar.RuleDetails{Key: key})
+				}
+
+				return &sonar.RulesSearch{Rules: rules, Paging: sonar.Paging{Total: int64(len(active))}}, nil, nil
+			},
+		}
+	}
+
+	t.Run("ObserveIgnoresUnmanagedRules", func(t *testing.T) {
+		t.Parallel()
+
+		profile := newQualityProfile("java:S3")
+		e := &external{qualityProfilesClient: qualityProfilesClient(&[]string{}), rulesClient: rulesClient("java:S1", "java:S3")}
+
+		got, err := e.Observe(context.Background(), profile)
+		if err != nil {
+			t.Fatalf("Observe() returned unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, got); diff != "" {
+			t.Errorf("Observe() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ObserveManagedRuleNotDesired", func(t *testing.T) {
+		t.Parallel()
+
+		profile := newQualityProfile("java:S2", "java:S3")
+		e := &external{qualityProfilesClient: qualityProfilesClient(&[]string{}), rulesClient: rulesClient("java:S1", "java:S2", "java:S3")}
+
+		got, err := e.Observe(context.Background(), profile)
+		if err != nil {
+			t.Fatalf("Observe() returned unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, got); diff != "" {
+			t.Errorf("Observe() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ObservePrunesLedger", func(t *testing.T) {
+		t.Parallel()
+
+		// java:S2 was deactivated outside of the resource
+		profile := newQualityProfile("java:S2", "java:S3")
+		e := &external{qualityProfilesClient: qualityProfilesClient(&[]string{}), rulesClient: rulesClient("java:S1", "java:S3")}
+
+		if _, err := e.Observe(context.Background(), profile); err != nil {
+			t.Fatalf("Observe() returned unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff([]string{"java:S3"}, profile.Status.AtProvider.ActivatedRules); diff != "" {
+			t.Errorf("Observe() activated rules mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("UpdateOnlyDeactivatesActivatedRules", func(t *testing.T) {
+		t.Parallel()
+
+		var deactivated []string
+
+		profile := newQualityProfile("java:S2")
+		e := &external{qualityProfilesClient: qualityProfilesClient(&deactivated), rulesClient: rulesClient()}
+
+		if _, err := e.Update(context.Background(), profile); err != nil {
+			t.Fatalf("Update() returned unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff([]string{"java:S2"}, deactivated); diff != "" {
+			t.Errorf("Update() deactivated rules mismatch (-want +got):\n%s", diff)
+		}
+
+		if diff := cmp.Diff([]string{"java:S3"}, profile.Status.AtProvider.ActivatedRules); diff != "" {
+			t.Errorf("Update() activated rules mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestDeactivatedRules(t *testing.T) {
+	t.Parallel()
+
+	errInheritedRule := errors.New("Cannot deactivate inherited rule")
+
+	newQualityProfile := func(inheritance string, conflicts ...v1alpha1.QualityProfileRuleConflict) *v1alpha1.QualityProfile {
+		qp := &v1alpha1.QualityProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-profile", Annotations: map[string]string{}},
+			Spec: v1alpha1.QualityProfileSpec{
+				ForProvider: v1alpha1.QualityProfileParameters{
+					Name:             "test-profile",
+					Language:         "java",
+					Default:          ptr.To(false),
+					DeactivatedRules: []string{"java:S1"},
+				},
+			},
+			Status: v1alpha1.QualityProfileStatus{
+				AtProvider: v1alpha1.QualityProfileObservation{
+					Name:          "test-profile",
+					Language:      "java",
+					Rules:         []v1alpha1.QualityProfileRuleObservation{{Key: "java:S1", Inheritance: inheritance}},
+					RuleConflicts: conflicts,
+				},
+			},
+		}
+		meta.SetExternalName(qp, "AU-TpxcA-iU5OvuD2FLz")
+
+		return qp
+	}
+
+	type want struct {
+		conflicts []v1alpha1.QualityProfileRuleConflict
+		condition corev1.ConditionStatus
+		resets    int
+		err       error
+	}
+
+	cases := map[string]struct {
+		deactivateErr error
+		inheritance   string
+		want          want
+	}{
+		"RuleDeactivated": {
+			inheritance: instance.RuleInheritanceNone,
+			want:        want{condition: corev1.ConditionFalse},
+		},
+		"InheritedRuleForcedByParent": {
+			deactivateErr: errInheritedRule,
+			inheritance:   instance.RuleInheritanceInherited,
+			want: want{
+				conflicts: []v1alpha1.QualityProfileRuleConflict{{Rule: "java:S1", Message: "Cannot deactivate inherited rule"}},
+				condition: corev1.ConditionTrue,
+			},
+		},
+		"OverridingRuleIsReset": {
+			deactivateErr: errInheritedRule,
+			inheritance:   instance.RuleInheritanceOverrides,
+			want: want{
+				conflicts: []v1alpha1.QualityProfileRuleConflict{{Rule: "java:S1", Message: "Cannot deactivate inherited rule"}},
+				condition: corev1.ConditionTrue,
+				resets:    1,
+			},
+		},
+		"NotInheritedRuleRejected": {
+			deactivateErr: errInheritedRule,
+			inheritance:   instance.RuleInheritanceNone,
+			want: want{
+				condition: corev1.ConditionFalse,
+				err: errors.Wrap(errors.Errorf("encountered 1 error(s) during Quality Profile rules sync: %v",
+					[]error{errors.Wrap(errInheritedRule, "cannot deactivate rule java:S1")}), "cannot sync Quality Profile Rules"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			resets := 0
+			profile := newQualityProfile(tc.inheritance)
+			e := &external{
+				qualityProfilesClient: &fake.MockQualityProfilesClient{
+					DeactivateRuleFn: func(opt *sonar.QualityprofilesDeactivateRuleOption) (*http.Response, error) {
+						if tc.deactivateErr != nil {
+							return &http.Response{StatusCode: http.StatusBadRequest, Body: http.NoBody}, tc.deactivateErr
+						}
+
+						return mockHTTPResponse(), nil
+					},
+					ActivateRuleFn: func(opt *sonar.QualityprofilesActivateRuleOption) (*http.Response, error) {
+						if opt.Reset {
+							resets++
+						}
+
+						return mockHTTPResponse(), nil
+					},
+				},
+				rulesClient: &fake.MockRulesClient{},
+			}
+
+			_, err := e.Update(context.Background(), profile)
+			if diff := cmp.Diff(tc.want.err, err, cmp.Comparer(errComparer)); diff != "" {
+				t.Errorf("Update() error mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.conflicts, profile.Status.AtProvider.RuleConflicts); diff != "" {
+				t.Errorf("Update() rule conflicts mismatch (-want +got):\n%s", diff)
+			}
+
+			if got := profile.GetCondition(v1alpha1.TypeRulesConflict).Status; got != tc.want.condition {
+				t.Errorf("Update() RulesConflict condition = %s, want %s", got, tc.want.condition)
+			}
+
+			if resets != tc.want.resets {
+				t.Errorf("Update() reset %d rules, want %d", resets, tc.want.resets)
+			}
+		})
+	}
+
+	t.Run("ObserveForcedRuleIsUpToDate", func(t *testing.T) {
+		t.Parallel()
+
+		profile := newQualityProfile(instance.RuleInheritanceInherited, v1alpha1.QualityProfileRuleConflict{Rule: "java:S1", Message: "Cannot deactivate inherited rule"})
+		e := &external{
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
+					return &sonar.QualityprofilesShow{Profile: sonar.ShownProfile{Key: "AU-TpxcA-iU5OvuD2FLz", Name: "test-profile", Language: "java"}}, nil, nil
+				},
+			},
+			rulesClient: &fake.MockRulesClient{
+				SearchFn: func(opt *sonar.RulesSearchOption) (*sonar.RulesSearch, *http.Response, error) {
+					return &sonar.RulesSearch{
+						Rules:   []sonar.RuleDetails{{Key: "java:S1"}},
+						Actives: map[string][]sonar.RuleActivation{"java:S1": {{QProfile: "AU-TpxcA-iU5OvuD2FLz", Inherit: instance.RuleInheritanceInherited}}},
+						Paging:  sonar.Paging{Total: 1},
+					}, nil, nil
+				},
+			},
+		}
+
+		got, err := e.Observe(context.Background(), profile)
+		if err != nil {
+			t.Fatalf("Observe() returned unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, got); diff != "" {
+			t.Errorf("Observe() mismatch (-want +got):\n%s", diff)
+		}
+
+		if got := profile.GetCondition(v1alpha1.TypeRulesConflict).Status; got != corev1.ConditionTrue {
+			t.Errorf("Observe() RulesConflict condition = %s, want True", got)
+		}
+	})
+}
+
+func TestObserveAdoptExisting(t *testing.T) {
+	t.Parallel()
+
+	errBoom := errors.New("boom")
+
+	newQualityProfile := func(adopt *bool) *v1alpha1.QualityProfile {
+		return &v1alpha1.QualityProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-profile", Annotations: map[string]string{}},
+			Spec: v1alpha1.QualityProfileSpec{
+				ForProvider: v1alpha1.QualityProfileParameters{
+					Name:          "test-profile",
+					Language:      "java",
+					Default:       ptr.To(false),
+					AdoptExisting: adopt,
+				},
+			},
+		}
+	}
+
+	type want struct {
+		o            managed.ExternalObservation
+		externalName string
+		err          error
+	}
+
+	cases := map[string]struct {
+		adopt    *bool
+		profiles []sonar.QualityProfile
+		err      error
+		want     want
+	}{
+		"AdoptionDisabled": {
+			adopt:    nil,
+			profiles: []sonar.QualityProfile{{Key: "AU-TpxcA-iU5OvuD2FLz", Name: "test-profile", Language: "java"}},
+			want:     want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"NoExistingProfile": {
+			adopt:    ptr.To(true),
+			profiles: []sonar.QualityProfile{{Key: "AU-TpxcA-iU5OvuD2FLz", Name: "test-profile", Language: "go"}},
+			want:     want{o: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"ExistingProfileAdopted": {
+			adopt:    ptr.To(true),
+			profiles: []sonar.QualityProfile{{Key: "AU-TpxcA-iU5OvuD2FLz", Name: "test-profile", Language: "java"}},
+			want: want{
+				o:            managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+				externalName: "AU-TpxcA-iU5OvuD2FLz",
+			},
+		},
+		"BuiltInProfileNotAdopted": {
+			adopt:    ptr.To(true),
+			profiles: []sonar.QualityProfile{{Key: "AU-TpxcA-iU5OvuD2FLz", Name: "test-profile", Language: "java", IsBuiltIn: true}},
+			want:     want{err: errors.Errorf(errAdoptBuiltIn, "test-profile")},
+		},
+		"SearchFailed": {
+			adopt: ptr.To(true),
+			err:   errBoom,
+			want:  want{err: errors.Wrap(errBoom, errSearchQualityProfile)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			profile := newQualityProfile(tc.adopt)
+			e := &external{
+				qualityProfilesClient: &fake.MockQualityProfilesClient{
+					SearchFn: func(opt *sonar.QualityprofilesSearchOption) (*sonar.QualityprofilesSearch, *http.Response, error) {
+						if opt.QualityProfile != "test-profile" || opt.Language != "java" {
+							t.Errorf("Search() called with unexpected option %+v", opt)
+						}
+
+						return &sonar.QualityprofilesSearch{Profiles: tc.profiles}, nil, tc.err
+					},
+					ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
+						return &sonar.QualityprofilesShow{Profile: sonar.ShownProfile{Key: opt.Key, Name: "test-profile", Language: "java"}}, nil, nil
+					},
+				},
+				rulesClient: &fake.MockRulesClient{
+					SearchFn: func(opt *sonar.RulesSearchOption) (*sonar.RulesSearch, *http.Response, error) {
+						return &sonar.RulesSearch{}, nil, nil
+					},
+				},
+			}
+
+			got, err := e.Observe(context.Background(), profile)
+			if diff := cmp.Diff(tc.want.err, err, cmp.Comparer(errComparer)); diff != "" {
+				t.Errorf("Observe() error mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("Observe() mismatch (-want +got):\n%s", diff)
+			}
+
+			if got := meta.GetExternalName(profile); got != tc.want.externalName {
+				t.Errorf("Observe() external name = %q, want %q", got, tc.want.externalName)
+			}
+		})
+	}
+}
+
+func TestDeleteBehavior(t *testing.T) {
+	t.Parallel()
+
+	notFound := &http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody}
+
+	newQualityProfile := func(behavior *string) *v1alpha1.QualityProfile {
+		qp := &v1alpha1.QualityProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-profile"},
+			Spec: v1alpha1.QualityProfileSpec{
+				ForProvider: v1alpha1.QualityProfileParameters{
+					// The rename to test-profile is still pending
+					Name:             "test-profile",
+					Language:         "java",
+					DeletionBehavior: behavior,
+				},
+			},
+		}
+		meta.SetExternalName(qp, "AU-TpxcA-iU5OvuD2FLz")
+
+		return qp
+	}
+
+	type want struct {
+		err       error
+		condition *xpv1.Condition
+		deleted   []string
+		reparents []string
+		removed   []string
+	}
+
+	cases := map[string]struct {
+		behavior  *string
+		showResp  *http.Response
+		showErr   error
+		isDefault bool
+		projects  []string
+		children  []sonar.InheritanceProfile
+		want      want
+	}{
+		"AlreadyDeleted": {
+			showResp: notFound,
+			showErr:  errors.New("not found"),
+			want:     want{},
+		},
+		"DefaultProfileBlocked": {
+			isDefault: true,
+			want: want{
+				err:       errors.Errorf(errDeleteDefault, "old-profile", "java"),
+				condition: ptr.To(v1alpha1.DeletionBlocked(v1alpha1.ReasonDefaultProfile, fmt.Sprintf(errDeleteDefault, "old-profile", "java"))),
+			},
+		},
+		"DeletedByObservedName": {
+			want: want{deleted: []string{"old-profile"}},
+		},
+		"ProjectsReassigned": {
+			projects: []string{"project-a", "project-b", "project-c"},
+			want: want{
+				deleted: []string{"old-profile"},
+				removed: []string{"project-a", "project-b", "project-c"},
+			},
+		},
+		"ReassignBlockedByChildren": {
+			children: []sonar.InheritanceProfile{{Key: "AU-2", Name: "child"}},
+			want: want{
+				err: errors.Errorf(errDeletionBlocked, "old-profile", "inherited by 1 child Quality Profile(s): child"),
+				condition: ptr.To(v1alpha1.DeletionBlocked(v1alpha1.ReasonProfileInUse,
+					fmt.Sprintf(errDeletionBlocked, "old-profile", "inherited by 1 child Quality Profile(s): child"))),
+			},
+		},
+		"ChildrenReparented": {
+			behavior: ptr.To(instance.QualityProfileDeletionReparent),
+			projects: []string{"project-a"},
+			children: []sonar.InheritanceProfile{{Key: "AU-2", Name: "child"}, {Key: "AU-3", Name: "other-child"}},
+			want: want{
+				deleted:   []string{"old-profile"},
+				reparents: []string{"child->parent", "other-child->parent"},
+				removed:   []string{"project-a"},
+			},
+		},
+		"BlockedByProjects": {
+			behavior: ptr.To(instance.QualityProfileDeletionBlock),
+			projects: []string{"project-a"},
+			want: want{
+				err: errors.Errorf(errDeletionBlocked, "old-profile", "used by 1 project(s): project-a"),
+				condition: ptr.To(v1alpha1.DeletionBlocked(v1alpha1.ReasonProfileInUse,
+					fmt.Sprintf(errDeletionBlocked, "old-profile", "used by 1 project(s): project-a"))),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var deleted, reparents, removed []string
+
+			profile := newQualityProfile(tc.behavior)
+			e := &external{
+				qualityProfilesClient: &fake.MockQualityProfilesClient{
+					ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
+						if tc.showErr != nil {
+							return nil, tc.showResp, tc.showErr
+						}
+
+						return &sonar.QualityprofilesShow{Profile: sonar.ShownProfile{Key: opt.Key, Name: "old-profile", Language: "java", IsDefault: tc.isDefault}}, nil, nil
+					},
+					ProjectsFn: func(opt *sonar.QualityprofilesProjectsOption) (*sonar.QualityprofilesProjects, *http.Response, error) {
+						// Serve the projects two by two to exercise the pagination
+						start := min(int(opt.Page-1)*2, len(tc.projects))
+						end := min(start+2, len(tc.projects))
+
+						results := make([]sonar.ProfileProject, 0, end-start)
+						for _, project := range tc.projects[start:end] {
+							results = append(results, sonar.ProfileProject{Key: project, Selected: true})
+						}
+
+						return &sonar.QualityprofilesProjects{Results: results, Paging: sonar.Paging{Total: int64(len(tc.projects))}}, nil, nil
+					},
+					InheritanceFn: func(opt *sonar.QualityprofilesInheritanceOption) (*sonar.QualityprofilesInheritance, *http.Response, error) {
+						return &sonar.QualityprofilesInheritance{
+							Ancestors: []sonar.InheritanceProfile{{Key: "AU-1", Name: "parent"}},
+							Children:  tc.children,
+						}, nil, nil
+					},
+					ChangeParentFn: func(opt *sonar.QualityprofilesChangeParentOption) (*http.Response, error) {
+						reparents = append(reparents, opt.QualityProfile+"->"+opt.ParentQualityProfile)
+
+						return mockHTTPResponse(), nil
+					},
+					RemoveProjectFn: func(opt *sonar.QualityprofilesRemoveProjectOption) (*http.Response, error) {
+						removed = append(removed, opt.Project)
+
+						return mockHTTPResponse(), nil
+					},
+					DeleteFn: func(opt *sonar.QualityprofilesDeleteOption) (*http.Response, error) {
+						deleted = append(deleted, opt.QualityProfile)
+
+						return mockHTTPResponse(), nil
+					},
+				},
+			}
+
+			_, err := e.Delete(context.Background(), profile)
+			if diff := cmp.Diff(tc.want.err, err, cmp.Comparer(errComparer)); diff != "" {
+				t.Errorf("Delete() error mismatch (-want +got):\n%s", diff)
+			}
+
+			if tc.want.condition != nil {
+				got := profile.GetCondition(v1alpha1.TypeDeletionBlocked)
+				if !got.Equal(*tc.want.condition) {
+					t.Errorf("Delete() DeletionBlocked condition = %+v, want %+v", got, *tc.want.condition)
+				}
+			}
+
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("Delete() deleted profiles mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.reparents, reparents); diff != "" {
+				t.Errorf("Delete() reparented children mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.removed, removed); diff != "" {
+				t.Errorf("Delete() reassigned projects mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func newFakeKube(objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	return fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func backupConfigMap(backup string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "profiles", Namespace: "default"},
+		Data:       map[string]string{"java.xml": backup},
+	}
+}
+
+func TestBackupRestore(t *testing.T) {
+	t.Parallel()
+
+	backup := `<?xml version="1.0" encoding="UTF-8"?><profile><name>test-profile</name><language>java</language><rules/></profile>`
+	restored := &instance.QualityProfilesRestore{
+		Profile:       sonar.QualityProfile{Key: "AU-TpxcA-iU5OvuD2FLz", Name: "test-profile", Language: "java"},
+		RuleSuccesses: 3,
+	}
+
+	newQualityProfile := func(restoredHash string) *v1alpha1.QualityProfile {
+		qp := &v1alpha1.QualityProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-profile", Namespace: "default", Annotations: map[string]string{}},
+			Spec: v1alpha1.QualityProfileSpec{
+				ForProvider: v1alpha1.QualityProfileParameters{
+					Name:        "test-profile",
+					Language:    "java",
+					Default:     ptr.To(false),
+					RulesPolicy: ptr.To(instance.QualityProfileRulesPolicyAdditive),
+					Source: &v1alpha1.QualityProfileBackupSource{
+						ConfigMapKeyRef: &v1alpha1.LocalConfigMapKeySelector{Name: "profiles", Key: "java.xml"},
+					},
+				},
+			},
+			Status: v1alpha1.QualityProfileStatus{
+				AtProvider: v1alpha1.QualityProfileObservation{Name: "test-profile", Language: "java"},
+			},
+		}
+
+		if restoredHash != "" {
+			qp.Status.AtProvider.Restore = &v1alpha1.QualityProfileRestoreObservation{BackupHash: restoredHash}
+		}
+
+		return qp
+	}
+
+	t.Run("CreateFromBackup", func(t *testing.T) {
+		t.Parallel()
+
+		profile := newQualityProfile("")
+		e := &external{
+			kube: newFakeKube(backupConfigMap(backup)),
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				SearchFn: func(opt *sonar.QualityprofilesSearchOption) (*sonar.QualityprofilesSearch, *http.Response, error) {
+					return &sonar.QualityprofilesSearch{}, nil, nil
+				},
+				RestoreBackupFn: func(got string) (*instance.QualityProfilesRestore, *http.Response, error) {
+					if got != backup {
+						t.Errorf("RestoreBackup() backup = %q, want %q", got, backup)
+					}
+
+					return restored, nil, nil
+				},
+			},
+		}
+
+		_, err := e.Create(context.Background(), profile)
+		if err != nil {
+			t.Fatalf("Create() returned unexpected error: %v", err)
+		}
+
+		if got := meta.GetExternalName(profile); got != "AU-TpxcA-iU5OvuD2FLz" {
+			t.Errorf("Create() external name = %q, want %q", got, "AU-TpxcA-iU5OvuD2FLz")
+		}
+
+		got := annotatedRestore(profile)
+		if got == nil || got.BackupHash != instance.HashQualityProfileBackup(backup) || got.RuleSuccesses != 3 || got.Time == nil {
+			t.Errorf("Create() restore annotation = %+v, want the hash of the backup and 3 rule successes", got)
+		}
+	})
+
+	t.Run("CreateFromBackupRestoresOnce", func(t *testing.T) {
+		t.Parallel()
+
+		restores := 0
+		profile := newQualityProfile("")
+		e := &external{
+			kube: newFakeKube(backupConfigMap(backup)),
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				SearchFn: func(opt *sonar.QualityprofilesSearchOption) (*sonar.QualityprofilesSearch, *http.Response, error) {
+					return &sonar.QualityprofilesSearch{}, nil, nil
+				},
+				ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
+					return &sonar.QualityprofilesShow{Profile: sonar.ShownProfile{Key: opt.Key, Name: "test-profile", Language: "java"}}, nil, nil
+				},
+				RestoreBackupFn: func(string) (*instance.QualityProfilesRestore, *http.Response, error) {
+					restores++
+
+					return restored, nil, nil
+				},
+			},
+			rulesClient: &fake.MockRulesClient{
+				SearchFn: func(opt *sonar.RulesSearchOption) (*sonar.RulesSearch, *http.Response, error) {
+					return &sonar.RulesSearch{}, nil, nil
+				},
+			},
+		}
+
+		_, err := e.Create(context.Background(), profile)
+		if err != nil {
+			t.Fatalf("Create() returned unexpected error: %v", err)
+		}
+
+		// The managed reconciler reloads the resource after Create, discarding its status but keeping its annotations
+		profile.Status.AtProvider = v1alpha1.QualityProfileObservation{}
+
+		_, err = e.Observe(context.Background(), profile)
+		if err != nil {
+			t.Fatalf("Observe() returned unexpected error: %v", err)
+		}
+
+		if !instance.IsQualityProfileBackupRestored(&profile.Status.AtProvider, instance.HashQualityProfileBackup(backup)) {
+			t.Errorf("Observe() restore observation = %+v, want the restoration made by Create", profile.Status.AtProvider.Restore)
+		}
+
+		_, err = e.Update(context.Background(), profile)
+		if err != nil {
+			t.Fatalf("Update() returned unexpected error: %v", err)
+		}
+
+		if restores != 1 {
+			t.Errorf("Create() then reconciling restored the backup %d times, want 1", restores)
+		}
+	})
+
+	t.Run("CreateDoesNotOverwriteExistingProfile", func(t *testing.T) {
+		t.Parallel()
+
+		e := &external{
+			kube: newFakeKube(backupConfigMap(backup)),
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				SearchFn: func(opt *sonar.QualityprofilesSearchOption) (*sonar.QualityprofilesSearch, *http.Response, error) {
+					return &sonar.QualityprofilesSearch{Profiles: []sonar.QualityProfile{{Key: "AU-1", Name: "test-profile", Language: "java"}}}, nil, nil
+				},
+			},
+		}
+
+		_, err := e.Create(context.Background(), newQualityProfile(""))
+		if diff := cmp.Diff(errors.Errorf(errRestoreExisting, "test-profile"), err, cmp.Comparer(errComparer)); diff != "" {
+			t.Errorf("Create() error mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("CreateRejectsBackupOfAnotherLanguage", func(t *testing.T) {
+		t.Parallel()
+
+		profile := newQualityProfile("")
+		profile.Spec.ForProvider.Language = "go"
+		e := &external{
+			kube:                  newFakeKube(backupConfigMap(backup)),
+			qualityProfilesClient: &fake.MockQualityProfilesClient{},
+		}
+
+		_, err := e.Create(context.Background(), profile)
+		if diff := cmp.Diff(errors.Errorf(errInvalidBackup, "the backup is for language java, not go"), err, cmp.Comparer(errComparer)); diff != "" {
+			t.Errorf("Create() error mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ObserveChangedBackupIsNotUpToDate", func(t *testing.T) {
+		t.Parallel()
+
+		profile := newQualityProfile(instance.HashQualityProfileBackup("<profile/>"))
+		meta.SetExternalName(profile, "AU-TpxcA-iU5OvuD2FLz")
+		e := &external{
+			kube: newFakeKube(backupConfigMap(backup)),
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
+					return &sonar.QualityprofilesShow{Profile: sonar.ShownProfile{Key: opt.Key, Name: "test-profile", Language: "java"}}, nil, nil
+				},
+			},
+			rulesClient: &fake.MockRulesClient{
+				SearchFn: func(opt *sonar.RulesSearchOption) (*sonar.RulesSearch, *http.Response, error) {
+					return &sonar.RulesSearch{}, nil, nil
+				},
+			},
+		}
+
+		got, err := e.Observe(context.Background(), profile)
+		if err != nil {
+			t.Fatalf("Observe() returned unexpected error: %v", err)
+		}
+
+		if got.ResourceUpToDate {
+			t.Errorf("Observe() ResourceUpToDate = true for a changed backup, want false")
+		}
+	})
+
+	t.Run("UpdateRestoresChangedBackup", func(t *testing.T) {
+		t.Parallel()
+
+		profile := newQualityProfile(instance.HashQualityProfileBackup("<profile/>"))
+		meta.SetExternalName(profile, "AU-TpxcA-iU5OvuD2FLz")
+		e := &external{
+			kube: newFakeKube(backupConfigMap(backup)),
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				RestoreBackupFn: func(string) (*instance.QualityProfilesRestore, *http.Response, error) {
+					return restored, nil, nil
+				},
+			},
+			rulesClient: &fake.MockRulesClient{},
+		}
+
+		_, err := e.Update(context.Background(), profile)
+		if err != nil {
+			t.Fatalf("Update() returned unexpected error: %v", err)
+		}
+
+		if !instance.IsQualityProfileBackupRestored(&profile.Status.AtProvider, instance.HashQualityProfileBackup(backup)) {
+			t.Errorf("Update() restore observation = %+v, want the hash of the changed backup", profile.Status.AtProvider.Restore)
+		}
+	})
+
+	t.Run("UpdateRejectsBackupOfAnotherProfile", func(t *testing.T) {
+		t.Parallel()
+
+		profile := newQualityProfile(instance.HashQualityProfileBackup("<profile/>"))
+		profile.Status.AtProvider.Name = "renamed-profile"
+		meta.SetExternalName(profile, "AU-TpxcA-iU5OvuD2FLz")
+		e := &external{
+			kube:                  newFakeKube(backupConfigMap(backup)),
+			qualityProfilesClient: &fake.MockQualityProfilesClient{},
+		}
+
+		_, err := e.Update(context.Background(), profile)
+		want := errors.Errorf(errInvalidBackup, "the backup is for Quality Profile test-profile, not renamed-profile, restoring it would create another Quality Profile")
+
+		if diff := cmp.Diff(want, err, cmp.Comparer(errComparer)); diff != "" {
+			t.Errorf("Update() error mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestBackupExport(t *testing.T) {
+	t.Parallel()
+
+	backup := `<?xml version="1.0" encoding="UTF-8"?><profile><name>test-profile</name><language>java</language><rules/></profile>`
+	export := &v1alpha1.QualityProfileBackupExport{ConfigMapName: "profiles", Key: ptr.To("java.xml")}
+	revision := instance.GenerateQualityProfileBackupExportRevision(export, &v1alpha1.QualityProfileObservation{Key: "AU-TpxcA-iU5OvuD2FLz", Name: "test-profile"})
+
+	newQualityProfile := func(revision string) *v1alpha1.QualityProfile {
+		qp := &v1alpha1.QualityProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-profile", Namespace: "default", UID: "qp-uid", Annotations: map[string]string{}},
+			Spec: v1alpha1.QualityProfileSpec{
+				ForProvider: v1alpha1.QualityProfileParameters{
+					Name:         "test-profile",
+					Language:     "java",
+					Default:      ptr.To(false),
+					BackupExport: export,
+				},
+			},
+			Status: v1alpha1.QualityProfileStatus{
+				AtProvider: v1alpha1.QualityProfileObservation{BackupExportRevision: revision},
+			},
+		}
+		meta.SetExternalName(qp, "AU-TpxcA-iU5OvuD2FLz")
+
+		return qp
+	}
+
+	controller := meta.AsController(meta.TypedReferenceTo(newQualityProfile(""), v1alpha1.QualityProfileGroupVersionKind))
+	newConfigMap := func(data map[string]string, owners ...metav1.OwnerReference) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "profiles", Namespace: "default", OwnerReferences: owners},
+			Data:       data,
+		}
+	}
+
+	newExternal := func(kube client.Client, name string, fetched *bool) *external {
+		return &external{
+			kube: kube,
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
+					return &sonar.QualityprofilesShow{Profile: sonar.ShownProfile{Key: opt.Key, Name: name, Language: "java"}}, nil, nil
+				},
+				BackupFn: func(opt *sonar.QualityprofilesBackupOption) (*string, *http.Response, error) {
+					*fetched = true
+
+					return ptr.To(backup), nil, nil
+				},
+			},
+			rulesClient: &fake.MockRulesClient{
+				SearchFn: func(opt *sonar.RulesSearchOption) (*sonar.RulesSearch, *http.Response, error) {
+					return &sonar.RulesSearch{}, nil, nil
+				},
+			},
+		}
+	}
+
+	type want struct {
+		err      error
+		fetched  bool
+		exported bool
+		revision string
+		data     map[string]string
+		owners   []metav1.OwnerReference
+	}
+
+	cases := map[string]struct {
+		objects      []client.Object
+		revision     string
+		observedName string
+		want         want
+	}{
+		"ConfigMapCreated": {
+			observedName: "test-profile",
+			want: want{
+				fetched:  true,
+				exported: true,
+				revision: revision,
+				data:     map[string]string{"java.xml": backup},
+				owners:   []metav1.OwnerReference{controller},
+			},
+		},
+		"ConfigMapUpdated": {
+			objects:      []client.Object{newConfigMap(map[string]string{"java.xml": "<profile/>", "go.xml": "<profile/>"}, controller)},
+			revision:     "profiles/java.xml/AU-TpxcA-iU5OvuD2FLz/old-profile/",
+			observedName: "test-profile",
+			want: want{
+				fetched:  true,
+				exported: true,
+				revision: revision,
+				data:     map[string]string{"java.xml": backup, "go.xml": "<profile/>"},
+				owners:   []metav1.OwnerReference{controller},
+			},
+		},
+		"UnchangedBackupNotWritten": {
+			objects:      []client.Object{newConfigMap(map[string]string{"java.xml": backup}, controller)},
+			observedName: "test-profile",
+			want: want{
+				fetched:  true,
+				revision: revision,
+				data:     map[string]string{"java.xml": backup},
+				owners:   []metav1.OwnerReference{controller},
+			},
+		},
+		"UnchangedProfileNotFetched": {
+			objects:      []client.Object{newConfigMap(map[string]string{"java.xml": "<profile/>"}, controller)},
+			revision:     revision,
+			observedName: "test-profile",
+			want: want{
+				revision: revision,
+				data:     map[string]string{"java.xml": "<profile/>"},
+				owners:   []metav1.OwnerReference{controller},
+			},
+		},
+		"MissingBackupExportedAgain": {
+			objects:      []client.Object{newConfigMap(nil, controller)},
+			revision:     revision,
+			observedName: "test-profile",
+			want: want{
+				fetched:  true,
+				exported: true,
+				revision: revision,
+				data:     map[string]string{"java.xml": backup},
+				owners:   []metav1.OwnerReference{controller},
+			},
+		},
+		"ConfigMapNotControlledRefused": {
+			objects:      []client.Object{newConfigMap(map[string]string{"java.xml": "<profile/>"})},
+			observedName: "test-profile",
+			want: want{
+				err:  errors.Wrap(errors.Errorf(errBackupNotControlled, "profiles"), errExportBackup),
+				data: map[string]string{"java.xml": "<profile/>"},
+			},
+		},
+		"NotExportedWhileNotUpToDate": {
+			observedName: "old-profile",
+			want:         want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			kube := newFakeKube(tc.objects...)
+			profile := newQualityProfile(tc.revision)
+			fetched := false
+
+			_, err := newExternal(kube, tc.observedName, &fetched).Observe(context.Background(), profile)
+			if diff := cmp.Diff(tc.want.err, err, cmp.Comparer(errComparer)); diff != "" {
+				t.Fatalf("Observe() error mismatch (-want +got):\n%s", diff)
+			}
+
+			if fetched != tc.want.fetched {
+				t.Errorf("Observe() fetched the backup = %t, want %t", fetched, tc.want.fetched)
+			}
+
+			if exported := profile.Status.AtProvider.BackupExportTime != nil; exported != tc.want.exported {
+				t.Errorf("Observe() exported = %t, want %t", exported, tc.want.exported)
+			}
+
+			if tc.want.err == nil && profile.Status.AtProvider.BackupExportRevision != tc.want.revision {
+				t.Errorf("Observe() backup export revision = %q, want %q", profile.Status.AtProvider.BackupExportRevision, tc.want.revision)
+			}
+
+			configMap := &corev1.ConfigMap{}
+
+			err = kube.Get(context.Background(), types.NamespacedName{Name: "profiles", Namespace: "default"}, configMap)
+			if client.IgnoreNotFound(err) != nil {
+				t.Fatalf("Get() returned unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want.data, configMap.Data); diff != "" {
+				t.Errorf("Observe() exported ConfigMap data mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.owners, configMap.OwnerReferences); diff != "" {
+				t.Errorf("Observe() exported ConfigMap owners mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// eventRecorder is an event.Recorder keeping the recorded events.
+type eventRecorder struct {
+	events []event.Event
+}
+
+func (r *eventRecorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *eventRecorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+func TestExternalChanges(t *testing.T) {
+	t.Parallel()
+
+	since := metav1.NewTime(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC))
+	// The changelog is looked up one event per page to check that all of its pages are reported
+	changelog := []instance.QualityProfilesChangelogEvent{
+		{Action: "DEACTIVATED", AuthorLogin: "jdoe", Date: "2026-03-01T10:20:00+0000", RuleKey: "java:S2"},
+		{Action: "ACTIVATED", AuthorLogin: "asmith", Date: "2026-03-01T10:10:00+0000", RuleKey: "java:S1", Params: map[string]json.RawMessage{"severity": json.RawMessage(`"MAJOR"`)}},
+	}
+
+	newQualityProfile := func(since *metav1.Time, isDefault bool) *v1alpha1.QualityProfile {
+		qp := &v1alpha1.QualityProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-profile", Annotations: map[string]string{}},
+			Spec: v1alpha1.QualityProfileSpec{
+				ForProvider: v1alpha1.QualityProfileParameters{
+					Name:     "test-profile",
+					Language: "java",
+					Default:  ptr.To(isDefault),
+				},
+			},
+			Status: v1alpha1.QualityProfileStatus{
+				AtProvider: v1alpha1.QualityProfileObservation{ChangelogSince: since},
+			},
+		}
+		meta.SetExternalName(qp, "AU-TpxcA-iU5OvuD2FLz")
+
+		return qp
+	}
+
+	type want struct {
+		events  []event.Event
+		changes []v1alpha1.QualityProfileExternalChange
+		since   *metav1.Time
+	}
+
+	cases := map[string]struct {
+		since        *metav1.Time
+		drifted      bool
+		changelogErr error
+		want         want
+	}{
+		"ChangesOfDriftedProfileReported": {
+			since:   &since,
+			drifted: true,
+			want: want{
+				events: []event.Event{
+					event.Normal(reasonExternalChange, "asmith ACTIVATED rule java:S1 outside of the resource with severity=MAJOR"),
+					event.Normal(reasonExternalChange, "jdoe DEACTIVATED rule java:S2 outside of the resource"),
+				},
+				changes: []v1alpha1.QualityProfileExternalChange{
+					{Date: ptr.To(metav1.NewTime(time.Date(2026, 3, 1, 10, 20, 0, 0, time.UTC))), Author: "jdoe", Action: "DEACTIVATED", Rule: "java:S2"},
+					{Date: ptr.To(metav1.NewTime(time.Date(2026, 3, 1, 10, 10, 0, 0, time.UTC))), Author: "asmith", Action: "ACTIVATED", Rule: "java:S1", Params: []string{"severity=MAJOR"}},
+				},
+				since: ptr.To(metav1.NewTime(time.Date(2026, 3, 1, 10, 20, 1, 0, time.UTC))),
+			},
+		},
+		"UpToDateProfileNotLookedUp": {
+			since: &since,
+			want:  want{since: &since},
+		},
+		"FailedLookupReported": {
+			since:        &since,
+			drifted:      true,
+			changelogErr: errors.New("boom"),
+			want: want{
+				events: []event.Event{event.Warning(reasonChangelog, errors.Wrap(errors.New("boom"), errChangelog))},
+				since:  &since,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := &eventRecorder{}
+			profile := newQualityProfile(tc.since, tc.drifted)
+			e := &external{
+				recorder: recorder,
+				qualityProfilesClient: &fake.MockQualityProfilesClient{
+					ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
+						return &sonar.QualityprofilesShow{Profile: sonar.ShownProfile{Key: opt.Key, Name: "test-profile", Language: "java"}}, nil, nil
+					},
+					ChangelogEventsFn: func(opt *sonar.QualityprofilesChangelogOption) (*instance.QualityProfilesChangelog, *http.Response, error) {
+						if opt.Since != "2026-03-01T10:00:00+0000" {
+							t.Errorf("ChangelogEvents() since = %q, want %q", opt.Since, "2026-03-01T10:00:00+0000")
+						}
+
+						if tc.changelogErr != nil {
+							return nil, nil, tc.changelogErr
+						}
+
+						return &instance.QualityProfilesChangelog{
+							Paging: sonar.Paging{PageIndex: opt.Page, PageSize: 1, Total: int64(len(changelog))},
+							Events: changelog[opt.Page-1 : opt.Page],
+						}, nil, nil
+					},
+				},
+				rulesClient: &fake.MockRulesClient{
+					SearchFn: func(opt *sonar.RulesSearchOption) (*sonar.RulesSearch, *http.Response, error) {
+						return &sonar.RulesSearch{}, nil, nil
+					},
+				},
+			}
+
+			got, err := e.Observe(context.Background(), profile)
+			if err != nil {
+				t.Fatalf("Observe() returned unexpected error: %v", err)
+			}
+
+			if got.ResourceUpToDate == tc.drifted {
+				t.Errorf("Observe() ResourceUpToDate = %t, want %t", got.ResourceUpToDate, !tc.drifted)
+			}
+
+			if diff := cmp.Diff(tc.want.events, recorder.events); diff != "" {
+				t.Errorf("Observe() events mismatch (-want +got):\n%s", diff)
+			}
+
+			timeComparer := cmp.Comparer(func(a, b metav1.Time) bool { return a.Equal(&b) })
+			if diff := cmp.Diff(tc.want.changes, profile.Status.AtProvider.ExternalChanges, timeComparer); diff != "" {
+				t.Errorf("Observe() external changes mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.since, profile.Status.AtProvider.ChangelogSince, timeComparer); diff != "" {
+				t.Errorf("Observe() changelog since mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("ChangelogSinceInitialized", func(t *testing.T) {
+		t.Parallel()
+
+		profile := newQualityProfile(nil, true)
+		e := &external{
+			recorder: &eventRecorder{},
+			qualityProfilesClient: &fake.MockQualityProfilesClient{
+				ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
+					return &sonar.QualityprofilesShow{Profile: sonar.ShownProfile{Key: opt.Key, Name: "test-profile", Language: "java"}}, nil, nil
+				},
+			},
+			rulesClient: &fake.MockRulesClient{
+				SearchFn: func(opt *sonar.RulesSearchOption) (*sonar.RulesSearch, *http.Response, error) {
+					return &sonar.RulesSearch{}, nil, nil
+				},
+			},
+		}
+
+		before := time.Now()
+
+		_, err := e.Observe(context.Background(), profile)
+		if err != nil {
+			t.Fatalf("Observe() returned unexpected error: %v", err)
+		}
+
+		// The changelog is looked up from the given second included, the changes of the current second are not external
+		since := profile.Status.AtProvider.ChangelogSince
+		if since == nil || !since.After(before) || since.Nanosecond() != 0 {
+			t.Errorf("Observe() changelog since = %v, want the second after %v", since, before)
+		}
+	})
+}
+
+func TestCompareTo(t *testing.T) {
+	t.Parallel()
+
+	previous := &v1alpha1.QualityProfileComparisonObservation{Key: "AU-SonarWay", Name: "Sonar way", OnlyInReferenceCount: 1, OnlyInReference: []string{"java:S1"}}
+	comparison := &sonar.QualityprofilesCompare{
+		Left:     sonar.CompareProfile{Key: "AU-TpxcA-iU5OvuD2FLz", Name: "test-profile"},
+		Right:    sonar.CompareProfile{Key: "AU-SonarWay", Name: "Sonar way"},
+		InLeft:   []sonar.CompareRule{{Key: "java:S3"}},
+		InRight:  []sonar.CompareRule{{Key: "java:S2"}, {Key: "java:S1"}},
+		Modified: []sonar.CompareModifiedRule{{Key: "java:S4"}},
+	}
+
+	type want struct {
+		events     []event.Event
+		searched   bool
+		comparison *v1alpha1.QualityProfileComparisonObservation
+	}
+
+	cases := map[string]struct {
+		compareTo  *v1alpha1.QualityProfileComparisonTarget
+		search     *sonar.QualityprofilesSearch
+		compareErr error
+		want       want
+	}{
+		"ComparedWithNamedReference": {
+			compareTo: &v1alpha1.QualityProfileComparisonTarget{Name: ptr.To("Sonar way")},
+			search: &sonar.QualityprofilesSearch{Profiles: []sonar.QualityProfile{
+				{Key: "AU-SonarWay", Name: "Sonar way", Language: "java", IsBuiltIn: true},
+			}},
+			want: want{
+				searched: true,
+				comparison: &v1alpha1.QualityProfileComparisonObservation{
+					Key:                  "AU-SonarWay",
+					Name:                 "Sonar way",
+					OnlyInProfileCount:   1,
+					OnlyInProfile:        []string{"java:S3"},
+					OnlyInReferenceCount: 2,
+					OnlyInReference:      []string{"java:S1", "java:S2"},
+					ModifiedCount:        1,
+					Modified:             []string{"java:S4"},
+				},
+			},
+		},
+		"ComparedWithReferenceKey": {
+			compareTo: &v1alpha1.QualityProfileComparisonTarget{Key: ptr.To("AU-SonarWay")},
+			want: want{
+				comparison: &v1alpha1.QualityProfileComparisonObservation{
+					Key:                  "AU-SonarWay",
+					Name:                 "Sonar way",
+					OnlyInProfileCount:   1,
+					OnlyInProfile:        []string{"java:S3"},
+					OnlyInReferenceCount: 2,
+					OnlyInReference:      []string{"java:S1", "java:S2"},
+					ModifiedCount:        1,
+					Modified:             []string{"java:S4"},
+				},
+			},
+		},
+		"ReferenceNotFound": {
+			compareTo: &v1alpha1.QualityProfileComparisonTarget{Name: ptr.To("Sonar way")},
+			search:    &sonar.QualityprofilesSearch{},
+			want: want{
+				events:     []event.Event{event.Warning(reasonComparison, errors.Errorf(errReferenceNotFound, "Sonar way", "java"))},
+				searched:   true,
+				comparison: previous,
+			},
+		},
+		"FailedComparisonKeepsPrevious": {
+			compareTo:  &v1alpha1.QualityProfileComparisonTarget{Key: ptr.To("AU-SonarWay")},
+			compareErr: errors.New("boom"),
+			want: want{
+				events:     []event.Event{event.Warning(reasonComparison, errors.Wrap(errors.New("boom"), errCompareQualityProfile))},
+				comparison: previous,
+			},
+		},
+		"NoReferenceClearsComparison": {
+			want: want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			profile := &v1alpha1.QualityProfile{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-profile", Annotations: map[string]string{}},
+				Spec: v1alpha1.QualityProfileSpec{
+					ForProvider: v1alpha1.QualityProfileParameters{
+						Name:      "test-profile",
+						Language:  "java",
+						Default:   ptr.To(false),
+						CompareTo: tc.compareTo,
+					},
+				},
+				Status: v1alpha1.QualityProfileStatus{
+					AtProvider: v1alpha1.QualityProfileObservation{
+						ChangelogSince: ptr.To(metav1.Now()),
+						Comparison:     previous.DeepCopy(),
+					},
+				},
+			}
+			meta.SetExternalName(profile, "AU-TpxcA-iU5OvuD2FLz")
+
+			recorder := &eventRecorder{}
+			searched := false
+			e := &external{
+				recorder: recorder,
+				qualityProfilesClient: &fake.MockQualityProfilesClient{
+					ShowFn: func(opt *sonar.QualityprofilesShowOption) (*sonar.QualityprofilesShow, *http.Response, error) {
+						return &sonar.QualityprofilesShow{Profile: sonar.ShownProfile{Key: opt.Key, Name: "test-profile", Language: "java"}}, nil, nil
+					},
+					SearchFn: func(opt *sonar.QualityprofilesSearchOption) (*sonar.QualityprofilesSearch, *http.Response, error) {
+						searched = true
+
+						if opt.Language != "java" || opt.QualityProfile != "Sonar way" {
+							t.Errorf("Search() option = %+v, want the Sonar way Quality Profile of java", opt)
+						}
+
+						return tc.search, nil, nil
+					},
+					CompareFn: func(opt *sonar.QualityprofilesCompareOption) (*sonar.QualityprofilesCompare, *http.Response, error) {
+						want := &sonar.QualityprofilesCompareOption{LeftKey: "AU-TpxcA-iU5OvuD2FLz", RightKey: "AU-SonarWay"}
+						if diff := cmp.Diff(want, opt); diff != "" {
+							t.Errorf("Compare() option mismatch (-want +got):\n%s", diff)
+						}
+
+						if tc.compareErr != nil {
+							return nil, nil, tc.compareErr
+						}
+
+						return comparison, nil, nil
+					},
+				},
+				rulesClient: &fake.MockRulesClient{
+					SearchFn: func(opt *sonar.RulesSearchOption) (*sonar.RulesSearch, *http.Response, error) {
+						return &sonar.RulesSearch{}, nil, nil
+					},
+				},
+			}
+
+			got, err := e.Observe(context.Background(), profile)
+			if err != nil {
+				t.Fatalf("Observe() returned unexpected error: %v", err)
+			}
+
+			if !got.ResourceUpToDate {
+				t.Errorf("Observe() ResourceUpToDate = false, the comparison must not cause a drift")
+			}
+
+			if searched != tc.want.searched {
+				t.Errorf("Observe() searched the reference = %t, want %t", searched, tc.want.searched)
+			}
+
+			if diff := cmp.Diff(tc.want.events, recorder.events); diff != "" {
+				t.Errorf("Observe() events mismatch (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.comparison, profile.Status.AtProvider.Comparison, cmpopts.IgnoreFields(v1alpha1.QualityProfileComparisonObservation{}, "Time")); diff != "" {
+				t.Errorf("Observe() comparison mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
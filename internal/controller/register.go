@@ -21,6 +21,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/crossplane/provider-sonarqube/internal/controller/config"
+	"github.com/crossplane/provider-sonarqube/internal/controller/emailconfiguration"
 	"github.com/crossplane/provider-sonarqube/internal/controller/qualitygate"
 	"github.com/crossplane/provider-sonarqube/internal/controller/qualityprofile"
 	"github.com/crossplane/provider-sonarqube/internal/controller/settings"
@@ -31,6 +32,7 @@ import (
 func SetupGated(mgr ctrl.Manager, opts controller.Options) error {
 	for _, setup := range []func(ctrl.Manager, controller.Options) error{
 		config.Setup,
+		emailconfiguration.SetupGated,
 		qualitygate.SetupGated,
 		qualityprofile.SetupGated,
 		settings.SetupGated,
@@ -18,7 +18,10 @@ package settings
 
 import (
 	"context"
+	"slices"
+	"strings"
 
+	"github.com/boxboxjason/sonarqube-client-go/sonar"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/feature"
 
 	stderrors "errors"
@@ -46,6 +49,14 @@ const (
 	errNotSettings  = "managed resource is not a Settings custom resource"
 	errTrackPCUsage = "cannot track ProviderConfig usage"
 	errGetPC        = "cannot get ProviderConfig"
+	errNewClient    = "cannot create SonarQube client"
+
+	errResolveValueFrom = "cannot resolve valueFrom of setting %s"
+	errListDefinitions  = "failed to get settings definitions"
+	errInvalidSettings  = "invalid settings"
+
+	errResetAppliedSettings = "failed to reset settings applied by this resource"
+	errGetParentValues      = "failed to get parent settings values"
 )
 
 // SetupGated adds a controller that reconciles Settings managed resources with safe-start support.
@@ -112,7 +123,7 @@ func Setup(mgr ctrl.Manager, opts controller.Options) error {
 type connector struct {
 	kube         client.Client
 	usage        *resource.ProviderConfigUsageTracker
-	newServiceFn func(config common.Config) instance.SettingsClient
+	newServiceFn func(config common.Config) (instance.SettingsClient, error)
 }
 
 // Connect typically produces an ExternalClient by:
@@ -142,14 +153,19 @@ func (c *connector) Connect(ctx context.Context, managedResource resource.Manage
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	svc := c.newServiceFn(*config)
+	svc, err := c.newServiceFn(*config)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
 
-	return &external{settingsClient: svc}, nil
+	return &external{kube: c.kube, settingsClient: svc}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
+	// kube is used to resolve the Secrets and ConfigMaps referenced by valueFrom
+	kube client.Client
 	// settingsClient is used to interact with SonarQube Settings API
 	settingsClient instance.SettingsClient
 }
@@ -164,8 +180,16 @@ func (c *external) Observe(ctx context.Context, managedResource resource.Managed
 	// Deleting: SonarQube settings cannot be deleted; mark the
 	// external resource as non-existent so the managed reconciler can
 	// remove the finalizer and allow the CR to be deleted.
+	// In Additive mode, the resource exists until the settings it has applied have been reset by Delete.
 	if !settings.DeletionTimestamp.IsZero() {
-		return managed.ExternalObservation{ResourceExists: false}, nil
+		additive := instance.IsAdditiveManagementMode(settings.Spec.ForProvider)
+
+		return managed.ExternalObservation{ResourceExists: additive && len(settings.Status.AtProvider.AppliedKeys) > 0}, nil
+	}
+
+	params, secretHashes, err := c.resolveParameters(ctx, settings)
+	if err != nil {
+		return managed.ExternalObservation{}, err
 	}
 
 	sonarSettings, resp, err := c.settingsClient.Values(instance.GenerateSettingsValuesOptions(&settings.Spec.ForProvider)) //nolint:bodyclose // closed via helpers.CloseBody
@@ -176,11 +200,43 @@ func (c *external) Observe(ctx context.Context, managedResource resource.Managed
 	}
 
 	observation := instance.GenerateSettingsObservation(sonarSettings)
+
+	// The parent values of component settings are their global values.
+	var parentSettings *sonar.SettingsValues
+
+	if settings.Spec.ForProvider.Component != nil {
+		parents, parentResp, err := c.settingsClient.Values(instance.GenerateSettingsParentValuesOptions(&settings.Spec.ForProvider)) //nolint:bodyclose // closed via helpers.CloseBody
+		defer helpers.CloseBody(parentResp)
+
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetParentValues)
+		}
+
+		parentSettings = parents
+	}
+
+	instance.SetSettingsLevels(&observation, settings.Spec.ForProvider.Component, parentSettings)
+
+	// SonarQube may report secured settings that were not requested, only keep the ones managed by this resource.
+	for key, setting := range observation.Settings {
+		if _, isManaged := settings.Spec.ForProvider.Settings[key]; setting.Secured && !isManaged {
+			delete(observation.Settings, key)
+		}
+	}
+
+	instance.RedactSecretSourcedSettings(*params, secretHashes, &observation)
+	instance.CarryOverAppliedValueHashes(&observation, settings.Status.AtProvider)
+	observation.AppliedKeys = settings.Status.AtProvider.AppliedKeys
 	settings.Status.AtProvider = observation
 
+	upToDate := instance.AreSettingsUpToDate(*params, secretHashes, observation)
+	if instance.IsAdditiveManagementMode(settings.Spec.ForProvider) {
+		upToDate = upToDate && instance.AreAppliedKeysUpToDate(*params, observation)
+	}
+
 	return managed.ExternalObservation{
 		ResourceExists:   true,
-		ResourceUpToDate: instance.AreSettingsUpToDate(settings.Spec.ForProvider, observation),
+		ResourceUpToDate: upToDate,
 	}, nil
 }
 
@@ -193,15 +249,27 @@ func (c *external) Create(ctx context.Context, managedResource resource.Managed)
 
 	settings.SetConditions(xpv1.Creating())
 
-	errs := make([]error, 0, len(settings.Spec.ForProvider.Settings))
+	resolved, secretHashes, err := c.resolveParameters(ctx, settings)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	err = c.validateSettings(resolved)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	errs := make([]error, 0, len(resolved.Settings))
 
 	// Iterate over the settings in the CR and create them in SonarQube using the settingsClient.
-	for key, params := range settings.Spec.ForProvider.Settings {
-		settingSetOptions := instance.GenerateSettingSetOptions(key, params, settings.Spec.ForProvider.Component)
+	for key, params := range resolved.Settings {
+		settingSetOptions := instance.GenerateSettingSetOptions(key, params, resolved.Component)
 
 		resp, err := c.settingsClient.Set(settingSetOptions) //nolint:bodyclose // closed via helpers.CloseBody
 		if err != nil {
 			errs = append(errs, errors.Errorf("failed to set setting %s: %s", key, err.Error()))
+		} else {
+			recordAppliedValue(settings, key, instance.AppliedValueHash(params, secretHashes[key]))
 		}
 
 		helpers.CloseBody(resp)
@@ -226,11 +294,25 @@ func (c *external) Update(ctx context.Context, managedResource resource.Managed)
 		return managed.ExternalUpdate{}, errors.New(errNotSettings)
 	}
 
+	resolved, secretHashes, err := c.resolveParameters(ctx, settings)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	err = c.validateSettings(resolved)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
 	var updateErrors []error
 	// Update out of date settings
-	updateErrors = append(updateErrors, c.updateOutOfDateSettings(settings)...)
+	updateErrors = append(updateErrors, c.updateOutOfDateSettings(settings, resolved, secretHashes)...)
 	// Reset obsolete settings
-	updateErrors = append(updateErrors, c.resetObsoleteSettings(settings)...)
+	if instance.IsAdditiveManagementMode(settings.Spec.ForProvider) {
+		updateErrors = append(updateErrors, c.resetObsoleteAppliedSettings(settings)...)
+	} else {
+		updateErrors = append(updateErrors, c.resetObsoleteSettings(settings)...)
+	}
 
 	if len(updateErrors) == 0 {
 		return managed.ExternalUpdate{}, nil
@@ -252,6 +334,11 @@ func (c *external) Delete(ctx context.Context, managedResource resource.Managed)
 
 	settings.SetConditions(xpv1.Deleting())
 
+	// In Additive mode, only reset the settings applied by this resource, other owners may manage the remaining ones.
+	if instance.IsAdditiveManagementMode(settings.Spec.ForProvider) {
+		return managed.ExternalDelete{}, c.resetAppliedSettings(settings, slices.Clone(settings.Status.AtProvider.AppliedKeys))
+	}
+
 	// Reset the settings and then delete the resource. This ensures that we don't leave any orphaned settings in SonarQube after the resource is deleted.
 	settingsResetOptions := instance.GenerateSettingsResetOptions(settings.Spec.ForProvider)
 
@@ -270,17 +357,26 @@ func (c *external) Disconnect(ctx context.Context) error {
 	return nil
 }
 
-// updateOutOfDateSettings updates settings that are out of date by comparing the desired settings in the CR with the observed settings in SonarQube.
-func (c *external) updateOutOfDateSettings(settings *v1alpha1.Settings) []error {
+// updateOutOfDateSettings updates settings that are out of date by comparing the desired (resolved) settings with the observed settings in SonarQube.
+func (c *external) updateOutOfDateSettings(settings *v1alpha1.Settings, resolved *v1alpha1.SettingsParameters, secretHashes map[string]string) []error {
 	var updateErrors []error
 
-	for key, params := range settings.Spec.ForProvider.Settings {
-		if !instance.IsSettingUpToDate(params, settings.Status.AtProvider.Settings[key]) {
-			settingSetOptions := instance.GenerateSettingSetOptions(key, params, settings.Spec.ForProvider.Component)
+	additive := instance.IsAdditiveManagementMode(settings.Spec.ForProvider)
+
+	for key, params := range resolved.Settings {
+		// In Additive mode, a setting that already has the desired value is still applied once so that it is recorded as owned.
+		notApplied := additive && !slices.Contains(settings.Status.AtProvider.AppliedKeys, key)
+
+		observation := settings.Status.AtProvider.Settings[key]
+
+		if notApplied || !instance.IsSettingUpToDate(params, secretHashes[key], observation) || instance.NeedsExplicitStorage(*resolved, observation) {
+			settingSetOptions := instance.GenerateSettingSetOptions(key, params, resolved.Component)
 
 			resp, err := c.settingsClient.Set(settingSetOptions) //nolint:bodyclose // closed via helpers.CloseBody
 			if err != nil {
 				updateErrors = append(updateErrors, errors.Errorf("failed to update setting %s: %s", key, err.Error()))
+			} else {
+				recordAppliedValue(settings, key, instance.AppliedValueHash(params, secretHashes[key]))
 			}
 
 			helpers.CloseBody(resp)
@@ -290,6 +386,116 @@ func (c *external) updateOutOfDateSettings(settings *v1alpha1.Settings) []error
 	return updateErrors
 }
 
+// resolveParameters returns a copy of the desired settings where every valueFrom has been replaced by the value
+// of the referenced Secret or ConfigMap key. The spec itself is never modified so that resolved values are not persisted.
+// It also returns the hash of the version of the Secret key of each setting sourced from a Secret, which is recorded instead of their value.
+func (c *external) resolveParameters(ctx context.Context, settings *v1alpha1.Settings) (*v1alpha1.SettingsParameters, map[string]string, error) {
+	resolved := settings.Spec.ForProvider.DeepCopy()
+	secretHashes := make(map[string]string)
+
+	for key, params := range resolved.Settings {
+		if params.ValueFrom == nil {
+			continue
+		}
+
+		var (
+			value *string
+			err   error
+		)
+
+		switch {
+		case params.ValueFrom.SecretKeyRef != nil:
+			value, err = common.GetTokenValueFromLocalSecret(ctx, c.kube, settings, params.ValueFrom.SecretKeyRef)
+			if err == nil {
+				var version string
+
+				version, err = common.GetLocalSecretKeyVersion(ctx, c.kube, settings, params.ValueFrom.SecretKeyRef)
+				secretHashes[key] = instance.HashSecretKeyVersion(version)
+			}
+		case params.ValueFrom.ConfigMapKeyRef != nil:
+			value, err = common.GetValueFromLocalConfigMap(ctx, c.kube, settings, params.ValueFrom.ConfigMapKeyRef.Name, params.ValueFrom.ConfigMapKeyRef.Key)
+		default:
+			err = errors.New("one of secretKeyRef or configMapKeyRef must be set")
+		}
+
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, errResolveValueFrom, key)
+		}
+
+		resolved.Settings[key] = v1alpha1.SettingParameters{Value: value}
+	}
+
+	return resolved, secretHashes, nil
+}
+
+// validateSettings checks the resolved settings against the setting definitions of SonarQube before any of them is applied.
+// Every offending key is listed in the returned error, which is surfaced in the Synced condition.
+func (c *external) validateSettings(resolved *v1alpha1.SettingsParameters) error {
+	definitions, resp, err := c.settingsClient.ListDefinitions(instance.GenerateSettingsListDefinitionsOptions(resolved.Component)) //nolint:bodyclose // closed via helpers.CloseBody
+	defer helpers.CloseBody(resp)
+
+	if err != nil {
+		return errors.Wrap(err, errListDefinitions)
+	}
+
+	violations := instance.ValidateSettings(*resolved, definitions)
+	if len(violations) > 0 {
+		return errors.Errorf("%s: %s", errInvalidSettings, strings.Join(violations, "; "))
+	}
+
+	return nil
+}
+
+// resetObsoleteAppliedSettings resets the settings applied by this resource that are no longer in the desired settings in the CR.
+// It is used in Additive mode, where settings not applied by this resource are never reset.
+func (c *external) resetObsoleteAppliedSettings(settings *v1alpha1.Settings) []error {
+	obsolete := instance.ObsoleteAppliedKeys(settings.Spec.ForProvider, settings.Status.AtProvider)
+
+	err := c.resetAppliedSettings(settings, obsolete)
+	if err != nil {
+		return []error{err}
+	}
+
+	return nil
+}
+
+// resetAppliedSettings resets the given settings applied by this resource and removes them from the applied keys ledger.
+func (c *external) resetAppliedSettings(settings *v1alpha1.Settings, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	resp, err := c.settingsClient.Reset(instance.GenerateSettingsResetOptionsFromList(keys, settings.Spec.ForProvider.Component)) //nolint:bodyclose // closed via helpers.CloseBody
+	defer helpers.CloseBody(resp)
+
+	if err != nil {
+		return errors.Wrap(err, errResetAppliedSettings)
+	}
+
+	instance.ForgetAppliedKeys(&settings.Status.AtProvider, keys)
+
+	for _, key := range keys {
+		delete(settings.Status.AtProvider.Settings, key)
+	}
+
+	return nil
+}
+
+// recordAppliedValue records the hash of the value applied for the given setting in the status.
+func recordAppliedValue(settings *v1alpha1.Settings, key, hash string) {
+	if settings.Status.AtProvider.Settings == nil {
+		settings.Status.AtProvider.Settings = make(map[string]v1alpha1.SettingObservation)
+	}
+
+	observation := settings.Status.AtProvider.Settings[key]
+	observation.AppliedValueHash = hash
+	settings.Status.AtProvider.Settings[key] = observation
+
+	if instance.IsAdditiveManagementMode(settings.Spec.ForProvider) {
+		instance.RecordAppliedKey(&settings.Status.AtProvider, key)
+	}
+}
+
 // resetObsoleteSettings resets any settings that are not in the desired settings in the CR. This ensures that any settings that were manually changed in SonarQube or removed from the CR are reset to their default values.
 func (c *external) resetObsoleteSettings(settings *v1alpha1.Settings) []error {
 	var resetErrors []error
@@ -23,14 +23,20 @@ import (
 	"time"
 
 	"github.com/boxboxjason/sonarqube-client-go/sonar"
+	xpv1 "github.com/crossplane/crossplane-runtime/v2/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/v2/pkg/resource"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	v1alpha1 "github.com/crossplane/provider-sonarqube/apis/instance/v1alpha1"
+	"github.com/crossplane/provider-sonarqube/internal/clients/instance"
 	"github.com/crossplane/provider-sonarqube/internal/fake"
 )
 
@@ -67,6 +73,33 @@ func errComparer(a, b error) bool {
 	return a.Error() == b.Error()
 }
 
+// definitionsFor returns a ListDefinitionsFn declaring every setting of the given Settings resource as valid.
+func definitionsFor(mg resource.Managed) func(opt *sonar.SettingsListDefinitionsOption) (*sonar.SettingsListDefinitions, *http.Response, error) {
+	return func(opt *sonar.SettingsListDefinitionsOption) (*sonar.SettingsListDefinitions, *http.Response, error) {
+		definitions := &sonar.SettingsListDefinitions{}
+
+		settings, ok := mg.(*v1alpha1.Settings)
+		if !ok {
+			return definitions, nil, nil
+		}
+
+		for key, params := range settings.Spec.ForProvider.Settings {
+			definition := sonar.SettingDefinition{Key: key, Type: "STRING", MultiValues: params.Values != nil}
+
+			if params.FieldValues != nil {
+				definition.Type = "PROPERTY_SET"
+				for field := range *params.FieldValues {
+					definition.Fields = append(definition.Fields, sonar.SettingField{Key: field, Type: "STRING"})
+				}
+			}
+
+			definitions.Definitions = append(definitions.Definitions, definition)
+		}
+
+		return definitions, nil, nil
+	}
+}
+
 //nolint:maintidx // Test function complexity is acceptable for comprehensive table-driven tests
 func TestObserve(t *testing.T) {
 	t.Parallel()
@@ -321,6 +354,18 @@ func TestObserve(t *testing.T) {
 		"ObserveWithComponent": {
 			client: &fake.MockSettingsClient{
 				ValuesFn: func(opt *sonar.SettingsValuesOption) (*sonar.SettingsValues, *http.Response, error) {
+					// The parent values are fetched without component
+					if opt.Component == "" {
+						return &sonar.SettingsValues{
+							Settings: []sonar.SettingValue{
+								{
+									Key:       "sonar.coverage.jacoco.xmlReportPaths",
+									Inherited: true,
+								},
+							},
+						}, nil, nil
+					}
+
 					// Verify component is passed correctly
 					if opt.Component != "my-project-key" {
 						return nil, nil, errors.New("expected component to be 'my-project-key'")
@@ -412,6 +457,69 @@ func TestCreate(t *testing.T) {
 				err: errors.New(errNotSettings),
 			},
 		},
+		"ListDefinitionsFails": {
+			client: &fake.MockSettingsClient{
+				ListDefinitionsFn: func(opt *sonar.SettingsListDefinitionsOption) (*sonar.SettingsListDefinitions, *http.Response, error) {
+					return nil, nil, errors.New("api error")
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				mg: &v1alpha1.Settings{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-settings"},
+					Spec: v1alpha1.SettingsSpec{
+						ForProvider: v1alpha1.SettingsParameters{
+							Settings: map[string]v1alpha1.SettingParameters{
+								"sonar.core.serverBaseURL": {
+									Value: ptr.To("https://sonarqube.example.com"),
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.Wrap(errors.New("api error"), errListDefinitions),
+			},
+		},
+		"InvalidSettingsAreNotApplied": {
+			client: &fake.MockSettingsClient{
+				ListDefinitionsFn: func(opt *sonar.SettingsListDefinitionsOption) (*sonar.SettingsListDefinitions, *http.Response, error) {
+					return &sonar.SettingsListDefinitions{
+						Definitions: []sonar.SettingDefinition{
+							{Key: "sonar.core.serverBaseURL", Type: "STRING"},
+							{Key: "sonar.forceAuthentication", Type: "BOOLEAN"},
+						},
+					}, nil, nil
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				mg: &v1alpha1.Settings{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-settings"},
+					Spec: v1alpha1.SettingsSpec{
+						ForProvider: v1alpha1.SettingsParameters{
+							Settings: map[string]v1alpha1.SettingParameters{
+								"sonar.core.serverBaseURL": {
+									Value: ptr.To("https://sonarqube.example.com"),
+								},
+								"sonar.forceAuthentication": {
+									Value: ptr.To("yes"),
+								},
+								"sonar.unknown": {
+									Value: ptr.To("value"),
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				o:   managed.ExternalCreation{},
+				err: errors.New(errInvalidSettings + `: sonar.forceAuthentication: value "yes" is not a boolean; sonar.unknown: unknown setting key`),
+			},
+		},
 		"SetFailsForSingleSetting": {
 			client: &fake.MockSettingsClient{
 				SetFn: func(opt *sonar.SettingsSetOption) (*http.Response, error) {
@@ -619,6 +727,10 @@ func TestCreate(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
+			if tc.client.ListDefinitionsFn == nil {
+				tc.client.ListDefinitionsFn = definitionsFor(tc.args.mg)
+			}
+
 			e := &external{settingsClient: tc.client}
 			got, err := e.Create(tc.args.ctx, tc.args.mg)
 
@@ -976,6 +1088,10 @@ func TestUpdate(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 
+			if tc.client.ListDefinitionsFn == nil {
+				tc.client.ListDefinitionsFn = definitionsFor(tc.args.mg)
+			}
+
 			e := &external{settingsClient: tc.client}
 			got, err := e.Update(tc.args.ctx, tc.args.mg)
 
@@ -1177,3 +1293,377 @@ func TestDisconnect(t *testing.T) {
 		t.Errorf("Disconnect() returned unexpected error: %v", err)
 	}
 }
+
+func TestValueFrom(t *testing.T) {
+	t.Parallel()
+
+	const securedKey = "sonar.auth.github.clientSecret.secured"
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	newKube := func(secret, resourceVersion string) client.Client {
+		return fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "default", UID: "github-uid", ResourceVersion: resourceVersion},
+				Data:       map[string][]byte{"clientSecret": []byte(secret)},
+			},
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "urls", Namespace: "default"},
+				Data:       map[string]string{"serverBaseURL": "https://sonarqube.example.com"},
+			},
+		).Build()
+	}
+
+	newSettings := func(appliedHash string) *v1alpha1.Settings {
+		return &v1alpha1.Settings{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-settings", Namespace: "default"},
+			Spec: v1alpha1.SettingsSpec{
+				ForProvider: v1alpha1.SettingsParameters{
+					Settings: map[string]v1alpha1.SettingParameters{
+						securedKey: {
+							ValueFrom: &v1alpha1.SettingValueSource{
+								SecretKeyRef: &xpv1.LocalSecretKeySelector{
+									LocalSecretReference: xpv1.LocalSecretReference{Name: "github"},
+									Key:                  "clientSecret",
+								},
+							},
+						},
+						"sonar.core.serverBaseURL": {
+							ValueFrom: &v1alpha1.SettingValueSource{
+								ConfigMapKeyRef: &v1alpha1.LocalConfigMapKeySelector{Name: "urls", Key: "serverBaseURL"},
+							},
+						},
+					},
+				},
+			},
+			Status: v1alpha1.SettingsStatus{
+				AtProvider: v1alpha1.SettingsObservation{
+					Settings: map[string]v1alpha1.SettingObservation{
+						securedKey: {Secured: true, AppliedValueHash: appliedHash},
+					},
+				},
+			},
+		}
+	}
+
+	values := func(opt *sonar.SettingsValuesOption) (*sonar.SettingsValues, *http.Response, error) {
+		return &sonar.SettingsValues{
+			Settings:           []sonar.SettingValue{{Key: "sonar.core.serverBaseURL", Value: "https://sonarqube.example.com"}},
+			SetSecuredSettings: []string{securedKey, "sonar.other.secured"},
+		}, nil, nil
+	}
+
+	secretHash := func(resourceVersion string) string {
+		return instance.HashSecretKeyVersion("github-uid/" + resourceVersion + "/clientSecret")
+	}
+
+	appliedHash := secretHash("1")
+
+	t.Run("ObserveUpToDate", func(t *testing.T) {
+		t.Parallel()
+
+		settings := newSettings(appliedHash)
+		e := &external{kube: newKube("s3cr3t", "1"), settingsClient: &fake.MockSettingsClient{ValuesFn: values}}
+
+		got, err := e.Observe(context.Background(), settings)
+		if err != nil {
+			t.Fatalf("Observe() returned unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, got); diff != "" {
+			t.Errorf("Observe() mismatch (-want +got):\n%s", diff)
+		}
+
+		if _, exists := settings.Status.AtProvider.Settings["sonar.other.secured"]; exists {
+			t.Errorf("Observe() kept a secured setting that is not managed by the resource")
+		}
+
+		if settings.Spec.ForProvider.Settings[securedKey].Value != nil {
+			t.Errorf("Observe() wrote the resolved secret value into the spec")
+		}
+	})
+
+	t.Run("ObserveSecretRotated", func(t *testing.T) {
+		t.Parallel()
+
+		e := &external{kube: newKube("rotated", "2"), settingsClient: &fake.MockSettingsClient{ValuesFn: values}}
+
+		got, err := e.Observe(context.Background(), newSettings(appliedHash))
+		if err != nil {
+			t.Fatalf("Observe() returned unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, got); diff != "" {
+			t.Errorf("Observe() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ObserveSecretMissing", func(t *testing.T) {
+		t.Parallel()
+
+		kube := fakeclient.NewClientBuilder().WithScheme(scheme).Build()
+		e := &external{kube: kube, settingsClient: &fake.MockSettingsClient{ValuesFn: values}}
+
+		_, err := e.Observe(context.Background(), newSettings(appliedHash))
+		if err == nil {
+			t.Fatal("Observe() expected an error when the referenced secret is missing")
+		}
+	})
+
+	t.Run("ObserveRedactsSecretValue", func(t *testing.T) {
+		t.Parallel()
+
+		settings := newSettings(appliedHash)
+		settings.Spec.ForProvider.Settings["sonar.core.serverBaseURL"] = v1alpha1.SettingParameters{
+			ValueFrom: &v1alpha1.SettingValueSource{
+				SecretKeyRef: &xpv1.LocalSecretKeySelector{
+					LocalSecretReference: xpv1.LocalSecretReference{Name: "github"},
+					Key:                  "clientSecret",
+				},
+			},
+		}
+
+		e := &external{kube: newKube("https://sonarqube.example.com", "1"), settingsClient: &fake.MockSettingsClient{ValuesFn: values}}
+
+		got, err := e.Observe(context.Background(), settings)
+		if err != nil {
+			t.Fatalf("Observe() returned unexpected error: %v", err)
+		}
+
+		if !got.ResourceUpToDate {
+			t.Errorf("Observe() ResourceUpToDate = false, want true")
+		}
+
+		want := v1alpha1.SettingObservation{
+			ValueHash: appliedHash,
+			Level:     instance.SettingLevelGlobal,
+		}
+		if diff := cmp.Diff(want, settings.Status.AtProvider.Settings["sonar.core.serverBaseURL"]); diff != "" {
+			t.Errorf("Observe() status mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("UpdateWritesRotatedSecret", func(t *testing.T) {
+		t.Parallel()
+
+		var set []*sonar.SettingsSetOption
+
+		settings := newSettings(appliedHash)
+		settings.Status.AtProvider.Settings["sonar.core.serverBaseURL"] = v1alpha1.SettingObservation{Value: "https://sonarqube.example.com"}
+
+		e := &external{
+			kube: newKube("rotated", "2"),
+			settingsClient: &fake.MockSettingsClient{
+				ListDefinitionsFn: definitionsFor(settings),
+				SetFn: func(opt *sonar.SettingsSetOption) (*http.Response, error) {
+					set = append(set, opt)
+
+					return nil, nil
+				},
+			},
+		}
+
+		_, err := e.Update(context.Background(), settings)
+		if err != nil {
+			t.Fatalf("Update() returned unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff([]*sonar.SettingsSetOption{{Key: securedKey, Value: "rotated"}}, set); diff != "" {
+			t.Errorf("Update() set mismatch (-want +got):\n%s", diff)
+		}
+
+		want := secretHash("2")
+		if got := settings.Status.AtProvider.Settings[securedKey].AppliedValueHash; got != want {
+			t.Errorf("Update() recorded hash %q, want %q", got, want)
+		}
+	})
+}
+
+func TestAdditiveManagementMode(t *testing.T) {
+	t.Parallel()
+
+	newSettings := func(appliedKeys ...string) *v1alpha1.Settings {
+		return &v1alpha1.Settings{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-settings", Namespace: "default"},
+			Spec: v1alpha1.SettingsSpec{
+				ForProvider: v1alpha1.SettingsParameters{
+					Component:      ptr.To("my-project"),
+					ManagementMode: ptr.To(instance.SettingsManagementModeAdditive),
+					Settings: map[string]v1alpha1.SettingParameters{
+						"sonar.exclusions": {Values: &[]string{"**/vendor/**"}},
+					},
+				},
+			},
+			Status: v1alpha1.SettingsStatus{
+				AtProvider: v1alpha1.SettingsObservation{
+					Settings: map[string]v1alpha1.SettingObservation{
+						"sonar.exclusions": {Values: []string{"**/vendor/**"}},
+					},
+					AppliedKeys: appliedKeys,
+				},
+			},
+		}
+	}
+
+	values := func(opt *sonar.SettingsValuesOption) (*sonar.SettingsValues, *http.Response, error) {
+		return &sonar.SettingsValues{
+			Settings: []sonar.SettingValue{{Key: "sonar.exclusions", Values: []string{"**/vendor/**"}}},
+		}, nil, nil
+	}
+
+	t.Run("ObserveUnrecordedSettingIsNotUpToDate", func(t *testing.T) {
+		t.Parallel()
+
+		e := &external{settingsClient: &fake.MockSettingsClient{ValuesFn: values}}
+
+		got, err := e.Observe(context.Background(), newSettings())
+		if err != nil {
+			t.Fatalf("Observe() returned unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, got); diff != "" {
+			t.Errorf("Observe() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ObserveKeepsLedger", func(t *testing.T) {
+		t.Parallel()
+
+		settings := newSettings("sonar.exclusions")
+		e := &external{settingsClient: &fake.MockSettingsClient{ValuesFn: values}}
+
+		got, err := e.Observe(context.Background(), settings)
+		if err != nil {
+			t.Fatalf("Observe() returned unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, got); diff != "" {
+			t.Errorf("Observe() mismatch (-want +got):\n%s", diff)
+		}
+
+		if diff := cmp.Diff([]string{"sonar.exclusions"}, settings.Status.AtProvider.AppliedKeys); diff != "" {
+			t.Errorf("Observe() applied keys mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("ObserveDeletingWithAppliedKeys", func(t *testing.T) {
+		t.Parallel()
+
+		settings := newSettings("sonar.exclusions")
+		settings.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+		e := &external{settingsClient: &fake.MockSettingsClient{}}
+
+		got, err := e.Observe(context.Background(), settings)
+		if err != nil {
+			t.Fatalf("Observe() returned unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true}, got); diff != "" {
+			t.Errorf("Observe() mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("UpdateResetsOnlyObsoleteAppliedKeys", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			set   []string
+			reset []*sonar.SettingsResetOption
+		)
+
+		// sonar.exclusions already has the desired value but has not been recorded yet,
+		// sonar.inclusions was applied by this resource and is no longer desired.
+		settings := newSettings("sonar.inclusions")
+
+		e := &external{
+			settingsClient: &fake.MockSettingsClient{
+				ListDefinitionsFn: definitionsFor(settings),
+				SetFn: func(opt *sonar.SettingsSetOption) (*http.Response, error) {
+					set = append(set, opt.Key)
+
+					return nil, nil
+				},
+				ResetFn: func(opt *sonar.SettingsResetOption) (*http.Response, error) {
+					reset = append(reset, opt)
+
+					return nil, nil
+				},
+			},
+		}
+
+		_, err := e.Update(context.Background(), settings)
+		if err != nil {
+			t.Fatalf("Update() returned unexpected error: %v", err)
+		}
+
+		if diff := cmp.Diff([]string{"sonar.exclusions"}, set); diff != "" {
+			t.Errorf("Update() set keys mismatch (-want +got):\n%s", diff)
+		}
+
+		wantReset := []*sonar.SettingsResetOption{{Keys: []string{"sonar.inclusions"}, Component: "my-project"}}
+		if diff := cmp.Diff(wantReset, reset); diff != "" {
+			t.Errorf("Update() reset mismatch (-want +got):\n%s", diff)
+		}
+
+		if diff := cmp.Diff([]string{"sonar.exclusions"}, settings.Status.AtProvider.AppliedKeys); diff != "" {
+			t.Errorf("Update() applied keys mismatch (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("DeleteResetsOnlyAppliedKeys", func(t *testing.T) {
+		t.Parallel()
+
+		var reset []*sonar.SettingsResetOption
+
+		settings := newSettings("sonar.exclusions", "sonar.inclusions")
+
+		e := &external{
+			settingsClient: &fake.MockSettingsClient{
+				ResetFn: func(opt *sonar.SettingsResetOption) (*http.Response, error) {
+					reset = append(reset, opt)
+
+					return nil, nil
+				},
+			},
+		}
+
+		_, err := e.Delete(context.Background(), settings)
+		if err != nil {
+			t.Fatalf("Delete() returned unexpected error: %v", err)
+		}
+
+		wantReset := []*sonar.SettingsResetOption{{Keys: []string{"sonar.exclusions", "sonar.inclusions"}, Component: "my-project"}}
+		if diff := cmp.Diff(wantReset, reset); diff != "" {
+			t.Errorf("Delete() reset mismatch (-want +got):\n%s", diff)
+		}
+
+		if len(settings.Status.AtProvider.AppliedKeys) != 0 {
+			t.Errorf("Delete() did not clear the applied keys: %v", settings.Status.AtProvider.AppliedKeys)
+		}
+	})
+
+	t.Run("DeleteResetFails", func(t *testing.T) {
+		t.Parallel()
+
+		settings := newSettings("sonar.exclusions")
+
+		e := &external{
+			settingsClient: &fake.MockSettingsClient{
+				ResetFn: func(opt *sonar.SettingsResetOption) (*http.Response, error) {
+					return nil, errors.New("api error")
+				},
+			},
+		}
+
+		_, err := e.Delete(context.Background(), settings)
+		if diff := cmp.Diff(errors.Wrap(errors.New("api error"), errResetAppliedSettings), err, cmp.Comparer(errComparer)); diff != "" {
+			t.Errorf("Delete() error mismatch (-want +got):\n%s", diff)
+		}
+
+		if diff := cmp.Diff([]string{"sonar.exclusions"}, settings.Status.AtProvider.AppliedKeys); diff != "" {
+			t.Errorf("Delete() applied keys mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
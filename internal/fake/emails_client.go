@@ -0,0 +1,44 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/boxboxjason/sonarqube-client-go/sonar"
+	"github.com/crossplane/provider-sonarqube/internal/clients/instance"
+)
+
+var errEmailsNotImplemented = errors.New("emails operation not implemented")
+
+// MockEmailsClient is a mock implementation of the EmailsClient interface.
+type MockEmailsClient struct {
+	SendFn func(opt *sonar.EmailsSendOption) (*http.Response, error)
+}
+
+// Ensure MockEmailsClient implements EmailsClient.
+var _ instance.EmailsClient = &MockEmailsClient{}
+
+// Send implements EmailsClient.Send.
+func (m *MockEmailsClient) Send(opt *sonar.EmailsSendOption) (*http.Response, error) {
+	if m.SendFn != nil {
+		return m.SendFn(opt)
+	}
+
+	return nil, errEmailsNotImplemented
+}
@@ -28,32 +28,36 @@ var errQualityProfileNotImplemented = errors.New("not implemented")
 
 // MockQualityProfilesClient is a mock implementation of the QualityProfilesClient interface.
 type MockQualityProfilesClient struct {
-	ActivateRuleFn    func(opt *sonar.QualityprofilesActivateRuleOption) (resp *http.Response, err error)
-	ActivateRulesFn   func(opt *sonar.QualityprofilesActivateRulesOption) (resp *http.Response, err error)
-	AddGroupFn        func(opt *sonar.QualityprofilesAddGroupOption) (resp *http.Response, err error)
-	AddProjectFn      func(opt *sonar.QualityprofilesAddProjectOption) (resp *http.Response, err error)
-	AddUserFn         func(opt *sonar.QualityprofilesAddUserOption) (resp *http.Response, err error)
-	BackupFn          func(opt *sonar.QualityprofilesBackupOption) (v *string, resp *http.Response, err error)
-	ChangeParentFn    func(opt *sonar.QualityprofilesChangeParentOption) (resp *http.Response, err error)
-	ChangelogFn       func(opt *sonar.QualityprofilesChangelogOption) (v *sonar.QualityprofilesChangelog, resp *http.Response, err error)
-	CompareFn         func(opt *sonar.QualityprofilesCompareOption) (v *sonar.QualityprofilesCompare, resp *http.Response, err error)
-	CopyFn            func(opt *sonar.QualityprofilesCopyOption) (v *sonar.QualityprofilesCopy, resp *http.Response, err error)
-	CreateFn          func(opt *sonar.QualityprofilesCreateOption) (v *sonar.QualityprofilesCreate, resp *http.Response, err error)
-	DeactivateRuleFn  func(opt *sonar.QualityprofilesDeactivateRuleOption) (resp *http.Response, err error)
-	DeactivateRulesFn func(opt *sonar.QualityprofilesDeactivateRulesOption) (resp *http.Response, err error)
-	DeleteFn          func(opt *sonar.QualityprofilesDeleteOption) (resp *http.Response, err error)
-	InheritanceFn     func(opt *sonar.QualityprofilesInheritanceOption) (v *sonar.QualityprofilesInheritance, resp *http.Response, err error)
-	ProjectsFn        func(opt *sonar.QualityprofilesProjectsOption) (v *sonar.QualityprofilesProjects, resp *http.Response, err error)
-	RemoveGroupFn     func(opt *sonar.QualityprofilesRemoveGroupOption) (resp *http.Response, err error)
-	RemoveProjectFn   func(opt *sonar.QualityprofilesRemoveProjectOption) (resp *http.Response, err error)
-	RemoveUserFn      func(opt *sonar.QualityprofilesRemoveUserOption) (resp *http.Response, err error)
-	RenameFn          func(opt *sonar.QualityprofilesRenameOption) (resp *http.Response, err error)
-	RestoreFn         func(opt *sonar.QualityprofilesRestoreOption) (resp *http.Response, err error)
-	SearchFn          func(opt *sonar.QualityprofilesSearchOption) (v *sonar.QualityprofilesSearch, resp *http.Response, err error)
-	SearchGroupsFn    func(opt *sonar.QualityprofilesSearchGroupsOption) (v *sonar.QualityprofilesSearchGroups, resp *http.Response, err error)
-	SearchUsersFn     func(opt *sonar.QualityprofilesSearchUsersOption) (v *sonar.QualityprofilesSearchUsers, resp *http.Response, err error)
-	SetDefaultFn      func(opt *sonar.QualityprofilesSetDefaultOption) (resp *http.Response, err error)
-	ShowFn            func(opt *sonar.QualityprofilesShowOption) (v *sonar.QualityprofilesShow, resp *http.Response, err error)
+	ActivateRuleFn        func(opt *sonar.QualityprofilesActivateRuleOption) (resp *http.Response, err error)
+	ActivateRulesFn       func(opt *sonar.QualityprofilesActivateRulesOption) (resp *http.Response, err error)
+	AddGroupFn            func(opt *sonar.QualityprofilesAddGroupOption) (resp *http.Response, err error)
+	AddProjectFn          func(opt *sonar.QualityprofilesAddProjectOption) (resp *http.Response, err error)
+	AddUserFn             func(opt *sonar.QualityprofilesAddUserOption) (resp *http.Response, err error)
+	BackupFn              func(opt *sonar.QualityprofilesBackupOption) (v *string, resp *http.Response, err error)
+	ChangeParentFn        func(opt *sonar.QualityprofilesChangeParentOption) (resp *http.Response, err error)
+	ChangelogFn           func(opt *sonar.QualityprofilesChangelogOption) (v *sonar.QualityprofilesChangelog, resp *http.Response, err error)
+	CompareFn             func(opt *sonar.QualityprofilesCompareOption) (v *sonar.QualityprofilesCompare, resp *http.Response, err error)
+	CopyFn                func(opt *sonar.QualityprofilesCopyOption) (v *sonar.QualityprofilesCopy, resp *http.Response, err error)
+	CreateFn              func(opt *sonar.QualityprofilesCreateOption) (v *sonar.QualityprofilesCreate, resp *http.Response, err error)
+	DeactivateRuleFn      func(opt *sonar.QualityprofilesDeactivateRuleOption) (resp *http.Response, err error)
+	DeactivateRulesFn     func(opt *sonar.QualityprofilesDeactivateRulesOption) (resp *http.Response, err error)
+	DeleteFn              func(opt *sonar.QualityprofilesDeleteOption) (resp *http.Response, err error)
+	InheritanceFn         func(opt *sonar.QualityprofilesInheritanceOption) (v *sonar.QualityprofilesInheritance, resp *http.Response, err error)
+	ProjectsFn            func(opt *sonar.QualityprofilesProjectsOption) (v *sonar.QualityprofilesProjects, resp *http.Response, err error)
+	RemoveGroupFn         func(opt *sonar.QualityprofilesRemoveGroupOption) (resp *http.Response, err error)
+	RemoveProjectFn       func(opt *sonar.QualityprofilesRemoveProjectOption) (resp *http.Response, err error)
+	RemoveUserFn          func(opt *sonar.QualityprofilesRemoveUserOption) (resp *http.Response, err error)
+	RenameFn              func(opt *sonar.QualityprofilesRenameOption) (resp *http.Response, err error)
+	RestoreFn             func(opt *sonar.QualityprofilesRestoreOption) (resp *http.Response, err error)
+	SearchFn              func(opt *sonar.QualityprofilesSearchOption) (v *sonar.QualityprofilesSearch, resp *http.Response, err error)
+	SearchGroupsFn        func(opt *sonar.QualityprofilesSearchGroupsOption) (v *sonar.QualityprofilesSearchGroups, resp *http.Response, err error)
+	SearchUsersFn         func(opt *sonar.QualityprofilesSearchUsersOption) (v *sonar.QualityprofilesSearchUsers, resp *http.Response, err error)
+	SetDefaultFn          func(opt *sonar.QualityprofilesSetDefaultOption) (resp *http.Response, err error)
+	ShowFn                func(opt *sonar.QualityprofilesShowOption) (v *sonar.QualityprofilesShow, resp *http.Response, err error)
+	BulkActivateRulesFn   func(opt *sonar.QualityprofilesActivateRulesOption) (v *instance.QualityProfilesBulkChange, resp *http.Response, err error)
+	BulkDeactivateRulesFn func(opt *sonar.QualityprofilesDeactivateRulesOption) (v *instance.QualityProfilesBulkChange, resp *http.Response, err error)
+	ChangelogEventsFn     func(opt *sonar.QualityprofilesChangelogOption) (v *instance.QualityProfilesChangelog, resp *http.Response, err error)
+	RestoreBackupFn       func(backup string) (v *instance.QualityProfilesRestore, resp *http.Response, err error)
 }
 
 // Ensure MockQualityProfilesClient implements QualityProfilesClient.
@@ -292,3 +296,39 @@ func (m *MockQualityProfilesClient) Show(opt *sonar.QualityprofilesShowOption) (
 
 	return nil, nil, errQualityProfileNotImplemented
 }
+
+// BulkActivateRules implements QualityProfilesClient.BulkActivateRules.
+func (m *MockQualityProfilesClient) BulkActivateRules(opt *sonar.QualityprofilesActivateRulesOption) (v *instance.QualityProfilesBulkChange, resp *http.Response, err error) {
+	if m.BulkActivateRulesFn != nil {
+		return m.BulkActivateRulesFn(opt)
+	}
+
+	return nil, nil, errQualityProfileNotImplemented
+}
+
+// BulkDeactivateRules implements QualityProfilesClient.BulkDeactivateRules.
+func (m *MockQualityProfilesClient) BulkDeactivateRules(opt *sonar.QualityprofilesDeactivateRulesOption) (v *instance.QualityProfilesBulkChange, resp *http.Response, err error) {
+	if m.BulkDeactivateRulesFn != nil {
+		return m.BulkDeactivateRulesFn(opt)
+	}
+
+	return nil, nil, errQualityProfileNotImplemented
+}
+
+// ChangelogEvents implements QualityProfilesClient.ChangelogEvents.
+func (m *MockQualityProfilesClient) ChangelogEvents(opt *sonar.QualityprofilesChangelogOption) (v *instance.QualityProfilesChangelog, resp *http.Response, err error) {
+	if m.ChangelogEventsFn != nil {
+		return m.ChangelogEventsFn(opt)
+	}
+
+	return nil, nil, errQualityProfileNotImplemented
+}
+
+// RestoreBackup implements QualityProfilesClient.RestoreBackup.
+func (m *MockQualityProfilesClient) RestoreBackup(backup string) (v *instance.QualityProfilesRestore, resp *http.Response, err error) {
+	if m.RestoreBackupFn != nil {
+		return m.RestoreBackupFn(backup)
+	}
+
+	return nil, nil, errQualityProfileNotImplemented
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/boxboxjason/sonarqube-client-go/sonar"
+	"github.com/crossplane/provider-sonarqube/internal/clients/instance"
+)
+
+var errServerNotImplemented = errors.New("server operation not implemented")
+
+// MockServerClient is a mock implementation of the ServerClient interface.
+type MockServerClient struct {
+	ValidateFn func() (v *sonar.AuthenticationValidation, resp *http.Response, err error)
+	VersionFn  func() (v *string, resp *http.Response, err error)
+	StatusFn   func() (v *sonar.SystemStatus, resp *http.Response, err error)
+	GlobalFn   func() (v *sonar.NavigationGlobal, resp *http.Response, err error)
+}
+
+// Ensure MockServerClient implements ServerClient.
+var _ instance.ServerClient = &MockServerClient{}
+
+// Validate implements ServerClient.Validate.
+func (m *MockServerClient) Validate() (*sonar.AuthenticationValidation, *http.Response, error) {
+	if m.ValidateFn != nil {
+		return m.ValidateFn()
+	}
+
+	return nil, nil, errServerNotImplemented
+}
+
+// Version implements ServerClient.Version.
+func (m *MockServerClient) Version() (*string, *http.Response, error) {
+	if m.VersionFn != nil {
+		return m.VersionFn()
+	}
+
+	return nil, nil, errServerNotImplemented
+}
+
+// Status implements ServerClient.Status.
+func (m *MockServerClient) Status() (*sonar.SystemStatus, *http.Response, error) {
+	if m.StatusFn != nil {
+		return m.StatusFn()
+	}
+
+	return nil, nil, errServerNotImplemented
+}
+
+// Global implements ServerClient.Global.
+func (m *MockServerClient) Global() (*sonar.NavigationGlobal, *http.Response, error) {
+	if m.GlobalFn != nil {
+		return m.GlobalFn()
+	}
+
+	return nil, nil, errServerNotImplemented
+}
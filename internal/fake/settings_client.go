@@ -28,9 +28,10 @@ var errSettingsNotImplemented = errors.New("settings operation not implemented")
 
 // MockSettingsClient is a mock implementation of the SettingsClient interface.
 type MockSettingsClient struct {
-	SetFn    func(opt *sonar.SettingsSetOption) (*http.Response, error)
-	ValuesFn func(opt *sonar.SettingsValuesOption) (*sonar.SettingsValues, *http.Response, error)
-	ResetFn  func(opt *sonar.SettingsResetOption) (*http.Response, error)
+	SetFn             func(opt *sonar.SettingsSetOption) (*http.Response, error)
+	ValuesFn          func(opt *sonar.SettingsValuesOption) (*sonar.SettingsValues, *http.Response, error)
+	ResetFn           func(opt *sonar.SettingsResetOption) (*http.Response, error)
+	ListDefinitionsFn func(opt *sonar.SettingsListDefinitionsOption) (*sonar.SettingsListDefinitions, *http.Response, error)
 }
 
 // Ensure MockSettingsClient implements SettingsClient.
@@ -62,3 +63,12 @@ func (m *MockSettingsClient) Reset(opt *sonar.SettingsResetOption) (*http.Respon
 
 	return nil, errSettingsNotImplemented
 }
+
+// ListDefinitions implements SettingsClient.ListDefinitions.
+func (m *MockSettingsClient) ListDefinitions(opt *sonar.SettingsListDefinitionsOption) (*sonar.SettingsListDefinitions, *http.Response, error) {
+	if m.ListDefinitionsFn != nil {
+		return m.ListDefinitionsFn(opt)
+	}
+
+	return nil, nil, errSettingsNotImplemented
+}